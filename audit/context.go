@@ -0,0 +1,25 @@
+// Package audit carries where a request came from through its context and
+// computes the changes recorded in the audit log.
+package audit
+
+import "context"
+
+// Request identifies the HTTP request an operation runs for.
+type Request struct {
+	ID string
+	IP string
+}
+
+type requestKey struct{}
+
+// WithRequest returns a copy of ctx carrying req.
+func WithRequest(ctx context.Context, req Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, req)
+}
+
+// RequestFromContext returns the request carried by ctx, which is empty
+// for operations no request started, like scheduled jobs.
+func RequestFromContext(ctx context.Context) Request {
+	req, _ := ctx.Value(requestKey{}).(Request)
+	return req
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/demo-talent/entities"
+)
+
+// Snapshot encodes v, a struct or nil, as the JSON stored in an audit
+// event. Nil encodes as null.
+func Snapshot(v interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding audit snapshot: %w", err)
+	}
+	return b, nil
+}
+
+// Diff compares two snapshots of a JSON object field by field. Fields set
+// on one side only, as on create and delete, count as changed.
+func Diff(before, after json.RawMessage) (map[string]entities.AuditChange, error) {
+	var b, a map[string]json.RawMessage
+	if err := unmarshalObject(before, &b); err != nil {
+		return nil, err
+	}
+	if err := unmarshalObject(after, &a); err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]entities.AuditChange)
+	for field, old := range b {
+		if v, ok := a[field]; !ok || !bytes.Equal(old, v) {
+			changes[field] = entities.AuditChange{Before: old, After: v}
+		}
+	}
+	for field, v := range a {
+		if _, ok := b[field]; !ok {
+			changes[field] = entities.AuditChange{After: v}
+		}
+	}
+	return changes, nil
+}
+
+// unmarshalObject decodes a JSON object, leaving dst nil for an empty or
+// null snapshot.
+func unmarshalObject(data json.RawMessage, dst *map[string]json.RawMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("error decoding audit snapshot: %w", err)
+	}
+	return nil
+}
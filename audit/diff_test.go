@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/demo-talent/entities"
+)
+
+func TestDiff(t *testing.T) {
+	raw := func(s string) json.RawMessage { return json.RawMessage(s) }
+
+	tests := []struct {
+		name   string
+		before json.RawMessage
+		after  json.RawMessage
+		want   map[string]entities.AuditChange
+	}{
+		{
+			name:   "Update",
+			before: raw(`{"amount":10,"description":"Taxi","tags":["trip"]}`),
+			after:  raw(`{"amount":12.5,"description":"Taxi","tags":["trip"]}`),
+			want: map[string]entities.AuditChange{
+				"amount": {Before: raw(`10`), After: raw(`12.5`)},
+			},
+		},
+		{
+			name:  "Create",
+			after: raw(`{"amount":10}`),
+			want: map[string]entities.AuditChange{
+				"amount": {After: raw(`10`)},
+			},
+		},
+		{
+			name:   "Delete",
+			before: raw(`{"amount":10}`),
+			after:  raw(`null`),
+			want: map[string]entities.AuditChange{
+				"amount": {Before: raw(`10`)},
+			},
+		},
+		{
+			name:   "FieldDropped",
+			before: raw(`{"category_id":"category_1"}`),
+			after:  raw(`{}`),
+			want: map[string]entities.AuditChange{
+				"category_id": {Before: raw(`"category_1"`)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package auth carries the authenticated principal of a request through
+// its context, from the middleware authenticating it down to the
+// repositories scoping their queries by it.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnauthenticated is returned when an operation needs an authenticated
+// principal and the context has none.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrNoWorkspace is returned when an operation acts on the data of a
+// workspace and the context selects none.
+var ErrNoWorkspace = errors.New("no workspace selected")
+
+// Scopes limit what an API key may do.
+const (
+	ScopeExpensesRead  = "expenses:read"
+	ScopeExpensesWrite = "expenses:write"
+	ScopeBudgetsWrite  = "budgets:write"
+	ScopeAdmin         = "admin"
+)
+
+// Scopes lists every known scope.
+var Scopes = []string{ScopeExpensesRead, ScopeExpensesWrite, ScopeBudgetsWrite, ScopeAdmin}
+
+// Principal is who a request acts on behalf of.
+type Principal struct {
+	UserID string
+	// Email is the email the issuer of the token verified, empty for API
+	// keys and tokens without one.
+	Email string
+	// Role is the role of the user in the workspace the request acts on,
+	// and decides what the user may do there; see Authorize. It is empty
+	// until a workspace is selected.
+	Role Role
+	// InstanceRole is the role of the user across workspaces, which only
+	// decides who may change what every workspace shares.
+	InstanceRole Role
+	// WorkspaceID is the workspace whose data the request acts on; the
+	// user is a member of it.
+	WorkspaceID string
+	// APIKeyID is set when the request authenticated with an API key.
+	APIKeyID string
+	// Scopes restrict an API key principal; nil means unrestricted, as
+	// for users authenticated interactively.
+	Scopes []string
+}
+
+// HasScope reports whether p may act with scope. The admin scope grants
+// every other scope.
+func (p Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok && p.UserID != ""
+}
+
+// UserID returns the ID of the user ctx acts on behalf of, or
+// ErrUnauthenticated.
+func UserID(ctx context.Context) (string, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	return p.UserID, nil
+}
+
+// WorkspaceID returns the ID of the workspace ctx acts on, or
+// ErrUnauthenticated without a principal and ErrNoWorkspace when it
+// selects none.
+func WorkspaceID(ctx context.Context) (string, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	if p.WorkspaceID == "" {
+		return "", ErrNoWorkspace
+	}
+	return p.WorkspaceID, nil
+}
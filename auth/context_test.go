@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUserID(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    string
+		wantErr error
+	}{
+		{name: "WithPrincipal", ctx: WithPrincipal(context.TODO(), Principal{UserID: "user_1"}), want: "user_1"},
+		{name: "NoPrincipal", ctx: context.TODO(), wantErr: ErrUnauthenticated},
+		{name: "EmptyUserID", ctx: WithPrincipal(context.TODO(), Principal{}), wantErr: ErrUnauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserID(tt.ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("UserID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkspaceID(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    string
+		wantErr error
+	}{
+		{name: "WithWorkspace", ctx: WithPrincipal(context.TODO(), Principal{UserID: "user_1", WorkspaceID: "workspace_1"}), want: "workspace_1"},
+		{name: "NoPrincipal", ctx: context.TODO(), wantErr: ErrUnauthenticated},
+		{name: "NoWorkspace", ctx: WithPrincipal(context.TODO(), Principal{UserID: "user_1"}), wantErr: ErrNoWorkspace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorkspaceID(tt.ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WorkspaceID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("WorkspaceID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrincipal_HasScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal Principal
+		scope     string
+		want      bool
+	}{
+		{name: "Unrestricted", principal: Principal{UserID: "user_1"}, scope: ScopeAdmin, want: true},
+		{name: "Granted", principal: Principal{Scopes: []string{ScopeExpensesRead}}, scope: ScopeExpensesRead, want: true},
+		{name: "NotGranted", principal: Principal{Scopes: []string{ScopeExpensesRead}}, scope: ScopeExpensesWrite, want: false},
+		{name: "NoScopes", principal: Principal{Scopes: []string{}}, scope: ScopeExpensesRead, want: false},
+		{name: "AdminGrantsAll", principal: Principal{Scopes: []string{ScopeAdmin}}, scope: ScopeExpensesWrite, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.HasScope(tt.scope); got != tt.want {
+				t.Errorf("Principal.HasScope(%q) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when a bearer token is malformed, expired or
+// not signed by a trusted key.
+var ErrInvalidToken = errors.New("invalid token")
+
+// clockSkew is tolerated when checking the time based claims.
+const clockSkew = 30 * time.Second
+
+// JWTConfig holds the keys trusted to sign bearer tokens and the claims
+// they must carry. At least one key is required.
+type JWTConfig struct {
+	// HMACSecret verifies HS256 tokens.
+	HMACSecret []byte
+	// RSAKeys verify RS256 tokens, by the kid in their header. A token
+	// without kid is verified by the key under the empty kid, or by the
+	// only key.
+	RSAKeys map[string]*rsa.PublicKey
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+}
+
+// TokenVerifier authenticates requests by their JWT bearer token.
+type TokenVerifier struct {
+	config JWTConfig
+	parser *jwt.Parser
+}
+
+// NewTokenVerifier creates a TokenVerifier accepting only the algorithms
+// config has keys for.
+func NewTokenVerifier(config JWTConfig) (*TokenVerifier, error) {
+	var methods []string
+	if len(config.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(config.RSAKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no JWT signing key configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		opts = append(opts, jwt.WithAudience(config.Audience))
+	}
+	return &TokenVerifier{config: config, parser: jwt.NewParser(opts...)}, nil
+}
+
+// tokenClaims are the claims read from a token.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// Verify checks the signature and claims of a token and returns the
+// principal named by its sub claim, with its email when the issuer
+// verified it.
+func (v *TokenVerifier) Verify(token string) (Principal, error) {
+	var claims tokenClaims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.key); err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("%w: sub claim is required", ErrInvalidToken)
+	}
+	p := Principal{UserID: claims.Subject}
+	if claims.EmailVerified {
+		p.Email = claims.Email
+	}
+	return p, nil
+}
+
+// key picks the key verifying t. The parser has already restricted the
+// algorithm, so an HMAC secret is never used to check an RSA token or the
+// other way around.
+func (v *TokenVerifier) key(t *jwt.Token) (interface{}, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return v.config.HMACSecret, nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := t.Header["kid"].(string)
+		if key, ok := v.config.RSAKeys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(v.config.RSAKeys) == 1 {
+			for _, key := range v.config.RSAKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+}
+
+// LoadRSAPublicKey reads a PEM encoded RSA public key or certificate.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading public key: %w", err)
+	}
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+	return key, nil
+}
+
+// LoadJWKS reads the RSA signing keys of a JSON Web Key Set file, by kid.
+// Keys of other types or uses are skipped.
+func LoadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JWKS: %w", err)
+	}
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error parsing JWKS: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") || (k.Alg != "" && k.Alg != "RS256") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("error parsing JWKS: invalid RSA key %q", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("error parsing JWKS: no RSA signing key")
+	}
+	return keys, nil
+}
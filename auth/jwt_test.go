@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTokenVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	v, err := NewTokenVerifier(JWTConfig{
+		HMACSecret: secret,
+		RSAKeys:    map[string]*rsa.PublicKey{"key-1": &rsaKey.PublicKey},
+		Issuer:     "https://auth.example.com",
+		Audience:   "expenses",
+	})
+	if err != nil {
+		t.Fatalf("NewTokenVerifier() error = %v", err)
+	}
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "user_1",
+			"iss": "https://auth.example.com",
+			"aud": "expenses",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	sign := func(method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	with := func(key string, value interface{}) jwt.MapClaims {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	verifiedEmail := valid()
+	verifiedEmail["email"], verifiedEmail["email_verified"] = "ana@example.com", true
+
+	tests := []struct {
+		name      string
+		token     string
+		want      string
+		wantEmail string
+		wantErr   error
+	}{
+		{name: "HS256", token: sign(jwt.SigningMethodHS256, "", valid(), secret), want: "user_1"},
+		{name: "VerifiedEmail", token: sign(jwt.SigningMethodHS256, "", verifiedEmail, secret), want: "user_1", wantEmail: "ana@example.com"},
+		{name: "UnverifiedEmail", token: sign(jwt.SigningMethodHS256, "", with("email", "ana@example.com"), secret), want: "user_1"},
+		{name: "RS256", token: sign(jwt.SigningMethodRS256, "key-1", valid(), rsaKey), want: "user_1"},
+		{name: "RS256_SingleKeyWithoutKid", token: sign(jwt.SigningMethodRS256, "", valid(), rsaKey), want: "user_1"},
+		{name: "RS256_UnknownKid", token: sign(jwt.SigningMethodRS256, "key-2", valid(), rsaKey), wantErr: ErrInvalidToken},
+		{name: "RS256_UntrustedKey", token: sign(jwt.SigningMethodRS256, "key-1", valid(), otherKey), wantErr: ErrInvalidToken},
+		{name: "HS384_NotAllowed", token: sign(jwt.SigningMethodHS384, "", valid(), secret), wantErr: ErrInvalidToken},
+		{name: "None_NotAllowed", token: sign(jwt.SigningMethodNone, "", valid(), jwt.UnsafeAllowNoneSignatureType), wantErr: ErrInvalidToken},
+		{name: "Expired", token: sign(jwt.SigningMethodHS256, "", with("exp", time.Now().Add(-time.Hour).Unix()), secret), wantErr: ErrInvalidToken},
+		{name: "NoExpiration", token: sign(jwt.SigningMethodHS256, "", with("exp", nil), secret), wantErr: ErrInvalidToken},
+		{name: "WrongIssuer", token: sign(jwt.SigningMethodHS256, "", with("iss", "https://evil.example.com"), secret), wantErr: ErrInvalidToken},
+		{name: "WrongAudience", token: sign(jwt.SigningMethodHS256, "", with("aud", "billing"), secret), wantErr: ErrInvalidToken},
+		{name: "NoSubject", token: sign(jwt.SigningMethodHS256, "", with("sub", nil), secret), wantErr: ErrInvalidToken},
+		{name: "Malformed", token: "not.a.token", wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TokenVerifier.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.UserID != tt.want {
+				t.Errorf("TokenVerifier.Verify() user = %q, want %q", got.UserID, tt.want)
+			}
+			if got.Email != tt.wantEmail {
+				t.Errorf("TokenVerifier.Verify() email = %q, want %q", got.Email, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestNewTokenVerifier_NoKey(t *testing.T) {
+	if _, err := NewTokenVerifier(JWTConfig{}); err == nil {
+		t.Error("NewTokenVerifier() without keys error = nil, want an error")
+	}
+}
+
+func TestLoadJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes())
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "key-1", "use": "sig", "alg": "RS256", "n": %q, "e": %q},
+		{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": "", "y": ""}
+	]}`, n, e, n, e)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(jwks), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadJWKS(path)
+	if err != nil {
+		t.Fatalf("LoadJWKS() error = %v", err)
+	}
+	if len(keys) != 1 || !keys["key-1"].Equal(&rsaKey.PublicKey) {
+		t.Errorf("LoadJWKS() = %v, want only key-1", keys)
+	}
+}
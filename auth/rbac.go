@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrForbidden is returned when the principal's role lacks the permission
+// an operation needs.
+var ErrForbidden = errors.New("forbidden")
+
+// Role is what a user may do.
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleMember   Role = "member"
+	RoleApprover Role = "approver"
+	RoleAdmin    Role = "admin"
+)
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permission is an operation a role may be allowed to perform.
+type Permission string
+
+const (
+	// PermReadExpenses reads expenses and what they refer to: categories,
+	// budgets, recurring expenses and attachments.
+	PermReadExpenses Permission = "expenses:read"
+	// PermWriteExpenses creates, changes and deletes expenses, their
+	// recurring templates and attachments.
+	PermWriteExpenses Permission = "expenses:write"
+	// PermApproveExpenses approves or rejects submitted expenses.
+	PermApproveExpenses Permission = "expenses:approve"
+	// PermManageCategories creates, changes and deletes categories and
+	// their budgets.
+	PermManageCategories Permission = "categories:manage"
+	// PermManageMembers adds and removes the members of a workspace and
+	// assigns their roles in it.
+	PermManageMembers Permission = "members:manage"
+	// PermManageExchangeRates imports exchange rates.
+	PermManageExchangeRates Permission = "exchange-rates:manage"
+	// PermManageUsers assigns instance roles.
+	PermManageUsers Permission = "users:manage"
+)
+
+// rolePermissions is the single place deciding what each role may do.
+var rolePermissions = map[Role][]Permission{
+	RoleViewer:   {PermReadExpenses},
+	RoleMember:   {PermReadExpenses, PermWriteExpenses},
+	RoleApprover: {PermReadExpenses, PermWriteExpenses, PermApproveExpenses},
+	RoleAdmin: {
+		PermReadExpenses, PermWriteExpenses, PermApproveExpenses,
+		PermManageCategories, PermManageMembers, PermManageExchangeRates, PermManageUsers,
+	},
+}
+
+// instancePermissions act on what every workspace shares, so they are
+// granted by the instance role of the user rather than by the role in the
+// selected workspace.
+var instancePermissions = map[Permission]bool{
+	PermManageExchangeRates: true,
+	PermManageUsers:         true,
+}
+
+// Can reports whether r grants p. Unknown roles grant nothing.
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize checks that the principal in ctx may perform p: with its role
+// in the selected workspace or, for the permissions over what every
+// workspace shares, with its instance role. It returns ErrUnauthenticated
+// without a principal and ErrForbidden when the role lacks p.
+func Authorize(ctx context.Context, p Permission) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	role := principal.Role
+	if instancePermissions[p] {
+		role = principal.InstanceRole
+	}
+	if !role.Can(p) {
+		return fmt.Errorf("%w: the %s role may not %s", ErrForbidden, roleName(role), p)
+	}
+	return nil
+}
+
+func roleName(r Role) string {
+	if r == "" {
+		return "empty"
+	}
+	return string(r)
+}
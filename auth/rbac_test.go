@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	as := func(role Role) context.Context {
+		return WithPrincipal(context.TODO(), Principal{UserID: "user_1", Role: role})
+	}
+	asInstance := func(role Role) context.Context {
+		return WithPrincipal(context.TODO(), Principal{UserID: "user_1", InstanceRole: role})
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		perm    Permission
+		wantErr error
+	}{
+		{name: "Viewer_Read", ctx: as(RoleViewer), perm: PermReadExpenses},
+		{name: "Viewer_Write", ctx: as(RoleViewer), perm: PermWriteExpenses, wantErr: ErrForbidden},
+		{name: "Member_Write", ctx: as(RoleMember), perm: PermWriteExpenses},
+		{name: "Member_Approve", ctx: as(RoleMember), perm: PermApproveExpenses, wantErr: ErrForbidden},
+		{name: "Member_ManageCategories", ctx: as(RoleMember), perm: PermManageCategories, wantErr: ErrForbidden},
+		{name: "Approver_Approve", ctx: as(RoleApprover), perm: PermApproveExpenses},
+		{name: "Approver_ManageCategories", ctx: as(RoleApprover), perm: PermManageCategories, wantErr: ErrForbidden},
+		{name: "Admin_ManageCategories", ctx: as(RoleAdmin), perm: PermManageCategories},
+		{name: "Admin_ManageMembers", ctx: as(RoleAdmin), perm: PermManageMembers},
+		{name: "Approver_ManageMembers", ctx: as(RoleApprover), perm: PermManageMembers, wantErr: ErrForbidden},
+		{name: "WorkspaceAdmin_ManageUsers", ctx: as(RoleAdmin), perm: PermManageUsers, wantErr: ErrForbidden},
+		{name: "InstanceAdmin_ManageUsers", ctx: asInstance(RoleAdmin), perm: PermManageUsers},
+		{name: "InstanceAdmin_ManageExchangeRates", ctx: asInstance(RoleAdmin), perm: PermManageExchangeRates},
+		{name: "InstanceAdmin_Write", ctx: asInstance(RoleAdmin), perm: PermWriteExpenses, wantErr: ErrForbidden},
+		{name: "InstanceMember_ManageExchangeRates", ctx: asInstance(RoleMember), perm: PermManageExchangeRates, wantErr: ErrForbidden},
+		{name: "UnknownRole", ctx: as("owner"), perm: PermReadExpenses, wantErr: ErrForbidden},
+		{name: "NoRole", ctx: as(""), perm: PermReadExpenses, wantErr: ErrForbidden},
+		{name: "Unauthenticated", ctx: context.TODO(), perm: PermReadExpenses, wantErr: ErrUnauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Authorize(tt.ctx, tt.perm); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authorize(%s) error = %v, wantErr %v", tt.perm, err, tt.wantErr)
+			}
+		})
+	}
+}
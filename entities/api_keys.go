@@ -0,0 +1,17 @@
+package entities
+
+// APIKey authenticates a machine client on behalf of its owner, limited to
+// its scopes. Only a hash of the key is stored; Key is set just in the
+// response that issues it.
+type APIKey struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Key          string   `json:"key,omitempty"`
+	Prefix       string   `json:"prefix"`
+	Scopes       []string `json:"scopes"`
+	OwnerID      string   `json:"owner_id"`
+	DateCreation int64    `json:"date_creation"`
+	ExpiresAt    *int64   `json:"expires_at,omitempty"`
+	RevokedAt    *int64   `json:"revoked_at,omitempty"`
+	LastUsedAt   *int64   `json:"last_used_at,omitempty"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+// Attachment is a file, such as a receipt, attached to an expense. Its
+// bytes are kept in a blob store under SHA256, so identical files are
+// stored once.
+type Attachment struct {
+	ID           string `json:"id"`
+	ExpenseID    string `json:"expense_id"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	SHA256       string `json:"sha256"`
+	DateCreation int64  `json:"date_creation"`
+}
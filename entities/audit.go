@@ -0,0 +1,49 @@
+package entities
+
+import "encoding/json"
+
+// AuditAction is what an audited operation did to an entity.
+type AuditAction string
+
+const (
+	AuditCreate    AuditAction = "create"
+	AuditUpdate    AuditAction = "update"
+	AuditDelete    AuditAction = "delete"
+	AuditSubmit    AuditAction = "submit"
+	AuditApprove   AuditAction = "approve"
+	AuditReject    AuditAction = "reject"
+	AuditReimburse AuditAction = "reimburse"
+	AuditRestore   AuditAction = "restore"
+)
+
+// AuditEntityExpense is the EntityType of the events about expenses.
+const AuditEntityExpense = "expense"
+
+// AuditEvent records who changed an entity, how and when. Events are
+// never changed nor deleted.
+type AuditEvent struct {
+	ID         string      `json:"id"`
+	EntityType string      `json:"entity_type"`
+	EntityID   string      `json:"entity_id"`
+	Action     AuditAction `json:"action"`
+	ActorID    string      `json:"actor_id"`
+	// APIKeyID is set when the actor used an API key.
+	APIKeyID string `json:"api_key_id,omitempty"`
+	// Before and After are the entity as JSON; Before is null on create
+	// and After on delete.
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	// Changes holds the top-level fields that differ between Before and
+	// After.
+	Changes      map[string]AuditChange `json:"changes"`
+	RequestID    string                 `json:"request_id,omitempty"`
+	IP           string                 `json:"ip,omitempty"`
+	DateCreation int64                  `json:"date_creation"`
+}
+
+// AuditChange is the value of a field before and after a change; a side
+// is omitted when the field did not exist.
+type AuditChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
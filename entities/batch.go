@@ -0,0 +1,56 @@
+package entities
+
+// BatchMode selects what happens to a batch when one of its operations
+// fails.
+type BatchMode string
+
+const (
+	// BatchAllOrNothing undoes the whole batch when an operation fails. It
+	// is the default.
+	BatchAllOrNothing BatchMode = "all_or_nothing"
+	// BatchPerItem undoes only the failed operations and keeps the others.
+	BatchPerItem BatchMode = "per_item"
+)
+
+// BatchOp is the kind of an operation in a batch.
+type BatchOp string
+
+const (
+	BatchCreate BatchOp = "create"
+	BatchUpdate BatchOp = "update"
+	BatchDelete BatchOp = "delete"
+)
+
+// ExpenseBatch is a list of changes to expenses made in a single
+// transaction, in order.
+type ExpenseBatch struct {
+	Mode       BatchMode          `json:"mode,omitempty"`
+	Operations []ExpenseOperation `json:"operations"`
+}
+
+// ExpenseOperation is a change in an ExpenseBatch. Creations and updates
+// carry the Expense to save; updates and deletions name the expense by
+// ID, and are made only if it is still at Version, which they require.
+type ExpenseOperation struct {
+	Op      BatchOp  `json:"op"`
+	ID      string   `json:"id,omitempty"`
+	Version int      `json:"version,omitempty"`
+	Expense *Expense `json:"expense,omitempty"`
+}
+
+// ExpenseBatchResult tells whether a batch was committed and what became
+// of each of its operations.
+type ExpenseBatchResult struct {
+	Committed bool
+	Results   []ExpenseOperationResult
+}
+
+// ExpenseOperationResult is the outcome of the operation at Index in its
+// batch: the expense as it was left, or the error that failed it. Deleted
+// expenses and failed operations have no expense.
+type ExpenseOperationResult struct {
+	Index   int
+	Op      BatchOp
+	Expense *Expense
+	Err     error
+}
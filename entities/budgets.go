@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+// BudgetPeriod is the length of the periods a budget limit applies to.
+type BudgetPeriod string
+
+const (
+	BudgetPeriodMonth   BudgetPeriod = "month"
+	BudgetPeriodQuarter BudgetPeriod = "quarter"
+	BudgetPeriodYear    BudgetPeriod = "year"
+)
+
+// Valid reports whether p is a known period.
+func (p BudgetPeriod) Valid() bool {
+	switch p {
+	case BudgetPeriodMonth, BudgetPeriodQuarter, BudgetPeriodYear:
+		return true
+	}
+	return false
+}
+
+// Bounds returns the UTC calendar period containing at, as [start, end).
+func (p BudgetPeriod) Bounds(at time.Time) (time.Time, time.Time) {
+	at = at.UTC()
+	switch p {
+	case BudgetPeriodYear:
+		start := time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0)
+	case BudgetPeriodQuarter:
+		month := time.Month((int(at.Month())-1)/3*3 + 1)
+		start := time.Date(at.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0)
+	default:
+		start := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// Budget caps what may be spent in a category per period. Limit is in the
+// base currency. With Rollover, what was left unspent in the previous
+// period is added to the current one.
+type Budget struct {
+	ID           string       `json:"id"`
+	CategoryID   string       `json:"category_id"`
+	Period       BudgetPeriod `json:"period"`
+	Limit        Money        `json:"limit"`
+	Rollover     bool         `json:"rollover"`
+	DateCreation int64        `json:"date_creation"`
+}
+
+// BudgetStatus is how much of a budget has been spent in one period.
+type BudgetStatus struct {
+	BudgetID    string    `json:"budget_id"`
+	Currency    string    `json:"currency"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Limit       Money     `json:"limit"`
+	RolledOver  Money     `json:"rolled_over"`
+	Spent       Money     `json:"spent"`
+	Remaining   Money     `json:"remaining"`
+	Percentage  float64   `json:"percentage"`
+	OverLimit   bool      `json:"over_limit"`
+}
+
+// BudgetWarning tells that an expense pushed a budget over its limit.
+type BudgetWarning struct {
+	BudgetID string `json:"budget_id"`
+	Message  string `json:"message"`
+}
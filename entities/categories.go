@@ -0,0 +1,8 @@
+package entities
+
+type Category struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	DateCreation int64  `json:"date_creation"`
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReferenceCurrency is the currency exchange rates are quoted against: a
+// rate is the number of units of a currency one EUR buys, as published by
+// the ECB.
+const ReferenceCurrency = "EUR"
+
+// ErrInvalidCurrency is returned for codes that are not active ISO 4217
+// currencies.
+var ErrInvalidCurrency = errors.New("invalid currency")
+
+// iso4217 lists the active ISO 4217 currency codes.
+var iso4217 = make(map[string]bool)
+
+func init() {
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND
+		BOB BRL BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF
+		DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD
+		HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW
+		KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR
+		MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN
+		PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN
+		SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD UYU UZS VES
+		VND VUV WST XAF XCD XCG XOF XPF YER ZAR ZMW ZWG
+	`) {
+		iso4217[code] = true
+	}
+}
+
+// NormalizeCurrency uppercases code and checks it is an ISO 4217 currency.
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !iso4217[code] {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	return code, nil
+}
+
+// ExchangeRate is the number of units of Currency one ReferenceCurrency
+// bought on Date. Rate is kept as a decimal string to stay exact.
+type ExchangeRate struct {
+	Currency string    `json:"currency"`
+	Date     time.Time `json:"date"`
+	Rate     string    `json:"rate"`
+}
+
+// DailyTotal is the sum of the expenses in one currency on one UTC day.
+type DailyTotal struct {
+	Currency string
+	Date     time.Time
+	Amount   Money
+}
+
+// CurrencyTotal is the sum of the expenses in one currency.
+type CurrencyTotal struct {
+	Currency string `json:"currency"`
+	Amount   Money  `json:"amount"`
+}
+
+// ExpenseTotals sums a set of expenses converted to a base currency.
+type ExpenseTotals struct {
+	Currency   string          `json:"currency"`
+	Total      Money           `json:"total"`
+	ByCurrency []CurrencyTotal `json:"by_currency"`
+}
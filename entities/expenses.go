@@ -1,8 +1,108 @@
 package entities
 
 type Expense struct {
-	ID           string  `json:"id"`
-	Description  string  `json:"description"`
-	Amount       float64 `json:"amount"`
-	DateCreation int64   `json:"date_creation"`
+	ID           string   `json:"id"`
+	Description  string   `json:"description"`
+	Amount       Money    `json:"amount"`
+	Currency     string   `json:"currency"`
+	DateCreation int64    `json:"date_creation"`
+	CategoryID   string   `json:"category_id,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	OwnerID      string   `json:"owner_id"`
+	WorkspaceID  string   `json:"workspace_id"`
+	// Status and StatusReason only change through the approval workflow;
+	// they are ignored on create and update.
+	Status       ExpenseStatus `json:"status"`
+	StatusReason string        `json:"status_reason,omitempty"`
+	// DeletedAt is set while the expense is in the trash.
+	DeletedAt *int64 `json:"deleted_at,omitempty"`
+	// Version starts at 1 and is incremented by every change, so a change
+	// can be based on the version it was made from.
+	Version int `json:"version"`
+	// ExternalRef identifies the statement transaction an imported expense
+	// was created from. It is only set by imports.
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Warnings are not stored; they are only returned by the call that
+	// caused them.
+	Warnings []BudgetWarning `json:"warnings,omitempty"`
+}
+
+// ExpenseFilter narrows down the expenses returned by a listing.
+// Nil bounds and empty strings are ignored.
+type ExpenseFilter struct {
+	MinAmount   *Money
+	MaxAmount   *Money
+	From        *int64
+	To          *int64
+	Description string
+	CategoryID  string
+	Currency    string
+	Tags        []string
+	TagMatch    TagMatch
+	Status      ExpenseStatus
+	// AllOwners keeps the expenses of every member of the workspace
+	// instead of only those of the user. Requests cannot set it.
+	AllOwners bool
+}
+
+// TagMatch selects how ExpenseFilter.Tags are matched.
+type TagMatch string
+
+const (
+	// TagMatchAny keeps expenses carrying at least one of the tags. It is
+	// the default.
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll keeps expenses carrying every one of the tags.
+	TagMatchAll TagMatch = "all"
+)
+
+// ExpenseStatus is the stage of an expense in the approval workflow.
+type ExpenseStatus string
+
+const (
+	// StatusDraft is the status of new expenses.
+	StatusDraft ExpenseStatus = "draft"
+	// StatusSubmitted expenses wait for an approver.
+	StatusSubmitted ExpenseStatus = "submitted"
+	// StatusApproved expenses were accepted and can no longer change.
+	StatusApproved ExpenseStatus = "approved"
+	// StatusRejected expenses were sent back to their owner with a reason,
+	// to be fixed and submitted again.
+	StatusRejected ExpenseStatus = "rejected"
+	// StatusReimbursed expenses were approved and paid back to their
+	// owner.
+	StatusReimbursed ExpenseStatus = "reimbursed"
+)
+
+// expenseTransitions lists the statuses each status may move to.
+var expenseTransitions = map[ExpenseStatus][]ExpenseStatus{
+	StatusDraft:     {StatusSubmitted},
+	StatusSubmitted: {StatusApproved, StatusRejected},
+	StatusRejected:  {StatusSubmitted},
+	StatusApproved:  {StatusReimbursed},
+}
+
+// Valid reports whether s is a known status.
+func (s ExpenseStatus) Valid() bool {
+	switch s {
+	case StatusDraft, StatusSubmitted, StatusApproved, StatusRejected, StatusReimbursed:
+		return true
+	}
+	return false
+}
+
+// CanMoveTo reports whether the workflow allows moving from s to next.
+func (s ExpenseStatus) CanMoveTo(next ExpenseStatus) bool {
+	for _, allowed := range expenseTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Locked reports whether expenses in status s can no longer be updated or
+// deleted.
+func (s ExpenseStatus) Locked() bool {
+	return s == StatusApproved || s == StatusReimbursed
 }
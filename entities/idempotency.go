@@ -0,0 +1,28 @@
+package entities
+
+// IdempotencyRecord remembers the first request a client sent with an
+// Idempotency-Key, so its retries get the same response instead of being
+// processed again.
+type IdempotencyRecord struct {
+	Key string
+	// RequestHash identifies the request, so a key reused for another
+	// request can be told apart from a retry.
+	RequestHash string
+	// Token identifies the claim of the request holding the key; it
+	// changes when another request takes the key over.
+	Token string
+	// Committed is set along with what the request holding the key
+	// creates, after which the key is never taken over.
+	Committed bool
+	// Response is nil while the first request is in progress.
+	Response  *IdempotentResponse
+	ClaimedAt int64
+	ExpiresAt int64
+}
+
+// IdempotentResponse is a response stored to be replayed.
+type IdempotentResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
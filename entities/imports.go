@@ -0,0 +1,99 @@
+package entities
+
+// CSVImportOptions tells how to read the CSV export of a bank or card
+// statement.
+type CSVImportOptions struct {
+	Columns CSVColumns
+	// DateFormat lays out dates with YYYY, YY, MM and DD, such as
+	// DD/MM/YYYY. It is YYYY-MM-DD when empty.
+	DateFormat string
+	// DecimalSeparator is "." or ",", the other one being taken as a
+	// thousands separator. It is "." when empty.
+	DecimalSeparator string
+	// AmountSign is the sign of the amounts of expenses on the
+	// statement, ExpensesPositive when empty. Amounts of the other sign
+	// are money coming in.
+	AmountSign AmountSign
+	// Delimiter separates the fields, ',' when zero.
+	Delimiter rune
+	// DryRun checks the rows without creating any expense.
+	DryRun bool
+}
+
+// AmountSign tells which sign the amounts of expenses have on a
+// statement.
+type AmountSign string
+
+const (
+	// ExpensesPositive statements write expenses as positive amounts.
+	ExpensesPositive AmountSign = "positive"
+	// ExpensesNegative statements write expenses as negative amounts,
+	// the way bank accounts show debits.
+	ExpensesNegative AmountSign = "negative"
+)
+
+// CSVColumns maps the fields of an expense to the header of the column
+// they are read from. Date, Description and Amount are required; without
+// a currency column expenses are in the base currency, and without a
+// category column they are uncategorized.
+type CSVColumns struct {
+	Date        string
+	Description string
+	Amount      string
+	Currency    string
+	Category    string
+}
+
+// QIFImportOptions tells how to read a QIF statement, whose dates and
+// amounts are written the way the software exporting it is set up.
+type QIFImportOptions struct {
+	// DateFormat lays out dates with YYYY, YY, MM and DD, such as
+	// DD/MM/YYYY; days and months may lack their leading zero and years
+	// may have two digits. It is MM/DD/YYYY when empty.
+	DateFormat string
+	// DecimalSeparator is "." or ",", the other one being taken as a
+	// thousands separator. It is "." when empty.
+	DecimalSeparator string
+	// Currency is the currency of the account, the base currency when
+	// empty.
+	Currency string
+	// DryRun checks the transactions without creating any expense.
+	DryRun bool
+}
+
+// ImportRowStatus is what became of a row of an imported file.
+type ImportRowStatus string
+
+const (
+	// ImportCreated rows were created as expenses.
+	ImportCreated ImportRowStatus = "created"
+	// ImportValid rows would be created by an import that is not a dry
+	// run.
+	ImportValid ImportRowStatus = "valid"
+	// ImportSkipped rows hold no expense, like blank rows, zero amounts
+	// and money coming in, or hold one that was already imported.
+	ImportSkipped ImportRowStatus = "skipped"
+	// ImportFailed rows could not be read or created.
+	ImportFailed ImportRowStatus = "failed"
+)
+
+// ImportSummary reports an import row by row. On a dry run Created counts
+// the rows that would be created.
+type ImportSummary struct {
+	DryRun  bool        `json:"dry_run"`
+	Created int         `json:"created"`
+	Skipped int         `json:"skipped"`
+	Failed  int         `json:"failed"`
+	Rows    []ImportRow `json:"rows"`
+}
+
+// ImportRow is the outcome of a row of an imported file, numbered by the
+// Line it starts on. Error tells why a row failed, and Reason why it was
+// skipped when it is not blank.
+type ImportRow struct {
+	Line    int             `json:"line"`
+	Status  ImportRowStatus `json:"status"`
+	Expense *Expense        `json:"expense,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Reason  string          `json:"reason,omitempty"`
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount expressed in minor units (cents), so sums never
+// suffer from floating point rounding. It reads and writes JSON numbers and
+// NUMERIC columns with exactly two decimals.
+type Money int64
+
+// MaxMoney is the largest absolute amount a DECIMAL(10, 2) column can hold.
+const MaxMoney Money = 99999999_99
+
+// ErrInvalidAmount is returned when an amount has more than two decimals,
+// does not fit the amount column or is not a number at all.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ParseMoney parses a decimal such as "15", "-3.5" or "1200.25" and checks
+// it fits the amount column.
+func ParseMoney(s string) (Money, error) {
+	m, err := parseDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+	if m > MaxMoney || m < -MaxMoney {
+		return 0, fmt.Errorf("%w: %q exceeds %s", ErrInvalidAmount, s, MaxMoney)
+	}
+	return m, nil
+}
+
+// parseDecimal parses a decimal with at most two decimals into minor
+// units without any range check beyond int64.
+func parseDecimal(s string) (Money, error) {
+	digits := strings.TrimPrefix(s, "-")
+	negative := len(digits) < len(s)
+
+	units, cents := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		units, cents = digits[:i], digits[i+1:]
+	}
+	if units == "" || !isDigits(units) || !isDigits(cents) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, s)
+	}
+	if len(cents) > 2 {
+		return 0, fmt.Errorf("%w: %q has more than two decimals", ErrInvalidAmount, s)
+	}
+
+	cents += strings.Repeat("0", 2-len(cents))
+	v, err := strconv.ParseInt(units+cents, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	if negative {
+		v = -v
+	}
+	return Money(v), nil
+}
+
+// String formats m with exactly two decimals, e.g. "15.50".
+func (m Money) String() string {
+	sign, v := "", int64(m)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// MarshalJSON encodes m as a JSON number with two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string holding one.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := string(bytes.Trim(b, `"`))
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Value stores m as a decimal string so the database never sees a float.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a NUMERIC column. Sums may exceed MaxMoney, so only the
+// number of decimals is checked.
+func (m *Money) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+	v, err := parseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
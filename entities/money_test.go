@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Money
+		wantErr bool
+	}{
+		{name: "Integer", s: "15", want: 1500},
+		{name: "OneDecimal", s: "15.5", want: 1550},
+		{name: "TwoDecimals", s: "0.10", want: 10},
+		{name: "Negative", s: "-3.25", want: -325},
+		{name: "Max", s: "99999999.99", want: MaxMoney},
+		{name: "LeadingZeros", s: "00012.30", want: 1230},
+		{name: "TooManyDecimals", s: "1.005", wantErr: true},
+		{name: "TooLarge", s: "100000000.00", wantErr: true},
+		{name: "Exponent", s: "1e3", wantErr: true},
+		{name: "Empty", s: "", wantErr: true},
+		{name: "OnlyDot", s: ".5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMoney(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("ParseMoney(%q) error = %v, want ErrInvalidAmount", tt.s, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var e struct {
+		Amount Money `json:"amount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 0.1}`), &e); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	sum := e.Amount + Money(20)
+	b, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{sum})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(b), `{"amount":0.30}`; got != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount": 12.345}`), &e); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrInvalidAmount", err)
+	}
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var m Money
+	if err := m.Scan([]byte("-1234.50")); err != nil {
+		t.Fatalf("Money.Scan() error = %v", err)
+	}
+	if m != -123450 || m.String() != "-1234.50" {
+		t.Errorf("Money.Scan() = %d (%s), want -123450 (-1234.50)", m, m)
+	}
+}
@@ -0,0 +1,43 @@
+package entities
+
+// SortField is a column expense listings can be ordered by.
+type SortField string
+
+const (
+	SortByDate        SortField = "date"
+	SortByAmount      SortField = "amount"
+	SortByDescription SortField = "description"
+)
+
+// Sort describes the ordering of an expense listing. Ties are always
+// broken by expense ID in the same direction so the order is stable.
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// DefaultSort lists the newest expenses first.
+var DefaultSort = Sort{Field: SortByDate, Desc: true}
+
+// String returns the sort in its query string form, e.g. "-date".
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + string(s.Field)
+	}
+	return string(s.Field)
+}
+
+// PageRequest selects a page of an expense listing. Cursor is the
+// opaque next_cursor returned with the previous page, empty for the first.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	Sort   Sort
+}
+
+// ExpensePage is one page of an expense listing.
+type ExpensePage struct {
+	Items      []*Expense `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+}
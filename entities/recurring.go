@@ -0,0 +1,113 @@
+package entities
+
+import "time"
+
+// Frequency is how often a recurring expense repeats, in the spirit of the
+// iCalendar RRULE FREQ part.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyYearly  Frequency = "yearly"
+)
+
+// Valid reports whether f is a known frequency.
+func (f Frequency) Valid() bool {
+	switch f {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		return true
+	}
+	return false
+}
+
+// RecurringExpense is a template materialized into an expense every
+// Interval periods of Frequency from StartAt, until EndAt when set.
+// NextRun is nil once the template has no occurrence left.
+type RecurringExpense struct {
+	ID           string    `json:"id"`
+	Description  string    `json:"description"`
+	Amount       Money     `json:"amount"`
+	Currency     string    `json:"currency"`
+	CategoryID   string    `json:"category_id,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Frequency    Frequency `json:"frequency"`
+	Interval     int       `json:"interval"`
+	StartAt      int64     `json:"start_at"`
+	EndAt        *int64    `json:"end_at,omitempty"`
+	NextRun      *int64    `json:"next_run"`
+	OwnerID      string    `json:"owner_id"`
+	WorkspaceID  string    `json:"workspace_id"`
+	DateCreation int64     `json:"date_creation"`
+}
+
+// Occurrence returns the n-th occurrence, starting at 0 with StartAt.
+// Monthly and yearly occurrences falling on a day the month lacks are
+// moved to its last day, so a template starting on the 31st stays at the
+// end of the month.
+func (r *RecurringExpense) Occurrence(n int) time.Time {
+	start := time.Unix(r.StartAt, 0).UTC()
+	step := n * r.Interval
+	switch r.Frequency {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, step)
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case FrequencyYearly:
+		return addMonths(start, 12*step)
+	default:
+		return addMonths(start, step)
+	}
+}
+
+// NextAfter returns the first occurrence strictly after t, or nil when
+// there is none before EndAt.
+func (r *RecurringExpense) NextAfter(t time.Time) *int64 {
+	n := 0
+	if start := time.Unix(r.StartAt, 0); t.After(start) {
+		// Jump close to t; the loops below settle the exact occurrence.
+		n = int(t.Sub(start) / r.approxPeriod())
+	}
+	// Months and years are longer than approxPeriod, so the guess may
+	// overshoot over long spans.
+	for n > 0 && r.Occurrence(n-1).After(t) {
+		n--
+	}
+	for !r.Occurrence(n).After(t) {
+		n++
+	}
+
+	next := r.Occurrence(n).Unix()
+	if r.EndAt != nil && next > *r.EndAt {
+		return nil
+	}
+	return &next
+}
+
+// approxPeriod is a lower bound of the time between two occurrences, so
+// dividing a span by it may count more occurrences than there are.
+func (r *RecurringExpense) approxPeriod() time.Duration {
+	day := 24 * time.Hour
+	switch r.Frequency {
+	case FrequencyDaily:
+		return time.Duration(r.Interval) * day
+	case FrequencyWeekly:
+		return time.Duration(r.Interval) * 7 * day
+	case FrequencyYearly:
+		return time.Duration(r.Interval) * 365 * day
+	default:
+		return time.Duration(r.Interval) * 28 * day
+	}
+}
+
+// addMonths adds months to t, clamping the day to the end of the month.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurringExpense_NextAfter(t *testing.T) {
+	jan31 := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	tests := []struct {
+		name string
+		r    RecurringExpense
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "BeforeStart",
+			r:    RecurringExpense{Frequency: FrequencyMonthly, Interval: 1, StartAt: jan31.Unix()},
+			t:    jan31.AddDate(0, 0, -10),
+			want: jan31,
+		},
+		{
+			name: "MonthlyClampsToEndOfMonth",
+			r:    RecurringExpense{Frequency: FrequencyMonthly, Interval: 1, StartAt: jan31.Unix()},
+			t:    jan31,
+			want: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "MonthlyKeepsDayAfterShortMonth",
+			r:    RecurringExpense{Frequency: FrequencyMonthly, Interval: 1, StartAt: jan31.Unix()},
+			t:    time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "EveryTwoWeeks",
+			r:    RecurringExpense{Frequency: FrequencyWeekly, Interval: 2, StartAt: jan31.Unix()},
+			t:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "MonthlyYearsLater",
+			r:    RecurringExpense{Frequency: FrequencyMonthly, Interval: 1, StartAt: jan31.Unix()},
+			t:    time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2029, 1, 31, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "YearlyFromLeapDay",
+			r:    RecurringExpense{Frequency: FrequencyYearly, Interval: 1, StartAt: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC).Unix()},
+			t:    time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2032, 2, 29, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Yearly",
+			r:    RecurringExpense{Frequency: FrequencyYearly, Interval: 1, StartAt: jan31.Unix()},
+			t:    time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2031, 1, 31, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.NextAfter(tt.t)
+			if got == nil || *got != tt.want.Unix() {
+				t.Errorf("RecurringExpense.NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	r := RecurringExpense{Frequency: FrequencyMonthly, Interval: 1, StartAt: jan31.Unix(), EndAt: &end}
+	if got := r.NextAfter(time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC)); got != nil {
+		t.Errorf("RecurringExpense.NextAfter() past EndAt = %v, want nil", time.Unix(*got, 0))
+	}
+}
+
+func TestRecurringExpense_NextAfter_LongSpans(t *testing.T) {
+	templates := []RecurringExpense{
+		{Frequency: FrequencyMonthly, Interval: 1, StartAt: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC).Unix()},
+		{Frequency: FrequencyMonthly, Interval: 5, StartAt: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC).Unix()},
+		{Frequency: FrequencyYearly, Interval: 1, StartAt: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC).Unix()},
+		{Frequency: FrequencyYearly, Interval: 1, StartAt: time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC).Unix()},
+	}
+	for _, r := range templates {
+		// Walking the occurrences one by one gives the expected answer.
+		n := 0
+		for day := time.Unix(r.StartAt, 0).UTC(); day.Year() < 2064; day = day.AddDate(0, 0, 1) {
+			for !r.Occurrence(n).After(day) {
+				n++
+			}
+			got := r.NextAfter(day)
+			if got == nil {
+				t.Fatalf("RecurringExpense.NextAfter(%v) of every %d %s = nil, want %v", day, r.Interval, r.Frequency, r.Occurrence(n))
+			}
+			if *got != r.Occurrence(n).Unix() {
+				t.Fatalf("RecurringExpense.NextAfter(%v) of every %d %s = %v, want %v", day, r.Interval, r.Frequency, time.Unix(*got, 0).UTC(), r.Occurrence(n))
+			}
+		}
+	}
+}
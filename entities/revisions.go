@@ -0,0 +1,20 @@
+package entities
+
+// ExpenseRevision is a version an expense had before it was changed.
+// Revisions are numbered from 1, the version the expense was created
+// with; the current version is the expense itself.
+type ExpenseRevision struct {
+	ExpenseID    string        `json:"expense_id"`
+	Revision     int           `json:"revision"`
+	Description  string        `json:"description"`
+	Amount       Money         `json:"amount"`
+	Currency     string        `json:"currency"`
+	CategoryID   string        `json:"category_id,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	Status       ExpenseStatus `json:"status"`
+	StatusReason string        `json:"status_reason,omitempty"`
+	// ReplacedBy is the user whose change ended this version, at
+	// ReplacedAt.
+	ReplacedBy string `json:"replaced_by"`
+	ReplacedAt int64  `json:"replaced_at"`
+}
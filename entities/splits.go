@@ -0,0 +1,78 @@
+package entities
+
+// SplitMethod is how the amount of an expense is divided among its
+// participants.
+type SplitMethod string
+
+const (
+	// SplitEqual divides the amount in equal parts.
+	SplitEqual SplitMethod = "equal"
+	// SplitExact gives each participant the given amount.
+	SplitExact SplitMethod = "exact"
+	// SplitPercentage gives each participant the given percentage.
+	SplitPercentage SplitMethod = "percentage"
+	// SplitShares divides the amount in proportion to the given shares.
+	SplitShares SplitMethod = "shares"
+)
+
+// Valid reports whether m is a known split method.
+func (m SplitMethod) Valid() bool {
+	switch m {
+	case SplitEqual, SplitExact, SplitPercentage, SplitShares:
+		return true
+	}
+	return false
+}
+
+// SplitRequest describes how to split an expense. Each participant sets
+// the field of the method: Amount for exact, Percentage for percentage and
+// Shares for shares.
+type SplitRequest struct {
+	Method       SplitMethod        `json:"method"`
+	Participants []SplitParticipant `json:"participants"`
+}
+
+// SplitParticipant is one user sharing an expense.
+type SplitParticipant struct {
+	UserID     string  `json:"user_id"`
+	Amount     Money   `json:"amount,omitempty"`
+	Percentage float64 `json:"percentage,omitempty"`
+	Shares     int64   `json:"shares,omitempty"`
+}
+
+// ExpenseSplit is the part of an expense a user owes to its owner, who
+// paid it. The parts of an expense add up to its amount.
+type ExpenseSplit struct {
+	ExpenseID string `json:"expense_id"`
+	UserID    string `json:"user_id"`
+	Amount    Money  `json:"amount"`
+}
+
+// Balance is what a user is owed in one currency, across the split
+// expenses and settlements of a workspace. It is negative when the user
+// owes money.
+type Balance struct {
+	UserID   string `json:"user_id"`
+	Currency string `json:"currency"`
+	Amount   Money  `json:"amount"`
+}
+
+// Settlement records a payment from one member of a workspace to another
+// that settles their balances.
+type Settlement struct {
+	ID           string `json:"id"`
+	FromUserID   string `json:"from_user_id"`
+	ToUserID     string `json:"to_user_id"`
+	Amount       Money  `json:"amount"`
+	Currency     string `json:"currency"`
+	DateCreation int64  `json:"date_creation"`
+}
+
+// Transfer is a payment that, with the others of a settle-up, zeroes out
+// the balances of a workspace.
+type Transfer struct {
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id"`
+	Amount     Money  `json:"amount"`
+	Currency   string `json:"currency"`
+}
@@ -0,0 +1,9 @@
+package entities
+
+type User struct {
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	DateCreation int64  `json:"date_creation"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+// Workspace isolates the data of one team. Its members only see the data
+// of the workspaces they belong to.
+type Workspace struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	OwnerID      string `json:"owner_id"`
+	DateCreation int64  `json:"date_creation"`
+	// Role is the role in the workspace of the member it was retrieved
+	// for.
+	Role string `json:"role,omitempty"`
+}
+
+// WorkspaceMember is a user belonging to a workspace.
+type WorkspaceMember struct {
+	WorkspaceID string `json:"workspace_id"`
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	DateJoined  int64  `json:"date_joined"`
+}
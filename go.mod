@@ -4,6 +4,7 @@ go 1.20
 
 require (
 	github.com/go-openapi/runtime v0.28.0
+	github.com/golang-jwt/jwt/v5 v5.2.1
 	github.com/golang-migrate/migrate/v4 v4.17.0
 	github.com/golang/mock v1.6.0
 	github.com/gorilla/mux v1.8.1
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// CreateAPIKey is the HTTP handler for issuing an API key.
+// swagger:route POST /api-keys APIKey createAPIKeyRequest
+// Issues an API key acting on behalf of the authenticated user, limited to
+// the given scopes. The key is only returned by this call.
+// Responses:
+//
+//	201: apiKeyResponse
+//	400: errorResponse
+//	500: errorResponse
+func CreateAPIKey(svc services.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var k entities.APIKey
+		if err := json.NewDecoder(r.Body).Decode(&k); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.CreateAPIKey(ctx, &k); err != nil {
+			if errors.Is(err, services.ErrInvalidAPIKey) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to create API key")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(k)
+	}
+}
+
+// ListAPIKeys is the HTTP handler for listing API keys.
+// swagger:route GET /api-keys APIKey listAPIKeysRequest
+// Lists the API keys of the authenticated user, without the keys
+// themselves.
+// Responses:
+//
+//	200: apiKeysResponse
+//	500: errorResponse
+func ListAPIKeys(svc services.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		keys, err := svc.ListAPIKeys(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list API keys")
+			return
+		}
+
+		json.NewEncoder(w).Encode(keys)
+	}
+}
+
+// RevokeAPIKey is the HTTP handler for revoking an API key.
+// swagger:route DELETE /api-keys/{id} APIKey revokeAPIKeyRequest
+// Revokes an API key immediately.
+// Responses:
+//
+//	200: okResponse
+//	404: errorResponse
+//	500: errorResponse
+func RevokeAPIKey(svc services.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.RevokeAPIKey(ctx, id); err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "API key not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to revoke API key")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// RotateAPIKey is the HTTP handler for rotating an API key.
+// swagger:route POST /api-keys/{id}/rotate APIKey rotateAPIKeyRequest
+// Issues a replacement for an API key with the same name, scopes and
+// expiry. The old key keeps working for grace seconds, 0 by default.
+// Responses:
+//
+//	201: apiKeyResponse
+//	400: errorResponse
+//	404: errorResponse
+//	500: errorResponse
+func RotateAPIKey(svc services.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+		grace, err := parseIntParam(r.URL.Query().Get("grace"), "grace")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var gracePeriod time.Duration
+		if grace != nil {
+			gracePeriod = time.Duration(*grace) * time.Second
+		}
+
+		ctx := r.Context()
+		k, err := svc.RotateAPIKey(ctx, id, gracePeriod)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidAPIKey):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "API key not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to rotate API key")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(k)
+	}
+}
+
+// swagger:parameters createAPIKeyRequest
+type createAPIKeyRequest struct {
+	// in:body
+	Body struct {
+		// Required: true
+		Name string `json:"name"`
+		// Required: true
+		// items.enum: expenses:read,expenses:write,budgets:write,admin
+		Scopes []string `json:"scopes"`
+		// Unix timestamp after which the key stops working.
+		ExpiresAt int64 `json:"expires_at"`
+	}
+}
+
+// swagger:parameters revokeAPIKeyRequest
+type apiKeyIDParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters rotateAPIKeyRequest
+type rotateAPIKeyRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// Seconds the old key keeps working, up to a week.
+	// in:query
+	Grace int64 `json:"grace"`
+}
+
+// swagger:response apiKeyResponse
+type apiKeyResponse struct {
+	// in:body
+	Body apiKey
+}
+
+// swagger:response apiKeysResponse
+type apiKeysResponse struct {
+	// in:body
+	Body []apiKey
+}
+
+type apiKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// The key, only returned when it is issued.
+	Key string `json:"key"`
+	// The start of the key, to tell keys apart.
+	Prefix       string   `json:"prefix"`
+	Scopes       []string `json:"scopes"`
+	OwnerID      string   `json:"owner_id"`
+	DateCreation int64    `json:"date_creation"`
+	ExpiresAt    int64    `json:"expires_at"`
+	RevokedAt    int64    `json:"revoked_at"`
+	LastUsedAt   int64    `json:"last_used_at"`
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// SubmitExpense is the HTTP handler for submitting an expense for approval.
+// swagger:route POST /expenses/{id}/submit Approval submitExpenseRequest
+// Submits a draft or rejected expense of the authenticated user for
+// approval.
+// Responses:
+//
+//	200: expenseResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+func SubmitExpense(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		e, err := svc.SubmitExpense(ctx, mux.Vars(r)["id"])
+		writeTransition(w, e, err, "Failed to submit expense")
+	}
+}
+
+// ApproveExpense is the HTTP handler for approving an expense.
+// swagger:route POST /expenses/{id}/approve Approval approveExpenseRequest
+// Approves a submitted expense of another member of the workspace. Approved
+// expenses can no longer be updated or deleted.
+// Responses:
+//
+//	200: expenseResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+func ApproveExpense(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		e, err := svc.ApproveExpense(ctx, mux.Vars(r)["id"])
+		writeTransition(w, e, err, "Failed to approve expense")
+	}
+}
+
+// RejectExpense is the HTTP handler for rejecting an expense.
+// swagger:route POST /expenses/{id}/reject Approval rejectExpenseRequest
+// Sends a submitted expense of another member of the workspace back to its
+// owner with a reason. The owner may fix and submit it again.
+// Responses:
+//
+//	200: expenseResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+func RejectExpense(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		e, err := svc.RejectExpense(ctx, mux.Vars(r)["id"], body.Reason)
+		if errors.Is(err, services.ErrReasonRequired) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeTransition(w, e, err, "Failed to reject expense")
+	}
+}
+
+// ReimburseExpense is the HTTP handler for recording a reimbursement.
+// swagger:route POST /expenses/{id}/reimburse Approval reimburseExpenseRequest
+// Records that an approved expense of another member of the workspace was
+// paid back to its owner.
+// Responses:
+//
+//	200: expenseResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+func ReimburseExpense(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		e, err := svc.ReimburseExpense(ctx, mux.Vars(r)["id"])
+		writeTransition(w, e, err, "Failed to reimburse expense")
+	}
+}
+
+// ListPendingApprovals is the HTTP handler for listing the expenses
+// waiting for approval.
+// swagger:route GET /approvals Approval listPendingApprovalsRequest
+// Lists the submitted expenses of every member of the workspace, oldest
+// first.
+// Responses:
+//
+//	200: expensesResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListPendingApprovals(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		expenses, err := svc.ListPendingApprovals(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list pending approvals")
+			return
+		}
+
+		json.NewEncoder(w).Encode(expenses)
+	}
+}
+
+// writeTransition replies with the expense a status transition left, or
+// with the status matching its error.
+func writeTransition(w http.ResponseWriter, e *entities.Expense, err error, msg string) {
+	switch {
+	case err == nil:
+		json.NewEncoder(w).Encode(e)
+	case errors.Is(err, services.ErrNotFound):
+		http.Error(w, "Expense not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		serviceError(w, err, msg)
+	}
+}
+
+// swagger:parameters submitExpenseRequest approveExpenseRequest reimburseExpenseRequest
+type transitionExpenseRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters rejectExpenseRequest
+type rejectExpenseRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body struct {
+		// Why the expense is rejected, shown to its owner.
+		// Required: true
+		Reason string `json:"reason"`
+	}
+}
+
+// swagger:response expensesResponse
+type expensesResponse struct {
+	// in:body
+	Body []struct {
+		ID           string   `json:"id"`
+		Description  string   `json:"description"`
+		Amount       float64  `json:"amount"`
+		Currency     string   `json:"currency"`
+		DateCreation int64    `json:"date_creation"`
+		CategoryID   string   `json:"category_id"`
+		Tags         []string `json:"tags"`
+		OwnerID      string   `json:"owner_id"`
+		Status       string   `json:"status"`
+		StatusReason string   `json:"status_reason"`
+	}
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// maxMultipartOverhead is allowed on top of services.MaxAttachmentSize for
+// the multipart boundaries and part headers.
+const maxMultipartOverhead = 1 << 20
+
+// UploadAttachment is the HTTP handler for attaching a receipt to an
+// expense.
+// swagger:route POST /expenses/{id}/attachments Attachment uploadAttachmentRequest
+// Attaches a receipt image or PDF, sent as the file field of a multipart
+// form, to an expense.
+// Responses:
+//
+//	200: attachmentResponse
+//	201: attachmentResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	413: errorResponse
+//	415: errorResponse
+//	500: errorResponse
+func UploadAttachment(svc services.AttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		expenseID := mux.Vars(r)["id"]
+
+		r.Body = http.MaxBytesReader(w, r.Body, services.MaxAttachmentSize+maxMultipartOverhead)
+		mr, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Stream the file part instead of buffering the whole form.
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				http.Error(w, "The file field is required", http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				writeUploadError(w, err)
+				return
+			}
+			if part.FormName() != "file" {
+				continue
+			}
+
+			ctx := r.Context()
+			a, created, err := svc.UploadAttachment(ctx, expenseID, part.FileName(), part)
+			if err != nil {
+				writeUploadError(w, err)
+				return
+			}
+
+			if created {
+				w.WriteHeader(http.StatusCreated)
+			}
+			json.NewEncoder(w).Encode(a)
+			return
+		}
+	}
+}
+
+// writeUploadError maps an error reading or storing an upload to a status.
+func writeUploadError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge), errors.Is(err, services.ErrAttachmentTooLarge):
+		http.Error(w, "Attachment too large", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, services.ErrUnsupportedAttachment):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, services.ErrNotFound):
+		http.Error(w, "Expense not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidAttachment):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		serviceError(w, err, "Failed to upload attachment")
+	}
+}
+
+// ListAttachments is the HTTP handler for listing the attachments of an
+// expense.
+// swagger:route GET /expenses/{id}/attachments Attachment listAttachmentsRequest
+// Lists the attachments of an expense.
+// Responses:
+//
+//	200: attachmentsResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func ListAttachments(svc services.AttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		expenseID := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		attachments, err := svc.ListAttachments(ctx, expenseID)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Expense not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to list attachments")
+			return
+		}
+
+		json.NewEncoder(w).Encode(attachments)
+	}
+}
+
+// DownloadAttachment is the HTTP handler for downloading an attachment.
+// swagger:route GET /expenses/{id}/attachments/{attachment_id} Attachment attachmentIDRequest
+// Downloads the file of an attachment.
+// Produces:
+// - application/octet-stream
+// Responses:
+//
+//	200: attachmentFileResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func DownloadAttachment(svc services.AttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		ctx := r.Context()
+		a, err := svc.GetAttachment(ctx, vars["id"], vars["attachment_id"])
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Attachment not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to retrieve attachment")
+			return
+		}
+		// The content of an attachment never changes.
+		etag := `"` + a.SHA256 + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		file, err := svc.OpenAttachment(ctx, a)
+		if err != nil {
+			serviceError(w, err, "Failed to retrieve attachment")
+			return
+		}
+		defer file.Close()
+
+		w.Header().Set("Content-Type", a.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("ETag", etag)
+		if _, err := io.Copy(w, file); err != nil {
+			log.Printf("Error sending attachment %s: %v", a.ID, err)
+		}
+	}
+}
+
+// DeleteAttachment is the HTTP handler for removing an attachment.
+// swagger:route DELETE /expenses/{id}/attachments/{attachment_id} Attachment deleteAttachmentRequest
+// Removes an attachment from an expense.
+// Responses:
+//
+//	200: okResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func DeleteAttachment(svc services.AttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		ctx := r.Context()
+		if err := svc.DeleteAttachment(ctx, vars["id"], vars["attachment_id"]); err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Attachment not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to delete attachment")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:parameters uploadAttachmentRequest
+type uploadAttachmentRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// The receipt image or PDF.
+	// in:formData
+	// Required: true
+	// swagger:file
+	File interface{} `json:"file"`
+}
+
+// swagger:parameters listAttachmentsRequest
+type expenseIDPathParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters attachmentIDRequest deleteAttachmentRequest
+type attachmentIDParameters struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:path
+	// Required: true
+	AttachmentID string `json:"attachment_id"`
+}
+
+// swagger:response attachmentResponse
+type attachmentResponse struct {
+	// in:body
+	Body attachment
+}
+
+// swagger:response attachmentsResponse
+type attachmentsResponse struct {
+	// in:body
+	Body []attachment
+}
+
+// The attachment bytes.
+// swagger:response attachmentFileResponse
+type attachmentFileResponse struct {
+	// in:body
+	Body []byte
+}
+
+type attachment struct {
+	ID          string `json:"id"`
+	ExpenseID   string `json:"expense_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Hex SHA-256 of the file, also sent as its ETag.
+	SHA256       string `json:"sha256"`
+	DateCreation int64  `json:"date_creation"`
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/demo-talent/audit"
+	"github.com/demo-talent/services"
+)
+
+// RequestIDHeader carries the ID of a request, kept from the client when
+// valid and generated otherwise.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength matches the size of the audit_events.request_id
+// column.
+const maxRequestIDLength = 128
+
+// TrackRequest is a middleware putting the ID and client IP of each
+// request in its context, for the audit log, and echoing the ID in the
+// response.
+func TrackRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := audit.WithRequest(r.Context(), audit.Request{ID: id, IP: ip})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// validRequestID reports whether a client-provided request ID is short
+// and printable ASCII, so it is safe to store and echo.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ListAuditEvents is the HTTP handler for reading the audit log.
+// swagger:route GET /audit Audit listAuditEventsRequest
+// Lists who changed an entity of the workspace, how and when, oldest
+// first. Needs an approver or an admin.
+// Responses:
+//
+//	200: auditEventsResponse
+//	400: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListAuditEvents(svc services.AuditService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		events, err := svc.ListAuditEvents(ctx, r.URL.Query().Get("entity_id"))
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidFilter) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to list audit events")
+			return
+		}
+
+		json.NewEncoder(w).Encode(events)
+	}
+}
+
+// swagger:parameters listAuditEventsRequest
+type listAuditEventsParameters struct {
+	// The ID of the entity, like an expense.
+	// in:query
+	// Required: true
+	EntityID string `json:"entity_id"`
+}
+
+// swagger:response auditEventsResponse
+type auditEventsResponse struct {
+	// in:body
+	Body []struct {
+		ID         string `json:"id"`
+		EntityType string `json:"entity_type"`
+		EntityID   string `json:"entity_id"`
+		// One of create, update, delete, restore, submit, approve,
+		// reject or reimburse.
+		Action   string `json:"action"`
+		ActorID  string `json:"actor_id"`
+		APIKeyID string `json:"api_key_id"`
+		// The entity before the change, null on create.
+		Before map[string]interface{} `json:"before"`
+		// The entity after the change, null on delete.
+		After map[string]interface{} `json:"after"`
+		// The fields that changed, with their value before and after.
+		Changes map[string]struct {
+			Before interface{} `json:"before"`
+			After  interface{} `json:"after"`
+		} `json:"changes"`
+		RequestID    string `json:"request_id"`
+		IP           string `json:"ip"`
+		DateCreation int64  `json:"date_creation"`
+	}
+}
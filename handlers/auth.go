@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// Authenticate is a middleware putting in the request context the user a
+// JWT bearer token or an API key acts on behalf of, registering the users
+// of new tokens. Failures are answered with a 401 problem response.
+func Authenticate(tokens *auth.TokenVerifier, apiKeys services.APIKeyService, users services.UserService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			credentials = strings.TrimSpace(credentials)
+			if credentials == "" {
+				unauthorized(w, "", "A bearer token or an API key is required")
+				return
+			}
+
+			ctx := r.Context()
+			var (
+				principal auth.Principal
+				err       error
+			)
+			switch {
+			case strings.EqualFold(scheme, "Bearer"):
+				principal, err = tokens.Verify(credentials)
+			case strings.EqualFold(scheme, "ApiKey"):
+				principal, err = apiKeys.Authenticate(ctx, credentials)
+			default:
+				unauthorized(w, "", "A bearer token or an API key is required")
+				return
+			}
+			switch {
+			case errors.Is(err, auth.ErrInvalidToken), errors.Is(err, services.ErrUnknownAPIKey):
+				unauthorized(w, "invalid_token", err.Error())
+				return
+			case err != nil:
+				writeProblem(w, http.StatusInternalServerError, "Failed to authenticate")
+				return
+			}
+
+			u, err := users.GetUserByID(ctx, principal.UserID)
+			if errors.Is(err, services.ErrNotFound) && principal.APIKeyID == "" {
+				u, err = users.ProvisionUser(ctx, principal)
+			}
+			switch {
+			case errors.Is(err, services.ErrNotFound):
+				unauthorized(w, "invalid_token", "The credentials name an unknown user")
+				return
+			case errors.Is(err, services.ErrUnverifiedEmail):
+				unauthorized(w, "invalid_token", "The token of a new user must carry a verified email")
+				return
+			case errors.Is(err, services.ErrEmailTaken):
+				writeProblem(w, http.StatusConflict, "The email of the token is registered to another user")
+				return
+			case err != nil:
+				writeProblem(w, http.StatusInternalServerError, "Failed to authenticate")
+				return
+			}
+
+			principal.InstanceRole = auth.Role(u.Role)
+			ctx = auth.WithPrincipal(ctx, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope is a middleware rejecting with 403 the requests whose
+// principal lacks the scope they need. It must run after Authenticate.
+func RequireScope() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := requiredScope(r)
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				unauthorized(w, "", "Authentication required")
+				return
+			}
+			if !principal.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				writeProblem(w, http.StatusForbidden, "The "+scope+" scope is required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WorkspaceHeader selects the workspace a request acts on.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// SelectWorkspace is a middleware putting in the request principal the
+// workspace named by the X-Workspace-ID header, or else the first one the
+// user joined, with the role of the user in it. It must run after
+// Authenticate.
+func SelectWorkspace(workspaces services.WorkspaceService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok {
+				unauthorized(w, "", "Authentication required")
+				return
+			}
+
+			id := strings.TrimSpace(r.Header.Get(WorkspaceHeader))
+			workspace, err := workspaces.SelectWorkspace(ctx, id)
+			switch {
+			case errors.Is(err, services.ErrNotFound):
+				writeProblem(w, http.StatusForbidden, "You are not a member of workspace "+id)
+				return
+			case errors.Is(err, services.ErrNoWorkspace):
+			case err != nil:
+				writeProblem(w, http.StatusInternalServerError, "Failed to select the workspace")
+				return
+			default:
+				principal.WorkspaceID = workspace.ID
+				principal.Role = auth.Role(workspace.Role)
+				ctx = auth.WithPrincipal(ctx, principal)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// requiredScope is the scope a request needs.
+func requiredScope(r *http.Request) string {
+	path := r.URL.Path
+	if strings.HasPrefix(path, "/admin/") || hasPathPrefix(path, "/api-keys") {
+		return auth.ScopeAdmin
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return auth.ScopeExpensesRead
+	}
+	switch {
+	case hasPathPrefix(path, "/users"), hasPathPrefix(path, "/workspaces"):
+		return auth.ScopeAdmin
+	case hasPathPrefix(path, "/categories"), hasPathPrefix(path, "/budgets"):
+		return auth.ScopeBudgetsWrite
+	}
+	return auth.ScopeExpensesWrite
+}
+
+// hasPathPrefix reports whether path is prefix or lies below it.
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// unauthorized replies with 401 and the challenges of both schemes.
+func unauthorized(w http.ResponseWriter, code, detail string) {
+	challenge := ""
+	if code != "" {
+		challenge = ` error="` + code + `"`
+	}
+	w.Header().Add("WWW-Authenticate", "Bearer"+challenge)
+	w.Header().Add("WWW-Authenticate", "ApiKey"+challenge)
+	writeProblem(w, http.StatusUnauthorized, detail)
+}
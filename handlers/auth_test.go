@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/demo-talent/services/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestRequireScope(t *testing.T) {
+	withPrincipal := func(p auth.Principal) mux.MiddlewareFunc {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), p)))
+			})
+		}
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+
+	tests := []struct {
+		name   string
+		scopes []string
+		method string
+		path   string
+		want   int
+	}{
+		{name: "ExpenseWrite", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPost, path: "/expenses", want: http.StatusNoContent},
+		{name: "ExpenseReadWithoutScope", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodGet, path: "/expenses", want: http.StatusForbidden},
+		{name: "UserRole", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPut, path: "/users/user_2/role", want: http.StatusForbidden},
+		{name: "CreateWorkspace", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPost, path: "/workspaces", want: http.StatusForbidden},
+		{name: "AddWorkspaceMember", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPost, path: "/workspaces/workspace_1/members", want: http.StatusForbidden},
+		{name: "RemoveWorkspaceMember", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodDelete, path: "/workspaces/workspace_1/members/user_2", want: http.StatusForbidden},
+		{name: "WorkspaceMemberRole", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPut, path: "/workspaces/workspace_1/members/user_2/role", want: http.StatusForbidden},
+		{name: "WorkspaceMemberRoleAsAdmin", scopes: []string{auth.ScopeAdmin}, method: http.MethodPut, path: "/workspaces/workspace_1/members/user_2/role", want: http.StatusNoContent},
+		{name: "CreateCategory", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPost, path: "/categories", want: http.StatusForbidden},
+		{name: "UpdateCategory", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPut, path: "/categories/category_1", want: http.StatusForbidden},
+		{name: "DeleteCategory", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodDelete, path: "/categories/category_1", want: http.StatusForbidden},
+		{name: "CreateBudget", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPost, path: "/budgets", want: http.StatusForbidden},
+		{name: "UpdateBudget", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodPut, path: "/budgets/budget_1", want: http.StatusForbidden},
+		{name: "DeleteBudget", scopes: []string{auth.ScopeExpensesWrite}, method: http.MethodDelete, path: "/budgets/budget_1", want: http.StatusForbidden},
+		{name: "BudgetWrite", scopes: []string{auth.ScopeBudgetsWrite}, method: http.MethodPut, path: "/budgets/budget_1", want: http.StatusNoContent},
+		{name: "BudgetRead", scopes: []string{auth.ScopeExpensesRead}, method: http.MethodGet, path: "/budgets/budget_1", want: http.StatusNoContent},
+		{name: "APIKeys", scopes: []string{auth.ScopeExpensesRead}, method: http.MethodGet, path: "/api-keys", want: http.StatusForbidden},
+		{name: "Interactive", method: http.MethodPut, path: "/users/user_2/role", want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mux.NewRouter()
+			r.Use(withPrincipal(auth.Principal{UserID: "user_1", Scopes: tt.scopes}), RequireScope())
+			r.PathPrefix("/").HandlerFunc(ok)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.want {
+				t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_ProvisionsNewUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	tokens, err := auth.NewTokenVerifier(auth.JWTConfig{HMACSecret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(claims jwt.MapClaims) string {
+		claims["sub"], claims["exp"] = "auth0|ana", time.Now().Add(time.Hour).Unix()
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+
+	tests := []struct {
+		name      string
+		token     string
+		want      int
+		setupMock func(users *mocks.MockUserService)
+	}{
+		{
+			name:  "VerifiedEmail",
+			token: sign(jwt.MapClaims{"email": "ana@example.com", "email_verified": true}),
+			want:  http.StatusNoContent,
+			setupMock: func(users *mocks.MockUserService) {
+				users.EXPECT().GetUserByID(gomock.Any(), "auth0|ana").Return(nil, services.ErrNotFound)
+				users.EXPECT().ProvisionUser(gomock.Any(), auth.Principal{UserID: "auth0|ana", Email: "ana@example.com"}).
+					Return(&entities.User{ID: "auth0|ana", Role: "member"}, nil)
+			},
+		},
+		{
+			name:  "UnverifiedEmail",
+			token: sign(jwt.MapClaims{"email": "ana@example.com"}),
+			want:  http.StatusUnauthorized,
+			setupMock: func(users *mocks.MockUserService) {
+				users.EXPECT().GetUserByID(gomock.Any(), "auth0|ana").Return(nil, services.ErrNotFound)
+				users.EXPECT().ProvisionUser(gomock.Any(), auth.Principal{UserID: "auth0|ana"}).Return(nil, services.ErrUnverifiedEmail)
+			},
+		},
+		{
+			name:  "EmailTaken",
+			token: sign(jwt.MapClaims{"email": "ana@example.com", "email_verified": true}),
+			want:  http.StatusConflict,
+			setupMock: func(users *mocks.MockUserService) {
+				users.EXPECT().GetUserByID(gomock.Any(), "auth0|ana").Return(nil, services.ErrNotFound)
+				users.EXPECT().ProvisionUser(gomock.Any(), gomock.Any()).Return(nil, services.ErrEmailTaken)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := mocks.NewMockUserService(ctrl)
+			tt.setupMock(users)
+
+			r := mux.NewRouter()
+			r.Use(Authenticate(tokens, nil, users))
+			r.PathPrefix("/").HandlerFunc(ok)
+
+			req := httptest.NewRequest(http.MethodGet, "/expenses", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("GET /expenses status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+)
+
+// RunExpenseBatch is the HTTP handler for changing several expenses at
+// once.
+// swagger:route POST /expenses:batch Expense runExpenseBatchRequest
+// Creates, updates and deletes up to 100 expenses in a single transaction,
+// undoing the whole batch or only the failed operations on failure.
+// Responses:
+//
+//	200: batchResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: batchResponse
+//	409: batchResponse
+//	412: batchResponse
+//	428: errorResponse
+//	500: errorResponse
+func RunExpenseBatch(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b entities.ExpenseBatch
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		result, err := svc.RunBatch(ctx, &b)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidBatch):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			case errors.Is(err, services.ErrVersionRequired):
+				http.Error(w, err.Error(), http.StatusPreconditionRequired)
+				return
+			}
+			serviceError(w, err, "Failed to run batch")
+			return
+		}
+
+		status := http.StatusOK
+		body := batchResult{Committed: result.Committed, Results: make([]batchItemResult, len(result.Results))}
+		for i, res := range result.Results {
+			item := batchItemResult{Index: res.Index, Op: res.Op, Status: batchItemStatus(res.Op, res.Err), Expense: res.Expense}
+			if res.Err != nil {
+				item.Error = res.Err.Error()
+				if item.Status == http.StatusInternalServerError {
+					log.Printf("Error running batch operation %d: %v", res.Index, res.Err)
+					item.Error = "Failed to run operation"
+				}
+				if !result.Committed && !errors.Is(res.Err, services.ErrBatchAborted) {
+					status = item.Status
+				}
+			}
+			body.Results[i] = item
+		}
+
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+// batchItemStatus is the status an operation of a batch would have had as
+// a request of its own.
+func batchItemStatus(op entities.BatchOp, err error) int {
+	switch {
+	case err == nil && op == entities.BatchCreate:
+		return http.StatusCreated
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, services.ErrUnknownCategory), errors.Is(err, services.ErrInvalidTag), errors.Is(err, services.ErrInvalidCurrency):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrExpenseLocked), errors.Is(err, services.ErrSplitMismatch):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, services.ErrBatchAborted):
+		return http.StatusFailedDependency
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// swagger:parameters runExpenseBatchRequest
+type runExpenseBatchRequest struct {
+	// in:body
+	Body struct {
+		// all_or_nothing or per_item.
+		Mode       string `json:"mode"`
+		Operations []struct {
+			// create, update or delete.
+			// Required: true
+			Op string `json:"op"`
+			// The expense to update or delete.
+			ID string `json:"id"`
+			// The version the expense to update or delete must still be
+			// at, from its ETag. Required for updates and deletions.
+			Version int `json:"version"`
+			// The expense to create, or the new fields of the expense to
+			// update, as in the single-expense endpoints.
+			Expense struct {
+				Description string   `json:"description"`
+				Amount      float64  `json:"amount"`
+				Currency    string   `json:"currency"`
+				CategoryID  string   `json:"category_id"`
+				Tags        []string `json:"tags"`
+			} `json:"expense"`
+		} `json:"operations"`
+	}
+}
+
+// swagger:response batchResponse
+type batchResponse struct {
+	// in:body
+	Body batchResult
+}
+
+type batchResult struct {
+	// Whether the changes of the batch were kept.
+	Committed bool              `json:"committed"`
+	Results   []batchItemResult `json:"results"`
+}
+
+type batchItemResult struct {
+	Index int              `json:"index"`
+	Op    entities.BatchOp `json:"op"`
+	// The status the operation would have had as a request of its own.
+	Status int `json:"status"`
+	// The expense as the operation left it, except for deletions.
+	Expense *entities.Expense `json:"expense,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// CreateBudget is the HTTP handler for creating a new budget.
+// swagger:route POST /budgets Budget createBudgetRequest
+// Creates a new budget for a category.
+// Responses:
+//
+//	201: budgetResponse
+//	400: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func CreateBudget(svc services.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b entities.Budget
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.CreateBudget(ctx, &b); err != nil {
+			if errors.Is(err, services.ErrInvalidBudget) || errors.Is(err, services.ErrUnknownCategory) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to create budget")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(b)
+	}
+}
+
+// ListBudgets is the HTTP handler for listing budgets.
+// swagger:route GET /budgets Budget listBudgetsRequest
+// Lists the budgets, optionally only those of a category.
+// Responses:
+//
+//	200: budgetsResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListBudgets(svc services.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		budgets, err := svc.ListBudgets(ctx, r.URL.Query().Get("category_id"))
+		if err != nil {
+			serviceError(w, err, "Failed to list budgets")
+			return
+		}
+
+		json.NewEncoder(w).Encode(budgets)
+	}
+}
+
+// GetBudget is the HTTP handler for retrieving a budget by ID.
+// swagger:route GET /budgets/{id} Budget getBudgetRequest
+// Retrieves a budget by ID.
+// Responses:
+//
+//	200: budgetResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func GetBudget(svc services.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		budget, err := svc.GetBudgetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Budget not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to retrieve budget")
+			return
+		}
+
+		json.NewEncoder(w).Encode(budget)
+	}
+}
+
+// UpdateBudget is the HTTP handler for updating a budget.
+// swagger:route PUT /budgets/{id} Budget updateBudgetRequest
+// Updates a budget.
+// Responses:
+//
+//	200: okResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func UpdateBudget(svc services.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b entities.Budget
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b.ID = mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.UpdateBudget(ctx, &b); err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidBudget), errors.Is(err, services.ErrUnknownCategory):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Budget not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to update budget")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// DeleteBudget is the HTTP handler for deleting a budget by ID.
+// swagger:route DELETE /budgets/{id} Budget deleteBudgetRequest
+// Deletes a budget by ID.
+// Responses:
+//
+//	200: okResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func DeleteBudget(svc services.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.DeleteBudget(ctx, id); err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Budget not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to delete budget")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GetBudgetStatus is the HTTP handler for a budget's spending status.
+// swagger:route GET /budgets/{id}/status Budget budgetStatusRequest
+// Computes the spent, remaining and percentage of a budget in the current
+// period, or in the period containing at.
+// Responses:
+//
+//	200: budgetStatusResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	422: errorResponse
+//	500: errorResponse
+func GetBudgetStatus(svc services.BudgetService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+		at := time.Now()
+		atParam, err := parseIntParam(r.URL.Query().Get("at"), "at")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if atParam != nil {
+			at = time.Unix(*atParam, 0)
+		}
+
+		ctx := r.Context()
+		status, err := svc.GetBudgetStatus(ctx, id, at)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Budget not found", http.StatusNotFound)
+			case errors.Is(err, services.ErrMissingRate):
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			default:
+				serviceError(w, err, "Failed to compute budget status")
+			}
+			return
+		}
+
+		json.NewEncoder(w).Encode(status)
+	}
+}
+
+// swagger:parameters createBudgetRequest
+type createBudgetRequest struct {
+	// in:body
+	Body struct {
+		// Required: true
+		CategoryID string `json:"category_id"`
+		// Required: true
+		// enum: month,quarter,year
+		Period string `json:"period"`
+		// Limit in the base currency.
+		// Required: true
+		Limit float64 `json:"limit"`
+		// Add what was left unspent in the previous period.
+		Rollover bool `json:"rollover"`
+	}
+}
+
+// swagger:parameters updateBudgetRequest
+type updateBudgetRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body struct {
+		// Required: true
+		CategoryID string `json:"category_id"`
+		// Required: true
+		// enum: month,quarter,year
+		Period string `json:"period"`
+		// Limit in the base currency.
+		// Required: true
+		Limit float64 `json:"limit"`
+		// Add what was left unspent in the previous period.
+		Rollover bool `json:"rollover"`
+	}
+}
+
+// swagger:parameters getBudgetRequest deleteBudgetRequest
+type budgetIDParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters listBudgetsRequest
+type listBudgetsParameters struct {
+	// in:query
+	CategoryID string `json:"category_id"`
+}
+
+// swagger:parameters budgetStatusRequest
+type budgetStatusParameters struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// Unix timestamp within the period to report on, now by default.
+	// in:query
+	At int64 `json:"at"`
+}
+
+// swagger:response budgetResponse
+type budgetResponse struct {
+	// in:body
+	Body struct {
+		ID           string  `json:"id"`
+		CategoryID   string  `json:"category_id"`
+		Period       string  `json:"period"`
+		Limit        float64 `json:"limit"`
+		Rollover     bool    `json:"rollover"`
+		DateCreation int64   `json:"date_creation"`
+	}
+}
+
+// swagger:response budgetsResponse
+type budgetsResponse struct {
+	// in:body
+	Body []struct {
+		ID           string  `json:"id"`
+		CategoryID   string  `json:"category_id"`
+		Period       string  `json:"period"`
+		Limit        float64 `json:"limit"`
+		Rollover     bool    `json:"rollover"`
+		DateCreation int64   `json:"date_creation"`
+	}
+}
+
+// swagger:response budgetStatusResponse
+type budgetStatusResponse struct {
+	// in:body
+	Body struct {
+		BudgetID    string    `json:"budget_id"`
+		Currency    string    `json:"currency"`
+		PeriodStart time.Time `json:"period_start"`
+		PeriodEnd   time.Time `json:"period_end"`
+		Limit       float64   `json:"limit"`
+		RolledOver  float64   `json:"rolled_over"`
+		Spent       float64   `json:"spent"`
+		Remaining   float64   `json:"remaining"`
+		Percentage  float64   `json:"percentage"`
+		OverLimit   bool      `json:"over_limit"`
+	}
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// CreateCategory is the HTTP handler for creating a new category.
+// swagger:route POST /categories Category createCategoryRequest
+// Creates a new category. Names are unique within a workspace.
+// Responses:
+//
+//	201: categoryResponse
+//	400: errorResponse
+//	403: problemResponse
+//	409: errorResponse
+//	500: errorResponse
+func CreateCategory(svc services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var c entities.Category
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.CreateCategory(ctx, &c); err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidCategory):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrCategoryExists):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				serviceError(w, err, "Failed to create category")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(c)
+	}
+}
+
+// ListCategories is the HTTP handler for listing all categories.
+// swagger:route GET /categories Category listCategoriesRequest
+// Lists all categories ordered by name.
+// Responses:
+//
+//	200: categoriesResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListCategories(svc services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		categories, err := svc.ListCategories(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list categories")
+			return
+		}
+
+		json.NewEncoder(w).Encode(categories)
+	}
+}
+
+// GetCategory is the HTTP handler for retrieving a category by ID.
+// swagger:route GET /categories/{id} Category getCategoryRequest
+// Retrieves a category by ID.
+// Responses:
+//
+//	200: categoryResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func GetCategory(svc services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		category, err := svc.GetCategoryByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Category not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to retrieve category")
+			return
+		}
+
+		json.NewEncoder(w).Encode(category)
+	}
+}
+
+// UpdateCategory is the HTTP handler for updating a category.
+// swagger:route PUT /categories/{id} Category updateCategoryRequest
+// Updates a category. Names are unique within a workspace.
+// Responses:
+//
+//	200: okResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+func UpdateCategory(svc services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var c entities.Category
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.ID = mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.UpdateCategory(ctx, &c); err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidCategory):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Category not found", http.StatusNotFound)
+			case errors.Is(err, services.ErrCategoryExists):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				serviceError(w, err, "Failed to update category")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// DeleteCategory is the HTTP handler for deleting a category by ID.
+// swagger:route DELETE /categories/{id} Category deleteCategoryRequest
+// Deletes a category by ID. Its expenses become uncategorized.
+// Responses:
+//
+//	200: okResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func DeleteCategory(svc services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.DeleteCategory(ctx, id); err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Category not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to delete category")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:parameters createCategoryRequest
+type createCategoryRequest struct {
+	// in:body
+	Body struct {
+		// Required: true
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+}
+
+// swagger:parameters updateCategoryRequest
+type updateCategoryRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body struct {
+		// Required: true
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+}
+
+// swagger:parameters getCategoryRequest deleteCategoryRequest
+type categoryIDParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:response categoryResponse
+type categoryResponse struct {
+	// in:body
+	Body struct {
+		ID           string `json:"id"`
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		DateCreation int64  `json:"date_creation"`
+	}
+}
+
+// swagger:response categoriesResponse
+type categoriesResponse struct {
+	// in:body
+	Body []struct {
+		ID           string `json:"id"`
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		DateCreation int64  `json:"date_creation"`
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/demo-talent/services"
+	"github.com/demo-talent/services/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestCategoryNameTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taken := fmt.Errorf("%w: Travel", services.ErrCategoryExists)
+	mockSvc := mocks.NewMockCategoryService(ctrl)
+	mockSvc.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(taken)
+	mockSvc.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(taken)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/categories", CreateCategory(mockSvc)).Methods("POST")
+	r.HandleFunc("/categories/{id}", UpdateCategory(mockSvc)).Methods("PUT")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "Create", method: http.MethodPost, path: "/categories"},
+		{name: "Update", method: http.MethodPut, path: "/categories/category_1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"name": "Travel"}`)))
+			if rec.Code != http.StatusConflict {
+				t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, rec.Code, http.StatusConflict)
+			}
+		})
+	}
+}
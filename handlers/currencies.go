@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/demo-talent/services"
+)
+
+// maxRatesFileSize bounds exchange rate uploads; the full ECB history is
+// well below it.
+const maxRatesFileSize = 32 << 20
+
+// GetExpenseTotals is the HTTP handler for summing expenses in the base
+// currency.
+// swagger:route GET /expenses/totals Expense expenseTotalsRequest
+// Sums the expenses matching the given filters, per currency and converted
+// to the base currency at the exchange rate in effect on each expense date.
+// Responses:
+//
+//	200: expenseTotalsResponse
+//	400: errorResponse
+//	422: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func GetExpenseTotals(svc services.CurrencyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, err := parseExpenseFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		totals, err := svc.ExpenseTotals(ctx, f)
+		if err != nil {
+			if errors.Is(err, services.ErrMissingRate) {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			serviceError(w, err, "Failed to compute expense totals")
+			return
+		}
+
+		json.NewEncoder(w).Encode(totals)
+	}
+}
+
+// ImportExchangeRates is the HTTP handler for loading exchange rates from
+// a file.
+// swagger:route POST /admin/exchange-rates Admin importExchangeRatesRequest
+// Imports exchange rates per EUR from an ECB XML file or a CSV file.
+// Responses:
+//
+//	200: importExchangeRatesResponse
+//	400: errorResponse
+//	403: problemResponse
+//	413: errorResponse
+//	500: errorResponse
+func ImportExchangeRates(svc services.CurrencyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := services.RateFormat(r.URL.Query().Get("format"))
+		if format == "" {
+			format = services.RateFormatECB
+			if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+				format = services.RateFormatCSV
+			}
+		}
+
+		ctx := r.Context()
+		body := http.MaxBytesReader(w, r.Body, maxRatesFileSize)
+		imported, err := svc.ImportRates(ctx, body, format)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			switch {
+			case errors.As(err, &tooLarge):
+				http.Error(w, "Exchange rates file too large", http.StatusRequestEntityTooLarge)
+			case errors.Is(err, services.ErrInvalidRates):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			default:
+				serviceError(w, err, "Failed to import exchange rates")
+			}
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]int{"imported": imported})
+	}
+}
+
+// swagger:parameters expenseTotalsRequest
+type expenseTotalsParameters struct {
+	// in:query
+	MinAmount float64 `json:"min_amount"`
+	// in:query
+	MaxAmount float64 `json:"max_amount"`
+	// in:query
+	From int64 `json:"from"`
+	// in:query
+	To int64 `json:"to"`
+	// in:query
+	Description string `json:"description"`
+	// in:query
+	CategoryID string `json:"category_id"`
+	// in:query
+	Currency string `json:"currency"`
+	// in:query
+	Tags string `json:"tags"`
+	// in:query
+	// enum: any,all
+	TagsMatch string `json:"tags_match"`
+}
+
+// swagger:parameters importExchangeRatesRequest
+type importExchangeRatesRequest struct {
+	// File format, guessed from the Content-Type when omitted.
+	// in:query
+	// enum: ecb,csv
+	Format string `json:"format"`
+	// in:body
+	// Required: true
+	Body string
+}
+
+// swagger:response expenseTotalsResponse
+type expenseTotalsResponse struct {
+	// in:body
+	Body struct {
+		// The base currency Total is expressed in.
+		Currency string  `json:"currency"`
+		Total    float64 `json:"total"`
+		// Unconverted sums per expense currency.
+		ByCurrency []struct {
+			Currency string  `json:"currency"`
+			Amount   float64 `json:"amount"`
+		} `json:"by_currency"`
+	}
+}
+
+// swagger:response importExchangeRatesResponse
+type importExchangeRatesResponse struct {
+	// in:body
+	Body struct {
+		Imported int `json:"imported"`
+	}
+}
@@ -0,0 +1,20 @@
+// Package handlers exposes the services over HTTP.
+//
+//	Security:
+//	- bearer:
+//	- apiKey:
+//
+//	SecurityDefinitions:
+//	bearer:
+//	  type: apiKey
+//	  in: header
+//	  name: Authorization
+//	  description: A JWT signed with HS256 or RS256, sent as "Bearer <token>".
+//	apiKey:
+//	  type: apiKey
+//	  in: header
+//	  name: Authorization
+//	  description: An API key sent as "ApiKey <key>", limited to its scopes.
+//
+// swagger:meta
+package handlers
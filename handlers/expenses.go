@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/demo-talent/entities"
 	"github.com/demo-talent/services"
@@ -11,6 +15,7 @@ import (
 // HelloWorld is the HTTP handler for the root path.
 // swagger:route GET / HelloWorld helloWorldRequest
 // Returns a simple hello world message.
+// Security:
 // Responses:
 //
 //	200: okResponse
@@ -21,11 +26,14 @@ func HelloWorld(w http.ResponseWriter, r *http.Request) {
 
 // CreateExpense is the HTTP handler for creating a new expense.
 // swagger:route POST /expenses Expense createExpenseRequest
-// Creates a new expense.
+// Creates a new expense. An Idempotency-Key header makes retries safe.
 // Responses:
 //
 //	201: expenseResponse
 //	400: errorResponse
+//	403: problemResponse
+//	409: errorResponse
+//	422: errorResponse
 //	500: errorResponse
 func CreateExpense(svc services.ExpenseService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -37,10 +45,19 @@ func CreateExpense(svc services.ExpenseService) http.HandlerFunc {
 
 		ctx := r.Context()
 		if err := svc.CreateExpense(ctx, &e); err != nil {
-			http.Error(w, "Failed to create expense", http.StatusInternalServerError)
+			if errors.Is(err, services.ErrUnknownCategory) || errors.Is(err, services.ErrInvalidTag) || errors.Is(err, services.ErrInvalidCurrency) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if errors.Is(err, services.ErrIdempotencyClaimLost) {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			serviceError(w, err, "Failed to create expense")
 			return
 		}
 
+		setETag(w, e.Version)
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(e)
 	}
@@ -48,11 +65,12 @@ func CreateExpense(svc services.ExpenseService) http.HandlerFunc {
 
 // GetExpense is the HTTP handler for retrieving an expense by ID.
 // swagger:route GET /expenses/{id} Expense getExpenseRequest
-// Retrieves an expense by ID.
+// Retrieves an expense by ID, with its version as ETag.
 // Responses:
 //
 //	200: expenseResponse
 //	400: errorResponse
+//	403: problemResponse
 //	404: errorResponse
 //	500: errorResponse
 func GetExpense(svc services.ExpenseService) http.HandlerFunc {
@@ -66,21 +84,68 @@ func GetExpense(svc services.ExpenseService) http.HandlerFunc {
 		ctx := r.Context()
 		expense, err := svc.GetExpenseByID(ctx, id)
 		if err != nil {
-			http.Error(w, "Failed to retrieve expense", http.StatusInternalServerError)
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Expense not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to retrieve expense")
 			return
 		}
 
+		setETag(w, expense.Version)
 		json.NewEncoder(w).Encode(expense)
 	}
 }
 
+// ListExpenses is the HTTP handler for listing expenses.
+// swagger:route GET /expenses Expense listExpensesRequest
+// Lists a page of the expenses matching the given filters, newest first.
+// Responses:
+//
+//	200: expensePageResponse
+//	400: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListExpenses(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, err := parseExpenseFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p, err := parsePageRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		page, err := svc.ListExpenses(ctx, f, p)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidFilter) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to list expenses")
+			return
+		}
+
+		json.NewEncoder(w).Encode(page)
+	}
+}
+
 // UpdateExpense is the HTTP handler for updating an expense.
 // swagger:route PUT /expenses Expense updateExpenseRequest
-// Updates an expense.
+// Updates an expense at the version If-Match names.
 // Responses:
 //
 //	200: okResponse
 //	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	412: errorResponse
+//	428: errorResponse
 //	500: errorResponse
 func UpdateExpense(svc services.ExpenseService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -89,24 +154,46 @@ func UpdateExpense(svc services.ExpenseService) http.HandlerFunc {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
+			return
+		}
+		e.Version = version
 
 		ctx := r.Context()
 		if err := svc.UpdateExpense(ctx, &e); err != nil {
-			http.Error(w, "Failed to update expense", http.StatusInternalServerError)
+			switch {
+			case errors.Is(err, services.ErrUnknownCategory), errors.Is(err, services.ErrInvalidTag), errors.Is(err, services.ErrInvalidCurrency):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Expense not found", http.StatusNotFound)
+			case errors.Is(err, services.ErrExpenseLocked), errors.Is(err, services.ErrSplitMismatch):
+				http.Error(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, services.ErrVersionConflict):
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			default:
+				serviceError(w, err, "Failed to update expense")
+			}
 			return
 		}
 
+		setETag(w, e.Version)
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
 // DeleteExpense is the HTTP handler for deleting an expense by ID.
 // swagger:route DELETE /expenses/{id} Expense deleteExpenseRequest
-// Deletes an expense by ID.
+// Moves an expense at the version If-Match names to the trash by ID.
 // Responses:
 //
 //	200: okResponse
 //	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	412: errorResponse
+//	428: errorResponse
 //	500: errorResponse
 func DeleteExpense(svc services.ExpenseService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -115,10 +202,23 @@ func DeleteExpense(svc services.ExpenseService) http.HandlerFunc {
 			http.Error(w, "Missing expense ID", http.StatusBadRequest)
 			return
 		}
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
+			return
+		}
 
 		ctx := r.Context()
-		if err := svc.DeleteExpense(ctx, id); err != nil {
-			http.Error(w, "Failed to delete expense", http.StatusInternalServerError)
+		if err := svc.DeleteExpense(ctx, id, version); err != nil {
+			switch {
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Expense not found", http.StatusNotFound)
+			case errors.Is(err, services.ErrExpenseLocked):
+				http.Error(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, services.ErrVersionConflict):
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			default:
+				serviceError(w, err, "Failed to delete expense")
+			}
 			return
 		}
 
@@ -126,34 +226,219 @@ func DeleteExpense(svc services.ExpenseService) http.HandlerFunc {
 	}
 }
 
+// setETag sets the ETag of a response to the version of the expense it
+// is about.
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion reads the version of the expense a change is based on
+// from the If-Match header, zero for *. It replies 428 when the header is
+// missing and 412 when it is not an ETag set by setETag, as no expense
+// could match it, and reports whether the change may go on.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" {
+		http.Error(w, "Missing If-Match header", http.StatusPreconditionRequired)
+		return 0, false
+	}
+	if v == "*" {
+		return 0, true
+	}
+	if unquoted, err := strconv.Unquote(v); err == nil && strings.HasPrefix(v, `"`) {
+		if version, err := strconv.Atoi(unquoted); err == nil && version > 0 {
+			return version, true
+		}
+	}
+	http.Error(w, "If-Match does not match the expense", http.StatusPreconditionFailed)
+	return 0, false
+}
+
+// parseExpenseFilter reads the listing filters from the query string.
+func parseExpenseFilter(r *http.Request) (entities.ExpenseFilter, error) {
+	q := r.URL.Query()
+	f := entities.ExpenseFilter{
+		Description: q.Get("description"),
+		CategoryID:  q.Get("category_id"),
+		Currency:    q.Get("currency"),
+		TagMatch:    entities.TagMatch(q.Get("tags_match")),
+		Status:      entities.ExpenseStatus(q.Get("status")),
+	}
+	for _, v := range q["tags"] {
+		f.Tags = append(f.Tags, strings.Split(v, ",")...)
+	}
+
+	var err error
+	if f.MinAmount, err = parseMoneyParam(q.Get("min_amount"), "min_amount"); err != nil {
+		return f, err
+	}
+	if f.MaxAmount, err = parseMoneyParam(q.Get("max_amount"), "max_amount"); err != nil {
+		return f, err
+	}
+	if f.From, err = parseIntParam(q.Get("from"), "from"); err != nil {
+		return f, err
+	}
+	if f.To, err = parseIntParam(q.Get("to"), "to"); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+// parsePageRequest reads the pagination and sorting parameters from the
+// query string.
+func parsePageRequest(r *http.Request) (entities.PageRequest, error) {
+	q := r.URL.Query()
+	p := entities.PageRequest{Cursor: q.Get("cursor")}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return p, fmt.Errorf("invalid limit: %q", v)
+		}
+		p.Limit = limit
+	}
+
+	if v := q.Get("sort"); v != "" {
+		field := strings.TrimPrefix(v, "-")
+		switch entities.SortField(field) {
+		case entities.SortByDate, entities.SortByAmount, entities.SortByDescription:
+		default:
+			return p, fmt.Errorf("invalid sort: %q", v)
+		}
+		p.Sort = entities.Sort{Field: entities.SortField(field), Desc: strings.HasPrefix(v, "-")}
+	}
+
+	return p, nil
+}
+
+func parseMoneyParam(value, name string) (*entities.Money, error) {
+	if value == "" {
+		return nil, nil
+	}
+	v, err := entities.ParseMoney(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return &v, nil
+}
+
+func parseIntParam(value, name string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return &v, nil
+}
+
 // swagger:parameters createExpenseRequest
 type createExpenseRequest struct {
+	// A key unique to this expense, up to 255 printable characters, kept
+	// for 24 hours by default. Reusing it with another body fails with 422,
+	// and while the first request is in progress with 409.
+	// in:header
+	IdempotencyKey string `json:"Idempotency-Key"`
 	// in:body
 	Body struct {
 		// Required: true
 		Description string `json:"description"`
+		// At most two decimals and 99999999.99 in absolute value.
 		// Required: true
 		Amount float64 `json:"amount"`
+		// ISO 4217 code, the base currency when omitted.
+		Currency   string   `json:"currency"`
+		CategoryID string   `json:"category_id"`
+		Tags       []string `json:"tags"`
 	}
 }
 
-// swagger:parameters getExpenseRequest deleteExpenseRequest
+// swagger:parameters getExpenseRequest
 type expenseIDParameter struct {
 	// in:path
 	// Required: true
 	ID string `json:"id"`
 }
 
+// swagger:parameters deleteExpenseRequest
+type deleteExpenseRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// The ETag the expense was read with, or *.
+	// in:header
+	// Required: true
+	IfMatch string `json:"If-Match"`
+}
+
+// swagger:parameters listExpensesRequest
+type listExpensesParameters struct {
+	// Minimum amount, inclusive.
+	// in:query
+	MinAmount float64 `json:"min_amount"`
+	// Maximum amount, inclusive.
+	// in:query
+	MaxAmount float64 `json:"max_amount"`
+	// Lower bound of date_creation as a Unix timestamp, inclusive.
+	// in:query
+	From int64 `json:"from"`
+	// Upper bound of date_creation as a Unix timestamp, inclusive.
+	// in:query
+	To int64 `json:"to"`
+	// Case-insensitive substring of the description.
+	// in:query
+	Description string `json:"description"`
+	// Only expenses in this category.
+	// in:query
+	CategoryID string `json:"category_id"`
+	// Only expenses recorded in this ISO 4217 currency.
+	// in:query
+	Currency string `json:"currency"`
+	// Comma-separated tags to filter by.
+	// in:query
+	Tags string `json:"tags"`
+	// Whether expenses must carry any (default) or all of the tags.
+	// in:query
+	// enum: any,all
+	TagsMatch string `json:"tags_match"`
+	// Only expenses in this approval status.
+	// in:query
+	// enum: draft,submitted,approved,rejected,reimbursed
+	Status string `json:"status"`
+	// Field to sort by: date, amount or description. Prefix with - for
+	// descending order. Defaults to -date.
+	// in:query
+	Sort string `json:"sort"`
+	// Maximum number of expenses to return, 50 by default and at most 200.
+	// in:query
+	Limit int `json:"limit"`
+	// The next_cursor returned with the previous page.
+	// in:query
+	Cursor string `json:"cursor"`
+}
+
 // swagger:parameters updateExpenseRequest
 type updateExpenseRequest struct {
+	// The ETag the expense was read with, or *.
+	// in:header
+	// Required: true
+	IfMatch string `json:"If-Match"`
 	// in:body
 	Body struct {
 		// Required: true
 		ID string `json:"id"`
 		// Required: true
 		Description string `json:"description"`
+		// At most two decimals and 99999999.99 in absolute value.
 		// Required: true
 		Amount float64 `json:"amount"`
+		// ISO 4217 code, unchanged when omitted.
+		Currency   string `json:"currency"`
+		CategoryID string `json:"category_id"`
+		// Replaces the expense tags. Omit it to keep them unchanged.
+		Tags []string `json:"tags"`
 	}
 }
 
@@ -161,10 +446,48 @@ type updateExpenseRequest struct {
 type expenseResponse struct {
 	// in:body
 	Body struct {
-		ID           string  `json:"id"`
-		Description  string  `json:"description"`
-		Amount       float64 `json:"amount"`
-		DateCreation int64   `json:"date_creation"`
+		ID           string   `json:"id"`
+		Description  string   `json:"description"`
+		Amount       float64  `json:"amount"`
+		Currency     string   `json:"currency"`
+		DateCreation int64    `json:"date_creation"`
+		CategoryID   string   `json:"category_id"`
+		Tags         []string `json:"tags"`
+		// One of draft, submitted, approved, rejected or reimbursed.
+		Status string `json:"status"`
+		// Why the expense was rejected.
+		StatusReason string `json:"status_reason"`
+		// Incremented by every change, and returned as ETag.
+		Version int `json:"version"`
+		// Identifies the statement transaction an imported expense was
+		// created from.
+		ExternalRef string `json:"external_ref"`
+		// Budgets this expense pushed over their limit, on creation only.
+		Warnings []struct {
+			BudgetID string `json:"budget_id"`
+			Message  string `json:"message"`
+		} `json:"warnings"`
+	}
+}
+
+// swagger:response expensePageResponse
+type expensePageResponse struct {
+	// in:body
+	Body struct {
+		Items []struct {
+			ID           string   `json:"id"`
+			Description  string   `json:"description"`
+			Amount       float64  `json:"amount"`
+			Currency     string   `json:"currency"`
+			DateCreation int64    `json:"date_creation"`
+			CategoryID   string   `json:"category_id"`
+			Tags         []string `json:"tags"`
+			Status       string   `json:"status"`
+			StatusReason string   `json:"status_reason"`
+			ExternalRef  string   `json:"external_ref"`
+		} `json:"items"`
+		NextCursor string `json:"next_cursor"`
+		HasMore    bool   `json:"has_more"`
 	}
 }
 
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+)
+
+// exportFlushInterval is how many rows are written between flushes of an
+// export to the client.
+const exportFlushInterval = 100
+
+// csvExportHeader names the columns of a CSV export.
+var csvExportHeader = []string{"id", "date", "description", "amount", "currency", "category_id", "tags", "status", "status_reason"}
+
+// ExportExpenses is the HTTP handler for downloading expenses as a file.
+// swagger:route GET /expenses/export Expense exportExpensesRequest
+// Streams every expense matching the given filters, oldest first, as CSV
+// or JSON Lines.
+// Responses:
+//
+//	200: exportResponse
+//	400: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func ExportExpenses(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, err := parseExpenseFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var exporter expenseExporter
+		switch format := r.URL.Query().Get("format"); format {
+		case "", "csv":
+			exporter = &csvExporter{w: csv.NewWriter(w)}
+		case "jsonl":
+			exporter = &jsonlExporter{enc: json.NewEncoder(w)}
+		default:
+			http.Error(w, "invalid format: "+format, http.StatusBadRequest)
+			return
+		}
+
+		// Headers are only sent with the first row, so errors found
+		// before any row is read still get their own status.
+		started, rows := false, 0
+		start := func() error {
+			started = true
+			w.Header().Set("Content-Type", exporter.contentType())
+			w.Header().Set("Content-Disposition", `attachment; filename="expenses.`+exporter.extension()+`"`)
+			return exporter.begin()
+		}
+
+		ctx := r.Context()
+		err = svc.ExportExpenses(ctx, f, func(e *entities.Expense) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			if err := exporter.write(e); err != nil {
+				return err
+			}
+			if rows++; rows%exportFlushInterval == 0 {
+				return exporter.flush(w)
+			}
+			return nil
+		})
+		if err == nil && !started {
+			err = start()
+		}
+		if err == nil {
+			err = exporter.flush(w)
+		}
+		if err != nil {
+			if started {
+				// The status is gone already; cutting the body short is all
+				// that is left to tell the client.
+				log.Printf("Error exporting expenses: %v", err)
+				return
+			}
+			if errors.Is(err, services.ErrInvalidFilter) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to export expenses")
+		}
+	}
+}
+
+// expenseExporter writes expenses in a file format.
+type expenseExporter interface {
+	contentType() string
+	extension() string
+	begin() error
+	write(e *entities.Expense) error
+	flush(w io.Writer) error
+}
+
+type csvExporter struct {
+	w *csv.Writer
+}
+
+func (x *csvExporter) contentType() string { return "text/csv; charset=utf-8" }
+func (x *csvExporter) extension() string   { return "csv" }
+
+func (x *csvExporter) begin() error {
+	return x.w.Write(csvExportHeader)
+}
+
+func (x *csvExporter) write(e *entities.Expense) error {
+	return x.w.Write([]string{
+		e.ID,
+		time.Unix(e.DateCreation, 0).UTC().Format("2006-01-02"),
+		csvText(e.Description),
+		e.Amount.String(),
+		e.Currency,
+		e.CategoryID,
+		csvText(strings.Join(e.Tags, ";")),
+		string(e.Status),
+		csvText(e.StatusReason),
+	})
+}
+
+func (x *csvExporter) flush(w io.Writer) error {
+	x.w.Flush()
+	if err := x.w.Error(); err != nil {
+		return err
+	}
+	flush(w)
+	return nil
+}
+
+// csvText keeps spreadsheets from evaluating text as a formula.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+type jsonlExporter struct {
+	enc *json.Encoder
+}
+
+func (x *jsonlExporter) contentType() string { return "application/x-ndjson" }
+func (x *jsonlExporter) extension() string   { return "jsonl" }
+func (x *jsonlExporter) begin() error        { return nil }
+
+func (x *jsonlExporter) write(e *entities.Expense) error {
+	return x.enc.Encode(e)
+}
+
+func (x *jsonlExporter) flush(w io.Writer) error {
+	flush(w)
+	return nil
+}
+
+// flush sends what was written to w so far to the client.
+func flush(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// swagger:parameters exportExpensesRequest
+type exportExpensesRequest struct {
+	// File format, csv by default.
+	// in:query
+	// enum: csv,jsonl
+	Format string `json:"format"`
+	// Minimum amount, inclusive.
+	// in:query
+	MinAmount float64 `json:"min_amount"`
+	// Maximum amount, inclusive.
+	// in:query
+	MaxAmount float64 `json:"max_amount"`
+	// Lower bound of date_creation as a Unix timestamp, inclusive.
+	// in:query
+	From int64 `json:"from"`
+	// Upper bound of date_creation as a Unix timestamp, inclusive.
+	// in:query
+	To int64 `json:"to"`
+	// Case-insensitive substring of the description.
+	// in:query
+	Description string `json:"description"`
+	// Only expenses in this category.
+	// in:query
+	CategoryID string `json:"category_id"`
+	// Only expenses recorded in this ISO 4217 currency.
+	// in:query
+	Currency string `json:"currency"`
+	// Comma-separated tags to filter by.
+	// in:query
+	Tags string `json:"tags"`
+	// Whether expenses must carry any (default) or all of the tags.
+	// in:query
+	// enum: any,all
+	TagsMatch string `json:"tags_match"`
+	// Only expenses in this approval status.
+	// in:query
+	// enum: draft,submitted,approved,rejected,reimbursed
+	Status string `json:"status"`
+}
+
+// A CSV file with an id,date,description,amount,currency,category_id,tags,status,status_reason
+// header, or one JSON expense per line.
+// swagger:response exportResponse
+type exportResponse struct {
+	// in:body
+	Body string
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// IdempotencyKeyHeader carries a key the client picks for a request, so
+// retrying it with the same key cannot process it twice.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// ReplayedHeader is set on the responses replayed for a retry.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotentBody bounds the body read to identify a request.
+const maxIdempotentBody = 1 << 20
+
+// replayedHeaders are the response headers stored to be replayed.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Idempotent is a middleware storing the successful response of a request
+// carrying an Idempotency-Key header and replaying it to identical retries.
+func Idempotent(svc services.IdempotencyService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			token, stored, err := svc.Begin(ctx, key, requestHash(r, body))
+			switch {
+			case errors.Is(err, services.ErrInvalidIdempotencyKey):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			case errors.Is(err, services.ErrIdempotencyKeyReused):
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			case errors.Is(err, services.ErrIdempotencyInProgress):
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			case err != nil:
+				serviceError(w, err, "Failed to check idempotency key")
+				return
+			case stored != nil:
+				for name, values := range stored.Header {
+					w.Header()[name] = values
+				}
+				w.Header().Set(ReplayedHeader, "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.Body)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r.WithContext(services.WithIdempotencyClaim(ctx, key, token)))
+
+			// The client may be gone by now, which must not keep the
+			// response from being stored for its retry.
+			detached := context.Background()
+			if principal, ok := auth.PrincipalFromContext(ctx); ok {
+				detached = auth.WithPrincipal(detached, principal)
+			}
+			if rec.status < 200 || rec.status > 299 {
+				if err := svc.Release(detached, key, token); err != nil {
+					log.Printf("Error releasing idempotency key: %v", err)
+				}
+				return
+			}
+			resp := &entities.IdempotentResponse{StatusCode: rec.status, Header: map[string][]string{}, Body: rec.body.Bytes()}
+			for _, name := range replayedHeaders {
+				if values := rec.Header().Values(name); len(values) > 0 {
+					resp.Header[name] = values
+				}
+			}
+			if err := svc.Complete(detached, key, token, resp); err != nil {
+				log.Printf("Error storing idempotent response: %v", err)
+			}
+		})
+	}
+}
+
+// requestHash identifies a request by its method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder passes a response through while keeping its status and
+// body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
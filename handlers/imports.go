@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+)
+
+// maxImportFileSize bounds statement uploads.
+const maxImportFileSize = 10 << 20
+
+// ImportCSV is the HTTP handler for importing expenses from a CSV
+// statement.
+// swagger:route POST /imports/csv Import importCSVRequest
+// Creates an expense for each row of a CSV bank or card statement, read
+// with the columns and formats the query names.
+// Responses:
+//
+//	200: importSummaryResponse
+//	400: errorResponse
+//	403: problemResponse
+//	413: errorResponse
+//	500: errorResponse
+func ImportCSV(svc services.ImportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseCSVImportOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		body := http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		summary, err := svc.ImportCSV(ctx, body, opts)
+		if err != nil {
+			writeImportError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(summary)
+	}
+}
+
+// ImportOFX is the HTTP handler for importing expenses from an OFX
+// statement.
+// swagger:route POST /imports/ofx Import importOFXRequest
+// Creates an expense for each debit of an OFX or QFX statement, skipping
+// the transactions imported before.
+// Responses:
+//
+//	200: importSummaryResponse
+//	400: errorResponse
+//	403: problemResponse
+//	413: errorResponse
+//	500: errorResponse
+func ImportOFX(svc services.ImportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun, err := parseBoolParam(r.URL.Query().Get("dry_run"), "dry_run")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		body := http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		summary, err := svc.ImportOFX(ctx, body, dryRun)
+		if err != nil {
+			writeImportError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(summary)
+	}
+}
+
+// ImportQIF is the HTTP handler for importing expenses from a QIF
+// statement.
+// swagger:route POST /imports/qif Import importQIFRequest
+// Creates an expense for each debit of a QIF statement, read with the
+// formats the query names, skipping the transactions imported before.
+// Responses:
+//
+//	200: importSummaryResponse
+//	400: errorResponse
+//	403: problemResponse
+//	413: errorResponse
+//	500: errorResponse
+func ImportQIF(svc services.ImportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		opts := entities.QIFImportOptions{
+			DateFormat:       q.Get("date_format"),
+			DecimalSeparator: q.Get("decimal_separator"),
+			Currency:         q.Get("currency"),
+		}
+		var err error
+		if opts.DryRun, err = parseBoolParam(q.Get("dry_run"), "dry_run"); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		body := http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		summary, err := svc.ImportQIF(ctx, body, opts)
+		if err != nil {
+			writeImportError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(summary)
+	}
+}
+
+// writeImportError maps an error reading an imported file to a status.
+func writeImportError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		http.Error(w, "Statement too large", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, services.ErrInvalidImport):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		serviceError(w, err, "Failed to import expenses")
+	}
+}
+
+func parseCSVImportOptions(r *http.Request) (entities.CSVImportOptions, error) {
+	q := r.URL.Query()
+	opts := entities.CSVImportOptions{
+		Columns: entities.CSVColumns{
+			Date:        q.Get("date_column"),
+			Description: q.Get("description_column"),
+			Amount:      q.Get("amount_column"),
+			Currency:    q.Get("currency_column"),
+			Category:    q.Get("category_column"),
+		},
+		DateFormat:       q.Get("date_format"),
+		DecimalSeparator: q.Get("decimal_separator"),
+		AmountSign:       entities.AmountSign(q.Get("amount_sign")),
+	}
+
+	switch v := q.Get("delimiter"); {
+	case v == "":
+	case v == "tab":
+		opts.Delimiter = '\t'
+	case utf8.RuneCountInString(v) == 1:
+		opts.Delimiter, _ = utf8.DecodeRuneInString(v)
+	default:
+		return opts, fmt.Errorf("invalid delimiter: %q", v)
+	}
+
+	var err error
+	if opts.DryRun, err = parseBoolParam(q.Get("dry_run"), "dry_run"); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+func parseBoolParam(value, name string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return v, nil
+}
+
+// swagger:parameters importOFXRequest
+type importOFXRequest struct {
+	// Report the transactions without creating any expense.
+	// in:query
+	DryRun bool `json:"dry_run"`
+	// in:body
+	// Required: true
+	Body string
+}
+
+// swagger:parameters importQIFRequest
+type importQIFRequest struct {
+	// Layout of the dates, made of YYYY or YY, MM and DD; MM/DD/YYYY by
+	// default. Days and months may lack their leading zero and years may
+	// have two digits.
+	// in:query
+	DateFormat string `json:"date_format"`
+	// . (the default) or ,; the other one is taken as a thousands
+	// separator.
+	// in:query
+	DecimalSeparator string `json:"decimal_separator"`
+	// ISO 4217 currency of the account, the base currency when omitted.
+	// in:query
+	Currency string `json:"currency"`
+	// Report the transactions without creating any expense.
+	// in:query
+	DryRun bool `json:"dry_run"`
+	// in:body
+	// Required: true
+	Body string
+}
+
+// swagger:parameters importCSVRequest
+type importCSVRequest struct {
+	// Header of the column holding the date.
+	// in:query
+	// Required: true
+	DateColumn string `json:"date_column"`
+	// Header of the column holding the description.
+	// in:query
+	// Required: true
+	DescriptionColumn string `json:"description_column"`
+	// Header of the column holding the amount.
+	// in:query
+	// Required: true
+	AmountColumn string `json:"amount_column"`
+	// Header of the column holding the ISO 4217 currency, the base
+	// currency when omitted.
+	// in:query
+	CurrencyColumn string `json:"currency_column"`
+	// Header of the column holding the name or ID of the category.
+	// in:query
+	CategoryColumn string `json:"category_column"`
+	// Layout of the dates, made of YYYY or YY, MM and DD; YYYY-MM-DD by
+	// default.
+	// in:query
+	DateFormat string `json:"date_format"`
+	// . (the default) or ,; the other one is taken as a thousands
+	// separator.
+	// in:query
+	DecimalSeparator string `json:"decimal_separator"`
+	// Sign of the amounts of expenses, positive (the default) or negative;
+	// amounts of the other sign are money coming in.
+	// in:query
+	AmountSign string `json:"amount_sign"`
+	// A single character, or tab; a comma by default.
+	// in:query
+	Delimiter string `json:"delimiter"`
+	// Report the rows without creating any expense.
+	// in:query
+	DryRun bool `json:"dry_run"`
+	// in:body
+	// Required: true
+	Body string
+}
+
+// swagger:response importSummaryResponse
+type importSummaryResponse struct {
+	// in:body
+	Body struct {
+		DryRun bool `json:"dry_run"`
+		// Rows created, or that would be created on a dry run.
+		Created int `json:"created"`
+		Skipped int `json:"skipped"`
+		Failed  int `json:"failed"`
+		Rows    []struct {
+			// The line of the file the row starts on.
+			Line int `json:"line"`
+			// One of created, valid (on a dry run), skipped or failed.
+			Status  string            `json:"status"`
+			Expense *entities.Expense `json:"expense"`
+			// Why the row failed.
+			Error string `json:"error"`
+			// Why the row was skipped, such as already imported.
+			Reason string `json:"reason"`
+		} `json:"rows"`
+	}
+}
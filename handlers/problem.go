@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/auth"
+)
+
+// problem is an RFC 7807 problem details body.
+type problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// writeProblem replies with an application/problem+json body for status.
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+// serviceError replies to a service error no other case of a handler
+// expects: 403 when the role of the principal forbids the operation, 401
+// without a principal, 400 without a workspace and 500 with msg otherwise.
+func serviceError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, auth.ErrNoWorkspace):
+		writeProblem(w, http.StatusBadRequest, "Select a workspace with the "+WorkspaceHeader+" header")
+	case errors.Is(err, auth.ErrForbidden):
+		writeProblem(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, auth.ErrUnauthenticated):
+		unauthorized(w, "", "Authentication required")
+	default:
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+}
+
+// A problem details body (RFC 7807).
+// swagger:response problemResponse
+type problemResponse struct {
+	// in:body
+	Body problem
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// CreateRecurringExpense is the HTTP handler for creating a recurring
+// expense template.
+// swagger:route POST /recurring-expenses RecurringExpense createRecurringExpenseRequest
+// Creates a recurring expense template. The scheduler creates an expense
+// from it every interval periods of frequency from start_at.
+// Responses:
+//
+//	201: recurringExpenseResponse
+//	400: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func CreateRecurringExpense(svc services.RecurringExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var re entities.RecurringExpense
+		if err := json.NewDecoder(r.Body).Decode(&re); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.CreateRecurringExpense(ctx, &re); err != nil {
+			if isRecurringValidationError(err) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to create recurring expense")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(re)
+	}
+}
+
+// ListRecurringExpenses is the HTTP handler for listing recurring expense
+// templates.
+// swagger:route GET /recurring-expenses RecurringExpense listRecurringExpensesRequest
+// Lists the recurring expense templates.
+// Responses:
+//
+//	200: recurringExpensesResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListRecurringExpenses(svc services.RecurringExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		recurring, err := svc.ListRecurringExpenses(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list recurring expenses")
+			return
+		}
+
+		json.NewEncoder(w).Encode(recurring)
+	}
+}
+
+// GetRecurringExpense is the HTTP handler for retrieving a recurring
+// expense template by ID.
+// swagger:route GET /recurring-expenses/{id} RecurringExpense getRecurringExpenseRequest
+// Retrieves a recurring expense template by ID.
+// Responses:
+//
+//	200: recurringExpenseResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func GetRecurringExpense(svc services.RecurringExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		re, err := svc.GetRecurringExpenseByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Recurring expense not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to retrieve recurring expense")
+			return
+		}
+
+		json.NewEncoder(w).Encode(re)
+	}
+}
+
+// UpdateRecurringExpense is the HTTP handler for updating a recurring
+// expense template.
+// swagger:route PUT /recurring-expenses/{id} RecurringExpense updateRecurringExpenseRequest
+// Updates a recurring expense template. Occurrences already due are not
+// created again.
+// Responses:
+//
+//	200: okResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func UpdateRecurringExpense(svc services.RecurringExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var re entities.RecurringExpense
+		if err := json.NewDecoder(r.Body).Decode(&re); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		re.ID = mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.UpdateRecurringExpense(ctx, &re); err != nil {
+			switch {
+			case isRecurringValidationError(err):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Recurring expense not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to update recurring expense")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// DeleteRecurringExpense is the HTTP handler for deleting a recurring
+// expense template by ID.
+// swagger:route DELETE /recurring-expenses/{id} RecurringExpense deleteRecurringExpenseRequest
+// Deletes a recurring expense template by ID. Expenses it already created
+// are kept.
+// Responses:
+//
+//	200: okResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func DeleteRecurringExpense(svc services.RecurringExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		if err := svc.DeleteRecurringExpense(ctx, id); err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Recurring expense not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to delete recurring expense")
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func isRecurringValidationError(err error) bool {
+	return errors.Is(err, services.ErrInvalidRecurringExpense) ||
+		errors.Is(err, services.ErrUnknownCategory) ||
+		errors.Is(err, services.ErrInvalidTag) ||
+		errors.Is(err, services.ErrInvalidCurrency)
+}
+
+// swagger:parameters createRecurringExpenseRequest
+type createRecurringExpenseRequest struct {
+	// in:body
+	Body recurringExpenseBody
+}
+
+// swagger:parameters updateRecurringExpenseRequest
+type updateRecurringExpenseRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body recurringExpenseBody
+}
+
+type recurringExpenseBody struct {
+	// Required: true
+	Description string `json:"description"`
+	// Required: true
+	Amount     float64  `json:"amount"`
+	Currency   string   `json:"currency"`
+	CategoryID string   `json:"category_id"`
+	Tags       []string `json:"tags"`
+	// Required: true
+	// enum: daily,weekly,monthly,yearly
+	Frequency string `json:"frequency"`
+	// Number of periods between occurrences, 1 by default.
+	Interval int `json:"interval"`
+	// Unix timestamp of the first occurrence, now by default.
+	StartAt int64 `json:"start_at"`
+	// Unix timestamp after which no occurrence is created.
+	EndAt int64 `json:"end_at"`
+}
+
+// swagger:parameters getRecurringExpenseRequest deleteRecurringExpenseRequest
+type recurringExpenseIDParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:response recurringExpenseResponse
+type recurringExpenseResponse struct {
+	// in:body
+	Body recurringExpense
+}
+
+// swagger:response recurringExpensesResponse
+type recurringExpensesResponse struct {
+	// in:body
+	Body []recurringExpense
+}
+
+type recurringExpense struct {
+	ID           string   `json:"id"`
+	Description  string   `json:"description"`
+	Amount       float64  `json:"amount"`
+	Currency     string   `json:"currency"`
+	CategoryID   string   `json:"category_id"`
+	Tags         []string `json:"tags"`
+	Frequency    string   `json:"frequency"`
+	Interval     int      `json:"interval"`
+	StartAt      int64    `json:"start_at"`
+	EndAt        int64    `json:"end_at"`
+	NextRun      int64    `json:"next_run"`
+	DateCreation int64    `json:"date_creation"`
+}
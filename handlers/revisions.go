@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// ListExpenseRevisions is the HTTP handler for listing the prior versions
+// of an expense.
+// swagger:route GET /expenses/{id}/revisions Revision listExpenseRevisionsRequest
+// Lists every version an expense of the authenticated user had before it
+// was changed, oldest first. Status changes make revisions too.
+// Responses:
+//
+//	200: revisionsResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func ListExpenseRevisions(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		revisions, err := svc.ListExpenseRevisions(ctx, mux.Vars(r)["id"])
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Expense not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to list expense revisions")
+			return
+		}
+
+		json.NewEncoder(w).Encode(revisions)
+	}
+}
+
+// GetExpenseRevision is the HTTP handler for reading a prior version of an
+// expense.
+// swagger:route GET /expenses/{id}/revisions/{n} Revision getExpenseRevisionRequest
+// Gets a version an expense of the authenticated user had before it was
+// changed, by its number.
+// Responses:
+//
+//	200: revisionResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func GetExpenseRevision(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(mux.Vars(r)["n"])
+		if err != nil {
+			http.Error(w, "Invalid revision number", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		rev, err := svc.GetExpenseRevision(ctx, mux.Vars(r)["id"], n)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Revision not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to get expense revision")
+			return
+		}
+
+		json.NewEncoder(w).Encode(rev)
+	}
+}
+
+// RevertExpense is the HTTP handler for reverting an expense to a prior
+// version.
+// swagger:route POST /expenses/{id}/revisions/{n}/revert Revision revertExpenseRequest
+// Updates an expense at the version If-Match names back to one of its
+// revisions.
+// Responses:
+//
+//	200: expenseResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	412: errorResponse
+//	428: errorResponse
+//	500: errorResponse
+func RevertExpense(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(mux.Vars(r)["n"])
+		if err != nil {
+			http.Error(w, "Invalid revision number", http.StatusBadRequest)
+			return
+		}
+		version, ok := ifMatchVersion(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := r.Context()
+		e, err := svc.RevertExpense(ctx, mux.Vars(r)["id"], n, version)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrUnknownCategory), errors.Is(err, services.ErrInvalidTag), errors.Is(err, services.ErrInvalidCurrency):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Revision not found", http.StatusNotFound)
+			case errors.Is(err, services.ErrExpenseLocked), errors.Is(err, services.ErrSplitMismatch):
+				http.Error(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, services.ErrVersionConflict):
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			default:
+				serviceError(w, err, "Failed to revert expense")
+			}
+			return
+		}
+
+		setETag(w, e.Version)
+		json.NewEncoder(w).Encode(e)
+	}
+}
+
+// swagger:parameters listExpenseRevisionsRequest
+type listExpenseRevisionsRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters getExpenseRevisionRequest revertExpenseRequest
+type expenseRevisionRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// The number of the revision, from 1 for the version the expense was
+	// created with.
+	// in:path
+	// Required: true
+	N int `json:"n"`
+}
+
+// swagger:parameters revertExpenseRequest
+type revertExpenseRequest struct {
+	// The ETag the expense was read with, or *.
+	// in:header
+	// Required: true
+	IfMatch string `json:"If-Match"`
+}
+
+// swagger:response revisionResponse
+type revisionResponse struct {
+	// in:body
+	Body revision
+}
+
+// swagger:response revisionsResponse
+type revisionsResponse struct {
+	// in:body
+	Body []revision
+}
+
+type revision struct {
+	ExpenseID    string   `json:"expense_id"`
+	Revision     int      `json:"revision"`
+	Description  string   `json:"description"`
+	Amount       float64  `json:"amount"`
+	Currency     string   `json:"currency"`
+	CategoryID   string   `json:"category_id"`
+	Tags         []string `json:"tags"`
+	Status       string   `json:"status"`
+	StatusReason string   `json:"status_reason"`
+	// The user whose change ended this version.
+	ReplacedBy string `json:"replaced_by"`
+	// When this version was replaced, in Unix seconds.
+	ReplacedAt int64 `json:"replaced_at"`
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/demo-talent/services/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestRevertExpense(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		ifMatch   string
+		setupMock func(mockSvc *mocks.MockExpenseService)
+		want      int
+		wantETag  string
+	}{
+		{
+			name:    "Reverted",
+			ifMatch: `"3"`,
+			setupMock: func(mockSvc *mocks.MockExpenseService) {
+				mockSvc.EXPECT().RevertExpense(gomock.Any(), "expense_1", 1, 3).Return(&entities.Expense{ID: "expense_1", Version: 4}, nil)
+			},
+			want:     http.StatusOK,
+			wantETag: `"4"`,
+		},
+		{
+			name:    "AnyVersion",
+			ifMatch: "*",
+			setupMock: func(mockSvc *mocks.MockExpenseService) {
+				mockSvc.EXPECT().RevertExpense(gomock.Any(), "expense_1", 1, 0).Return(&entities.Expense{ID: "expense_1", Version: 4}, nil)
+			},
+			want:     http.StatusOK,
+			wantETag: `"4"`,
+		},
+		{
+			name:      "MissingIfMatch",
+			setupMock: func(mockSvc *mocks.MockExpenseService) {},
+			want:      http.StatusPreconditionRequired,
+		},
+		{
+			name:    "VersionConflict",
+			ifMatch: `"2"`,
+			setupMock: func(mockSvc *mocks.MockExpenseService) {
+				mockSvc.EXPECT().RevertExpense(gomock.Any(), "expense_1", 1, 2).Return(nil, fmt.Errorf("%w: expense expense_1 is at version 3, not 2", services.ErrVersionConflict))
+			},
+			want: http.StatusPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewMockExpenseService(ctrl)
+			tt.setupMock(mockSvc)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/expenses/{id}/revisions/{n}/revert", RevertExpense(mockSvc)).Methods("POST")
+			req := httptest.NewRequest(http.MethodPost, "/expenses/expense_1/revisions/1/revert", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("RevertExpense() status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("RevertExpense() ETag = %q, want %q", got, tt.wantETag)
+			}
+		})
+	}
+}
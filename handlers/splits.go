@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// SetExpenseSplits is the HTTP handler for splitting an expense.
+// swagger:route PUT /expenses/{id}/splits Split setExpenseSplitsRequest
+// Splits an expense among members of its workspace, replacing its previous
+// splits.
+// Responses:
+//
+//	200: expenseSplitsResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	409: errorResponse
+//	500: errorResponse
+func SetExpenseSplits(svc services.SplitService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req entities.SplitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		splits, err := svc.SetSplits(ctx, mux.Vars(r)["id"], req)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidSplit):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Expense not found", http.StatusNotFound)
+			case errors.Is(err, services.ErrSplitMismatch):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				serviceError(w, err, "Failed to split expense")
+			}
+			return
+		}
+
+		json.NewEncoder(w).Encode(splits)
+	}
+}
+
+// ListExpenseSplits is the HTTP handler for listing the splits of an
+// expense.
+// swagger:route GET /expenses/{id}/splits Split listExpenseSplitsRequest
+// Lists the parts each participant owes for an expense.
+// Responses:
+//
+//	200: expenseSplitsResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func ListExpenseSplits(svc services.SplitService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		splits, err := svc.ListSplits(ctx, mux.Vars(r)["id"])
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Expense not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to list expense splits")
+			return
+		}
+
+		json.NewEncoder(w).Encode(splits)
+	}
+}
+
+// ListBalances is the HTTP handler for listing balances.
+// swagger:route GET /balances Split listBalancesRequest
+// Lists what each member of the workspace is owed, per currency.
+// Responses:
+//
+//	200: balancesResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListBalances(svc services.SplitService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		balances, err := svc.Balances(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to compute balances")
+			return
+		}
+
+		json.NewEncoder(w).Encode(balances)
+	}
+}
+
+// SettleUp is the HTTP handler for planning a settle-up.
+// swagger:route GET /balances/settle-up Split settleUpRequest
+// Computes the fewest transfers, per currency, that zero out the balances
+// of the workspace.
+// Responses:
+//
+//	200: transfersResponse
+//	403: problemResponse
+//	500: errorResponse
+func SettleUp(svc services.SplitService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		transfers, err := svc.SettleUp(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to settle up")
+			return
+		}
+
+		json.NewEncoder(w).Encode(transfers)
+	}
+}
+
+// CreateSettlement is the HTTP handler for recording a settlement.
+// swagger:route POST /settlements Split createSettlementRequest
+// Records a payment from the authenticated user to another member of the
+// workspace, which moves their balances.
+// Responses:
+//
+//	201: settlementResponse
+//	400: errorResponse
+//	403: problemResponse
+//	500: errorResponse
+func CreateSettlement(svc services.SplitService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var st entities.Settlement
+		if err := json.NewDecoder(r.Body).Decode(&st); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.RecordSettlement(ctx, &st); err != nil {
+			if errors.Is(err, services.ErrInvalidSplit) || errors.Is(err, services.ErrInvalidCurrency) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to record settlement")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(st)
+	}
+}
+
+// ListSettlements is the HTTP handler for listing settlements.
+// swagger:route GET /settlements Split listSettlementsRequest
+// Lists the settlements of the workspace, oldest first.
+// Responses:
+//
+//	200: settlementsResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListSettlements(svc services.SplitService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		settlements, err := svc.ListSettlements(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list settlements")
+			return
+		}
+
+		json.NewEncoder(w).Encode(settlements)
+	}
+}
+
+// swagger:parameters setExpenseSplitsRequest
+type setExpenseSplitsRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body struct {
+		// One of equal, exact, percentage or shares.
+		// Required: true
+		Method       string             `json:"method"`
+		Participants []splitParticipant `json:"participants"`
+	}
+}
+
+type splitParticipant struct {
+	// Required: true
+	UserID string `json:"user_id"`
+	// The part of the participant, for exact splits. The parts must add
+	// up to the amount of the expense.
+	Amount float64 `json:"amount,omitempty"`
+	// The percentage of the participant, for percentage splits. The
+	// percentages must add up to 100.
+	Percentage float64 `json:"percentage,omitempty"`
+	// The shares of the participant, for shares splits.
+	Shares int64 `json:"shares,omitempty"`
+}
+
+// swagger:parameters listExpenseSplitsRequest
+type expenseSplitsIDParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters createSettlementRequest
+type createSettlementRequest struct {
+	// in:body
+	Body struct {
+		// Required: true
+		ToUserID string `json:"to_user_id"`
+		// Required: true
+		Amount float64 `json:"amount"`
+		// Required: true
+		Currency string `json:"currency"`
+	}
+}
+
+// swagger:response expenseSplitsResponse
+type expenseSplitsResponse struct {
+	// in:body
+	Body []struct {
+		ExpenseID string  `json:"expense_id"`
+		UserID    string  `json:"user_id"`
+		Amount    float64 `json:"amount"`
+	}
+}
+
+// swagger:response balancesResponse
+type balancesResponse struct {
+	// in:body
+	Body []struct {
+		UserID   string  `json:"user_id"`
+		Currency string  `json:"currency"`
+		Amount   float64 `json:"amount"`
+	}
+}
+
+// swagger:response transfersResponse
+type transfersResponse struct {
+	// in:body
+	Body []struct {
+		FromUserID string  `json:"from_user_id"`
+		ToUserID   string  `json:"to_user_id"`
+		Amount     float64 `json:"amount"`
+		Currency   string  `json:"currency"`
+	}
+}
+
+// swagger:response settlementResponse
+type settlementResponse struct {
+	// in:body
+	Body settlement
+}
+
+// swagger:response settlementsResponse
+type settlementsResponse struct {
+	// in:body
+	Body []settlement
+}
+
+type settlement struct {
+	ID           string  `json:"id"`
+	FromUserID   string  `json:"from_user_id"`
+	ToUserID     string  `json:"to_user_id"`
+	Amount       float64 `json:"amount"`
+	Currency     string  `json:"currency"`
+	DateCreation int64   `json:"date_creation"`
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// RestoreExpense is the HTTP handler for taking an expense out of the
+// trash.
+// swagger:route POST /expenses/{id}/restore Trash restoreExpenseRequest
+// Restores a deleted expense of the authenticated user, as it was when it
+// was deleted.
+// Responses:
+//
+//	200: expenseResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func RestoreExpense(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		e, err := svc.RestoreExpense(ctx, mux.Vars(r)["id"])
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Expense not found in the trash", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to restore expense")
+			return
+		}
+
+		json.NewEncoder(w).Encode(e)
+	}
+}
+
+// ListTrash is the HTTP handler for listing the deleted expenses.
+// swagger:route GET /trash Trash listTrashRequest
+// Lists the deleted expenses of the authenticated user that were not purged
+// yet, most recently deleted first.
+// Responses:
+//
+//	200: trashResponse
+//	403: problemResponse
+//	500: errorResponse
+func ListTrash(svc services.ExpenseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		expenses, err := svc.ListTrash(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list deleted expenses")
+			return
+		}
+
+		json.NewEncoder(w).Encode(expenses)
+	}
+}
+
+// swagger:parameters restoreExpenseRequest
+type restoreExpenseRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:response trashResponse
+type trashResponse struct {
+	// in:body
+	Body []struct {
+		ID           string   `json:"id"`
+		Description  string   `json:"description"`
+		Amount       float64  `json:"amount"`
+		Currency     string   `json:"currency"`
+		DateCreation int64    `json:"date_creation"`
+		CategoryID   string   `json:"category_id"`
+		Tags         []string `json:"tags"`
+		OwnerID      string   `json:"owner_id"`
+		Status       string   `json:"status"`
+		StatusReason string   `json:"status_reason"`
+		// When the expense was deleted, in Unix seconds.
+		DeletedAt int64 `json:"deleted_at"`
+	}
+}
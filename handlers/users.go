@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// GetCurrentUser is the HTTP handler for retrieving the authenticated user.
+// swagger:route GET /users/me User getCurrentUserRequest
+// Retrieves the authenticated user.
+// Responses:
+//
+//	200: userResponse
+//	401: problemResponse
+//	500: errorResponse
+func GetCurrentUser(svc services.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		u, err := svc.CurrentUser(ctx)
+		if err != nil {
+			if errors.Is(err, services.ErrUnauthenticated) || errors.Is(err, services.ErrNotFound) {
+				unauthorized(w, "", "Authentication required")
+				return
+			}
+			serviceError(w, err, "Failed to retrieve user")
+			return
+		}
+
+		json.NewEncoder(w).Encode(u)
+	}
+}
+
+// SetUserRole is the HTTP handler for changing the instance role of a
+// user.
+// swagger:route PUT /users/{id}/role User setUserRoleRequest
+// Changes the instance role of a user. Needs the admin instance role.
+// Responses:
+//
+//	200: okResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func SetUserRole(svc services.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Role string `json:"role"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.SetUserRole(ctx, mux.Vars(r)["id"], auth.Role(body.Role)); err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidUser):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "User not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to set user role")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:response userResponse
+type userResponse struct {
+	// in:body
+	Body struct {
+		ID           string `json:"id"`
+		Email        string `json:"email"`
+		Name         string `json:"name"`
+		Role         string `json:"role"`
+		DateCreation int64  `json:"date_creation"`
+	}
+}
+
+// swagger:parameters setUserRoleRequest
+type setUserRoleRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body struct {
+		// One of viewer, member, approver or admin.
+		// Required: true
+		Role string `json:"role"`
+	}
+}
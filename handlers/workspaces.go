@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/gorilla/mux"
+)
+
+// CreateWorkspace is the HTTP handler for creating a workspace.
+// swagger:route POST /workspaces Workspace createWorkspaceRequest
+// Creates a workspace owned by the authenticated user, who becomes its
+// first member and an admin of it.
+// Responses:
+//
+//	201: workspaceResponse
+//	400: errorResponse
+//	500: errorResponse
+func CreateWorkspace(svc services.WorkspaceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var ws entities.Workspace
+		if err := json.NewDecoder(r.Body).Decode(&ws); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if err := svc.CreateWorkspace(ctx, &ws); err != nil {
+			if errors.Is(err, services.ErrInvalidWorkspace) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			serviceError(w, err, "Failed to create workspace")
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(ws)
+	}
+}
+
+// ListWorkspaces is the HTTP handler for listing workspaces.
+// swagger:route GET /workspaces Workspace listWorkspacesRequest
+// Lists the workspaces of the authenticated user, in the order they were
+// joined. Requests without an X-Workspace-ID header act on the first one.
+// Responses:
+//
+//	200: workspacesResponse
+//	500: errorResponse
+func ListWorkspaces(svc services.WorkspaceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		workspaces, err := svc.ListWorkspaces(ctx)
+		if err != nil {
+			serviceError(w, err, "Failed to list workspaces")
+			return
+		}
+
+		json.NewEncoder(w).Encode(workspaces)
+	}
+}
+
+// ListWorkspaceMembers is the HTTP handler for listing the members of a
+// workspace.
+// swagger:route GET /workspaces/{id}/members Workspace listWorkspaceMembersRequest
+// Lists the members of a workspace of the authenticated user.
+// Responses:
+//
+//	200: workspaceMembersResponse
+//	404: errorResponse
+//	500: errorResponse
+func ListWorkspaceMembers(svc services.WorkspaceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		ctx := r.Context()
+		members, err := svc.ListMembers(ctx, id)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				http.Error(w, "Workspace not found", http.StatusNotFound)
+				return
+			}
+			serviceError(w, err, "Failed to list workspace members")
+			return
+		}
+
+		json.NewEncoder(w).Encode(members)
+	}
+}
+
+// AddWorkspaceMember is the HTTP handler for adding a member to a
+// workspace.
+// swagger:route POST /workspaces/{id}/members Workspace addWorkspaceMemberRequest
+// Adds a registered user to a workspace as a member. Needs the admin role
+// in the workspace.
+// Responses:
+//
+//	201: workspaceMemberResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func AddWorkspaceMember(svc services.WorkspaceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Email string `json:"email"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		m, err := svc.AddMember(ctx, mux.Vars(r)["id"], body.Email)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrUnknownUser):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Workspace not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to add workspace member")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(m)
+	}
+}
+
+// SetWorkspaceMemberRole is the HTTP handler for changing the role of a
+// member of a workspace.
+// swagger:route PUT /workspaces/{id}/members/{user_id}/role Workspace setWorkspaceMemberRoleRequest
+// Changes the role of a member in a workspace. Needs the admin role in the
+// workspace; the owner stays an admin.
+// Responses:
+//
+//	200: okResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func SetWorkspaceMemberRole(svc services.WorkspaceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Role string `json:"role"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		vars := mux.Vars(r)
+
+		ctx := r.Context()
+		if err := svc.SetMemberRole(ctx, vars["id"], vars["user_id"], auth.Role(body.Role)); err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidWorkspace):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Workspace member not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to set workspace member role")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// RemoveWorkspaceMember is the HTTP handler for removing a member from a
+// workspace.
+// swagger:route DELETE /workspaces/{id}/members/{user_id} Workspace removeWorkspaceMemberRequest
+// Removes a member from a workspace. Members may leave on their own.
+// Responses:
+//
+//	200: okResponse
+//	400: errorResponse
+//	403: problemResponse
+//	404: errorResponse
+//	500: errorResponse
+func RemoveWorkspaceMember(svc services.WorkspaceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		ctx := r.Context()
+		if err := svc.RemoveMember(ctx, vars["id"], vars["user_id"]); err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidWorkspace):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, services.ErrNotFound):
+				http.Error(w, "Workspace member not found", http.StatusNotFound)
+			default:
+				serviceError(w, err, "Failed to remove workspace member")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:parameters createWorkspaceRequest
+type createWorkspaceRequest struct {
+	// in:body
+	Body struct {
+		// Required: true
+		Name string `json:"name"`
+	}
+}
+
+// swagger:parameters listWorkspaceMembersRequest
+type workspaceIDParameter struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+}
+
+// swagger:parameters addWorkspaceMemberRequest
+type addWorkspaceMemberRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:body
+	Body struct {
+		// The email the user registered with.
+		// Required: true
+		Email string `json:"email"`
+	}
+}
+
+// swagger:parameters removeWorkspaceMemberRequest
+type removeWorkspaceMemberRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:path
+	// Required: true
+	UserID string `json:"user_id"`
+}
+
+// swagger:parameters setWorkspaceMemberRoleRequest
+type setWorkspaceMemberRoleRequest struct {
+	// in:path
+	// Required: true
+	ID string `json:"id"`
+	// in:path
+	// Required: true
+	UserID string `json:"user_id"`
+	// in:body
+	Body struct {
+		// One of viewer, member, approver or admin.
+		// Required: true
+		Role string `json:"role"`
+	}
+}
+
+// swagger:response workspaceResponse
+type workspaceResponse struct {
+	// in:body
+	Body workspace
+}
+
+// swagger:response workspacesResponse
+type workspacesResponse struct {
+	// in:body
+	Body []workspace
+}
+
+type workspace struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	OwnerID      string `json:"owner_id"`
+	DateCreation int64  `json:"date_creation"`
+	// The role of the authenticated user in the workspace.
+	Role string `json:"role"`
+}
+
+// swagger:response workspaceMemberResponse
+type workspaceMemberResponse struct {
+	// in:body
+	Body workspaceMember
+}
+
+// swagger:response workspaceMembersResponse
+type workspaceMembersResponse struct {
+	// in:body
+	Body []workspaceMember
+}
+
+type workspaceMember struct {
+	WorkspaceID string `json:"workspace_id"`
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	DateJoined  int64  `json:"date_joined"`
+}
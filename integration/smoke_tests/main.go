@@ -1,11 +1,15 @@
 package main
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 	"os"
+	"time"
 )
 
 func main() {
@@ -30,8 +34,11 @@ func main() {
 	// Test HelloWorld handler
 	testHelloWorld(client, instanceURL)
 
+	// Users are registered by their first request
+	userID := fmt.Sprintf("smoke-%d", time.Now().UnixNano())
+
 	// Test CreateExpense handler
-	testCreateExpense(client, instanceURL, expenseData)
+	testCreateExpense(client, instanceURL, userID, expenseData)
 }
 
 func readJSONFile(filename string) (map[string]interface{}, error) {
@@ -68,7 +75,18 @@ func testHelloWorld(client *http.Client, instanceURL string) {
 	fmt.Println("✅ HelloWorld test passed")
 }
 
-func testCreateExpense(client *http.Client, instanceURL string, data map[string]interface{}) {
+// signToken signs an HS256 JWT for userID, with a verified email, with the
+// JWT_HS256_SECRET the instance trusts.
+func signToken(userID string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header := encode([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := encode([]byte(fmt.Sprintf(`{"sub":%q,"email":%q,"email_verified":true,"exp":%d}`, userID, userID+"@example.com", time.Now().Add(5*time.Minute).Unix())))
+	mac := hmac.New(sha256.New, []byte(os.Getenv("JWT_HS256_SECRET")))
+	mac.Write([]byte(header + "." + claims))
+	return header + "." + claims + "." + encode(mac.Sum(nil))
+}
+
+func testCreateExpense(client *http.Client, instanceURL string, userID string, data map[string]interface{}) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		fmt.Println("❌ Error marshalling JSON:", err)
@@ -78,6 +96,7 @@ func testCreateExpense(client *http.Client, instanceURL string, data map[string]
 	url := fmt.Sprintf("%s/expenses", instanceURL)
 	req, _ := http.NewRequest("POST", url, strings.NewReader(string(jsonData)))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+signToken(userID))
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
+	"crypto/rsa"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
 	"github.com/demo-talent/handlers"
 	"github.com/demo-talent/repository"
 	"github.com/demo-talent/services"
+	"github.com/demo-talent/storage"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -25,6 +32,14 @@ func main() {
 	dbPassword := os.Getenv("DB_PASSWORD")
 	dbName := os.Getenv("DB_NAME")
 	sslmode := os.Getenv("SSL_MODE")
+	baseCurrency := os.Getenv("BASE_CURRENCY")
+	if baseCurrency == "" {
+		baseCurrency = "USD"
+	}
+	baseCurrency, err := entities.NormalizeCurrency(baseCurrency)
+	if err != nil {
+		log.Fatal("Invalid BASE_CURRENCY:", err)
+	}
 
 	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		dbHost, dbPort, dbUser, dbPassword, dbName, sslmode)
@@ -46,16 +61,100 @@ func main() {
         log.Fatal("Error running migrations:", err)
 	}
 
+	userRepo := repository.NewUserRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
 	repo := repository.NewExpenseRepository(db)
-	svc := services.NewExpenseService(repo)
+	categoryRepo := repository.NewCategoryRepository(db)
+	rateRepo := repository.NewExchangeRateRepository(db)
+	budgetRepo := repository.NewBudgetRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	recurringRepo := repository.NewRecurringExpenseRepository(db)
+	splitRepo := repository.NewSplitRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	units := repository.NewUnitOfWorkFactory(db)
+	userSvc := services.NewUserService(userRepo, units)
+
+	// "./main grant-admin <email>" makes a registered user an admin and exits
+	if len(os.Args) > 1 && os.Args[1] == "grant-admin" {
+		if err := grantAdmin(context.Background(), userRepo, userSvc, os.Args[2:]); err != nil {
+			log.Fatal("Error granting the admin role:", err)
+		}
+		return
+	}
+	workspaceSvc := services.NewWorkspaceService(workspaceRepo, userRepo)
+	apiKeySvc := services.NewAPIKeyService(apiKeyRepo)
+	categorySvc := services.NewCategoryService(categoryRepo)
+	currencySvc := services.NewCurrencyService(rateRepo, repo, baseCurrency)
+	budgetSvc := services.NewBudgetService(budgetRepo, categoryRepo, currencySvc)
+	auditSvc := services.NewAuditService(auditRepo)
+
+	// Store attachment bytes on the local filesystem
+	attachmentDir := os.Getenv("ATTACHMENT_DIR")
+	if attachmentDir == "" {
+		attachmentDir = "./data/attachments"
+	}
+	blobs, err := storage.NewLocalStore(attachmentDir)
+	if err != nil {
+		log.Fatal("Error opening the attachment store:", err)
+	}
+
+	svc := services.NewExpenseService(repo, units, categoryRepo, budgetSvc, auditSvc, blobs, baseCurrency)
+	recurringSvc := services.NewRecurringExpenseService(recurringRepo, categoryRepo, workspaceRepo, svc, baseCurrency)
+	splitSvc := services.NewSplitService(splitRepo, repo, workspaceRepo)
+	importSvc := services.NewImportService(svc, repo, categoryRepo, baseCurrency)
+	attachmentSvc := services.NewAttachmentService(attachmentRepo, repo, blobs)
+
+	// Materialize recurring expenses in the background
+	recurringInterval := time.Minute
+	if v := os.Getenv("RECURRING_INTERVAL"); v != "" {
+		if recurringInterval, err = time.ParseDuration(v); err != nil || recurringInterval <= 0 {
+			log.Fatal("Invalid RECURRING_INTERVAL:", v)
+		}
+	}
+	go services.RunRecurringScheduler(context.Background(), recurringSvc, recurringInterval)
+
+	// Purge the expenses that stayed in the trash longer than the retention
+	trashRetention := 30 * 24 * time.Hour
+	if v := os.Getenv("TRASH_RETENTION"); v != "" {
+		if trashRetention, err = time.ParseDuration(v); err != nil || trashRetention <= 0 {
+			log.Fatal("Invalid TRASH_RETENTION:", v)
+		}
+	}
+	go services.RunTrashPurger(context.Background(), svc, trashRetention, time.Hour)
+
+	// Remember the Idempotency-Keys of expense creations for a while
+	idempotencyTTL := 24 * time.Hour
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		if idempotencyTTL, err = time.ParseDuration(v); err != nil || idempotencyTTL <= 0 {
+			log.Fatal("Invalid IDEMPOTENCY_TTL:", v)
+		}
+	}
+	idempotencySvc := services.NewIdempotencyService(idempotencyRepo, idempotencyTTL)
+	go services.RunIdempotencyKeyPurger(context.Background(), idempotencySvc, time.Hour)
+
+	// Handlers act through services checking the role of the user
+	authzUserSvc := services.NewAuthorizedUserService(userSvc)
+	authzSvc := services.NewAuthorizedExpenseService(svc)
+	authzCategorySvc := services.NewAuthorizedCategoryService(categorySvc)
+	authzCurrencySvc := services.NewAuthorizedCurrencyService(currencySvc)
+	authzBudgetSvc := services.NewAuthorizedBudgetService(budgetSvc)
+	authzRecurringSvc := services.NewAuthorizedRecurringExpenseService(recurringSvc)
+	authzAttachmentSvc := services.NewAuthorizedAttachmentService(attachmentSvc)
+	authzSplitSvc := services.NewAuthorizedSplitService(splitSvc)
+	authzAuditSvc := services.NewAuthorizedAuditService(auditSvc)
+	authzImportSvc := services.NewAuthorizedImportService(importSvc)
+
+	tokens, err := newTokenVerifier()
+	if err != nil {
+		log.Fatal("Invalid JWT configuration:", err)
+	}
 
 	r := mux.NewRouter()
+	r.Use(handlers.TrackRequest)
 
-	// Register the expense handlers
-	r.HandleFunc("/expenses", handlers.CreateExpense(svc)).Methods("POST")
-	r.HandleFunc("/expenses", handlers.GetExpense(svc)).Methods("GET")
-	r.HandleFunc("/expenses", handlers.UpdateExpense(svc)).Methods("PUT")
-	r.HandleFunc("/expenses", handlers.DeleteExpense(svc)).Methods("DELETE")
+	// Register the public handlers
 	r.HandleFunc("/", handlers.HelloWorld).Methods("GET")
 
 	opts := middleware.RedocOpts{SpecURL: "/swagger.json"}
@@ -65,12 +164,158 @@ func main() {
 		http.ServeFile(w, r, "./docs/swagger.json")
 	})
 
+	// Every other route acts on behalf of the user authenticated by a JWT
+	// or an API key, within the scopes of the key, on the data of the
+	// workspace it selects
+	api := r.NewRoute().Subrouter()
+	api.Use(handlers.Authenticate(tokens, apiKeySvc, userSvc), handlers.RequireScope(), handlers.SelectWorkspace(workspaceSvc))
+	api.HandleFunc("/users/me", handlers.GetCurrentUser(userSvc)).Methods("GET")
+	api.HandleFunc("/users/{id}/role", handlers.SetUserRole(authzUserSvc)).Methods("PUT")
+
+	// Register the workspace handlers
+	api.HandleFunc("/workspaces", handlers.CreateWorkspace(workspaceSvc)).Methods("POST")
+	api.HandleFunc("/workspaces", handlers.ListWorkspaces(workspaceSvc)).Methods("GET")
+	api.HandleFunc("/workspaces/{id}/members", handlers.AddWorkspaceMember(workspaceSvc)).Methods("POST")
+	api.HandleFunc("/workspaces/{id}/members", handlers.ListWorkspaceMembers(workspaceSvc)).Methods("GET")
+	api.HandleFunc("/workspaces/{id}/members/{user_id}", handlers.RemoveWorkspaceMember(workspaceSvc)).Methods("DELETE")
+	api.HandleFunc("/workspaces/{id}/members/{user_id}/role", handlers.SetWorkspaceMemberRole(workspaceSvc)).Methods("PUT")
+
+	// Register the API key handlers
+	api.HandleFunc("/api-keys", handlers.CreateAPIKey(apiKeySvc)).Methods("POST")
+	api.HandleFunc("/api-keys", handlers.ListAPIKeys(apiKeySvc)).Methods("GET")
+	api.HandleFunc("/api-keys/{id}", handlers.RevokeAPIKey(apiKeySvc)).Methods("DELETE")
+	api.HandleFunc("/api-keys/{id}/rotate", handlers.RotateAPIKey(apiKeySvc)).Methods("POST")
+
+	// Register the expense handlers
+	api.Handle("/expenses", handlers.Idempotent(idempotencySvc)(handlers.CreateExpense(authzSvc))).Methods("POST")
+	api.HandleFunc("/expenses", handlers.GetExpense(authzSvc)).Methods("GET").Queries("id", "{id}")
+	api.HandleFunc("/expenses", handlers.ListExpenses(authzSvc)).Methods("GET")
+	api.HandleFunc("/expenses/totals", handlers.GetExpenseTotals(authzCurrencySvc)).Methods("GET")
+	api.HandleFunc("/expenses/export", handlers.ExportExpenses(authzSvc)).Methods("GET")
+	api.HandleFunc("/expenses", handlers.UpdateExpense(authzSvc)).Methods("PUT")
+	api.HandleFunc("/expenses", handlers.DeleteExpense(authzSvc)).Methods("DELETE")
+	api.HandleFunc("/expenses:batch", handlers.RunExpenseBatch(authzSvc)).Methods("POST")
+
+	// Register the approval handlers
+	api.HandleFunc("/expenses/{id}/submit", handlers.SubmitExpense(authzSvc)).Methods("POST")
+	api.HandleFunc("/expenses/{id}/approve", handlers.ApproveExpense(authzSvc)).Methods("POST")
+	api.HandleFunc("/expenses/{id}/reject", handlers.RejectExpense(authzSvc)).Methods("POST")
+	api.HandleFunc("/expenses/{id}/reimburse", handlers.ReimburseExpense(authzSvc)).Methods("POST")
+	api.HandleFunc("/approvals", handlers.ListPendingApprovals(authzSvc)).Methods("GET")
+
+	// Register the trash handlers
+	api.HandleFunc("/expenses/{id}/restore", handlers.RestoreExpense(authzSvc)).Methods("POST")
+	api.HandleFunc("/trash", handlers.ListTrash(authzSvc)).Methods("GET")
+
+	// Register the revision handlers
+	api.HandleFunc("/expenses/{id}/revisions", handlers.ListExpenseRevisions(authzSvc)).Methods("GET")
+	api.HandleFunc("/expenses/{id}/revisions/{n}", handlers.GetExpenseRevision(authzSvc)).Methods("GET")
+	api.HandleFunc("/expenses/{id}/revisions/{n}/revert", handlers.RevertExpense(authzSvc)).Methods("POST")
+
+	// Register the import handlers
+	api.HandleFunc("/imports/csv", handlers.ImportCSV(authzImportSvc)).Methods("POST")
+	api.HandleFunc("/imports/ofx", handlers.ImportOFX(authzImportSvc)).Methods("POST")
+	api.HandleFunc("/imports/qif", handlers.ImportQIF(authzImportSvc)).Methods("POST")
+
+	// Register the audit handlers
+	api.HandleFunc("/audit", handlers.ListAuditEvents(authzAuditSvc)).Methods("GET")
+
+	// Register the attachment handlers
+	api.HandleFunc("/expenses/{id}/attachments", handlers.UploadAttachment(authzAttachmentSvc)).Methods("POST")
+	api.HandleFunc("/expenses/{id}/attachments", handlers.ListAttachments(authzAttachmentSvc)).Methods("GET")
+	api.HandleFunc("/expenses/{id}/attachments/{attachment_id}", handlers.DownloadAttachment(authzAttachmentSvc)).Methods("GET")
+	api.HandleFunc("/expenses/{id}/attachments/{attachment_id}", handlers.DeleteAttachment(authzAttachmentSvc)).Methods("DELETE")
+
+	// Register the split handlers
+	api.HandleFunc("/expenses/{id}/splits", handlers.SetExpenseSplits(authzSplitSvc)).Methods("PUT")
+	api.HandleFunc("/expenses/{id}/splits", handlers.ListExpenseSplits(authzSplitSvc)).Methods("GET")
+	api.HandleFunc("/balances", handlers.ListBalances(authzSplitSvc)).Methods("GET")
+	api.HandleFunc("/balances/settle-up", handlers.SettleUp(authzSplitSvc)).Methods("GET")
+	api.HandleFunc("/settlements", handlers.CreateSettlement(authzSplitSvc)).Methods("POST")
+	api.HandleFunc("/settlements", handlers.ListSettlements(authzSplitSvc)).Methods("GET")
+
+	// Register the category handlers
+	api.HandleFunc("/categories", handlers.CreateCategory(authzCategorySvc)).Methods("POST")
+	api.HandleFunc("/categories", handlers.ListCategories(authzCategorySvc)).Methods("GET")
+	api.HandleFunc("/categories/{id}", handlers.GetCategory(authzCategorySvc)).Methods("GET")
+	api.HandleFunc("/categories/{id}", handlers.UpdateCategory(authzCategorySvc)).Methods("PUT")
+	api.HandleFunc("/categories/{id}", handlers.DeleteCategory(authzCategorySvc)).Methods("DELETE")
+
+	// Register the budget handlers
+	api.HandleFunc("/budgets", handlers.CreateBudget(authzBudgetSvc)).Methods("POST")
+	api.HandleFunc("/budgets", handlers.ListBudgets(authzBudgetSvc)).Methods("GET")
+	api.HandleFunc("/budgets/{id}", handlers.GetBudget(authzBudgetSvc)).Methods("GET")
+	api.HandleFunc("/budgets/{id}", handlers.UpdateBudget(authzBudgetSvc)).Methods("PUT")
+	api.HandleFunc("/budgets/{id}", handlers.DeleteBudget(authzBudgetSvc)).Methods("DELETE")
+	api.HandleFunc("/budgets/{id}/status", handlers.GetBudgetStatus(authzBudgetSvc)).Methods("GET")
+
+	// Register the recurring expense handlers
+	api.HandleFunc("/recurring-expenses", handlers.CreateRecurringExpense(authzRecurringSvc)).Methods("POST")
+	api.HandleFunc("/recurring-expenses", handlers.ListRecurringExpenses(authzRecurringSvc)).Methods("GET")
+	api.HandleFunc("/recurring-expenses/{id}", handlers.GetRecurringExpense(authzRecurringSvc)).Methods("GET")
+	api.HandleFunc("/recurring-expenses/{id}", handlers.UpdateRecurringExpense(authzRecurringSvc)).Methods("PUT")
+	api.HandleFunc("/recurring-expenses/{id}", handlers.DeleteRecurringExpense(authzRecurringSvc)).Methods("DELETE")
+
+	// Register the admin handlers
+	api.HandleFunc("/admin/exchange-rates", handlers.ImportExchangeRates(authzCurrencySvc)).Methods("POST")
+
 	log.Println("Server started on port 8080")
 	if err := http.ListenAndServe(":8080", r); err != nil {
 		log.Fatal("Error starting the server:", err)
 	}
 }
 
+// grantAdmin makes the registered user with the email in args an admin.
+// Nobody becomes an admin by registering, so this is how an operator with
+// access to the database bootstraps the first admin, who then assigns the
+// other roles through the API.
+func grantAdmin(ctx context.Context, users repository.UserRepositoryInterface, userSvc services.UserService, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: grant-admin <email>")
+	}
+	u, err := users.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(args[0])))
+	if err != nil {
+		return err
+	}
+	if err := userSvc.SetUserRole(ctx, u.ID, auth.RoleAdmin); err != nil {
+		return err
+	}
+	log.Printf("User %s (%s) is now an admin", u.ID, u.Email)
+	return nil
+}
+
+// newTokenVerifier trusts the HS256 secret in JWT_HS256_SECRET, the RS256
+// key in the PEM file at JWT_RS256_PUBLIC_KEY and the RS256 keys in the
+// JWKS file at JWT_JWKS_FILE, whichever are set.
+func newTokenVerifier() (*auth.TokenVerifier, error) {
+	config := auth.JWTConfig{
+		HMACSecret: []byte(os.Getenv("JWT_HS256_SECRET")),
+		RSAKeys:    map[string]*rsa.PublicKey{},
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+	}
+	if len(config.HMACSecret) > 0 && len(config.HMACSecret) < 32 {
+		return nil, fmt.Errorf("JWT_HS256_SECRET must be at least 32 bytes")
+	}
+	if path := os.Getenv("JWT_RS256_PUBLIC_KEY"); path != "" {
+		key, err := auth.LoadRSAPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		config.RSAKeys[""] = key
+	}
+	if path := os.Getenv("JWT_JWKS_FILE"); path != "" {
+		keys, err := auth.LoadJWKS(path)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			config.RSAKeys[kid] = key
+		}
+	}
+	return auth.NewTokenVerifier(config)
+}
+
 func runMigrations(db *sql.DB) error {
     driver, err := postgres.WithInstance(db, &postgres.Config{})
     if err != nil {
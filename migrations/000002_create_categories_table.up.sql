@@ -0,0 +1,11 @@
+CREATE TABLE categories (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    date_creation BIGINT NOT NULL
+);
+
+ALTER TABLE expenses
+    ADD COLUMN category_id VARCHAR(255) REFERENCES categories (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_expenses_category_id ON expenses (category_id);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE
+);
+
+CREATE TABLE expense_tags (
+    expense_id VARCHAR(255) NOT NULL REFERENCES expenses (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (expense_id, tag_id)
+);
+
+CREATE INDEX idx_expense_tags_tag_id ON expense_tags (tag_id);
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Expenses recorded before currencies existed are assumed to be in USD.
+ALTER TABLE expenses ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE expenses ALTER COLUMN currency DROP DEFAULT;
+
+-- Units of currency one EUR bought on rate_date, as published by the ECB.
+CREATE TABLE exchange_rates (
+    currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (currency, rate_date)
+);
\ No newline at end of file
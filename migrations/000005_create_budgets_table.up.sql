@@ -0,0 +1,10 @@
+CREATE TABLE budgets (
+    id VARCHAR(255) PRIMARY KEY,
+    category_id VARCHAR(255) NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    period VARCHAR(16) NOT NULL CHECK (period IN ('month', 'quarter', 'year')),
+    limit_amount DECIMAL(10, 2) NOT NULL CHECK (limit_amount > 0),
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    date_creation BIGINT NOT NULL
+);
+
+CREATE INDEX idx_budgets_category_id ON budgets (category_id);
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE recurring_expenses (
+    id VARCHAR(255) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    category_id VARCHAR(255) REFERENCES categories (id) ON DELETE SET NULL,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    frequency VARCHAR(16) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    interval_count INTEGER NOT NULL CHECK (interval_count > 0),
+    start_at BIGINT NOT NULL,
+    end_at BIGINT,
+    next_run BIGINT,
+    date_creation BIGINT NOT NULL
+);
+
+CREATE INDEX idx_recurring_expenses_next_run ON recurring_expenses (next_run) WHERE next_run IS NOT NULL;
+
+-- One row per occurrence the scheduler claimed. The primary key makes a
+-- claim succeed at most once, so restarts and concurrent schedulers never
+-- create the same occurrence twice.
+CREATE TABLE recurring_occurrences (
+    recurring_id VARCHAR(255) NOT NULL REFERENCES recurring_expenses (id) ON DELETE CASCADE,
+    scheduled_at BIGINT NOT NULL,
+    expense_id VARCHAR(255),
+    PRIMARY KEY (recurring_id, scheduled_at)
+);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE attachments (
+    id VARCHAR(255) PRIMARY KEY,
+    expense_id VARCHAR(255) NOT NULL REFERENCES expenses (id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    sha256 CHAR(64) NOT NULL,
+    date_creation BIGINT NOT NULL,
+    UNIQUE (expense_id, sha256)
+);
+
+CREATE INDEX idx_attachments_sha256 ON attachments (sha256);
\ No newline at end of file
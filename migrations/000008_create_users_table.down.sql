@@ -0,0 +1,3 @@
+ALTER TABLE recurring_expenses DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS owner_id;
+DROP TABLE IF EXISTS users;
\ No newline at end of file
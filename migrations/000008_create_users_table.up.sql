@@ -0,0 +1,20 @@
+CREATE TABLE users (
+    id VARCHAR(255) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    date_creation BIGINT NOT NULL
+);
+
+-- Rows created before users existed are owned by a placeholder user.
+INSERT INTO users (id, email, name, date_creation)
+VALUES ('user_legacy', 'legacy@localhost', 'Legacy data', EXTRACT(EPOCH FROM NOW())::BIGINT);
+
+ALTER TABLE expenses ADD COLUMN owner_id VARCHAR(255) REFERENCES users (id) ON DELETE CASCADE;
+UPDATE expenses SET owner_id = 'user_legacy';
+ALTER TABLE expenses ALTER COLUMN owner_id SET NOT NULL;
+CREATE INDEX idx_expenses_owner_id ON expenses (owner_id, date_creation);
+
+ALTER TABLE recurring_expenses ADD COLUMN owner_id VARCHAR(255) REFERENCES users (id) ON DELETE CASCADE;
+UPDATE recurring_expenses SET owner_id = 'user_legacy';
+ALTER TABLE recurring_expenses ALTER COLUMN owner_id SET NOT NULL;
+CREATE INDEX idx_recurring_expenses_owner_id ON recurring_expenses (owner_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'member'
+    CHECK (role IN ('viewer', 'member', 'approver', 'admin'));
\ No newline at end of file
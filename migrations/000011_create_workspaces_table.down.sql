@@ -0,0 +1,24 @@
+ALTER TABLE attachments DROP COLUMN IF EXISTS workspace_id;
+
+ALTER TABLE recurring_expenses DROP COLUMN IF EXISTS workspace_id;
+CREATE INDEX IF NOT EXISTS idx_recurring_expenses_owner_id ON recurring_expenses (owner_id);
+
+ALTER TABLE budgets DROP COLUMN IF EXISTS workspace_id;
+
+UPDATE expense_tags et SET tag_id = f.id
+FROM tags t, (SELECT name, MIN(id) AS id FROM tags GROUP BY name) f
+WHERE t.id = et.tag_id AND f.name = t.name;
+DELETE FROM tags t WHERE t.id > (SELECT MIN(id) FROM tags WHERE name = t.name);
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS tags_workspace_id_name_key;
+ALTER TABLE tags DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE tags ADD CONSTRAINT tags_name_key UNIQUE (name);
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_workspace_id_name_key;
+ALTER TABLE categories DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+ALTER TABLE expenses DROP COLUMN IF EXISTS workspace_id;
+CREATE INDEX IF NOT EXISTS idx_expenses_owner_id ON expenses (owner_id, date_creation);
+
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
\ No newline at end of file
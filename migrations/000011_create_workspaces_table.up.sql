@@ -0,0 +1,57 @@
+CREATE TABLE workspaces (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    date_creation BIGINT NOT NULL
+);
+
+CREATE TABLE workspace_members (
+    workspace_id VARCHAR(255) NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL DEFAULT 'member' CHECK (role IN ('viewer', 'member', 'approver', 'admin')),
+    date_joined BIGINT NOT NULL,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members (user_id, date_joined);
+
+-- Rows created before workspaces existed move to a workspace every
+-- existing user belongs to, with the role they had, and its owner manages
+-- as an admin.
+INSERT INTO workspaces (id, name, owner_id, date_creation)
+VALUES ('workspace_legacy', 'Legacy workspace', 'user_legacy', EXTRACT(EPOCH FROM NOW())::BIGINT);
+INSERT INTO workspace_members (workspace_id, user_id, role, date_joined)
+SELECT 'workspace_legacy', id, CASE WHEN id = 'user_legacy' THEN 'admin' ELSE role END, EXTRACT(EPOCH FROM NOW())::BIGINT FROM users;
+
+ALTER TABLE expenses ADD COLUMN workspace_id VARCHAR(255) REFERENCES workspaces (id) ON DELETE CASCADE;
+UPDATE expenses SET workspace_id = 'workspace_legacy';
+ALTER TABLE expenses ALTER COLUMN workspace_id SET NOT NULL;
+DROP INDEX idx_expenses_owner_id;
+CREATE INDEX idx_expenses_workspace_id ON expenses (workspace_id, owner_id, date_creation);
+
+ALTER TABLE categories ADD COLUMN workspace_id VARCHAR(255) REFERENCES workspaces (id) ON DELETE CASCADE;
+UPDATE categories SET workspace_id = 'workspace_legacy';
+ALTER TABLE categories ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE categories DROP CONSTRAINT categories_name_key;
+ALTER TABLE categories ADD CONSTRAINT categories_workspace_id_name_key UNIQUE (workspace_id, name);
+
+ALTER TABLE tags ADD COLUMN workspace_id VARCHAR(255) REFERENCES workspaces (id) ON DELETE CASCADE;
+UPDATE tags SET workspace_id = 'workspace_legacy';
+ALTER TABLE tags ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE tags DROP CONSTRAINT tags_name_key;
+ALTER TABLE tags ADD CONSTRAINT tags_workspace_id_name_key UNIQUE (workspace_id, name);
+
+ALTER TABLE budgets ADD COLUMN workspace_id VARCHAR(255) REFERENCES workspaces (id) ON DELETE CASCADE;
+UPDATE budgets SET workspace_id = 'workspace_legacy';
+ALTER TABLE budgets ALTER COLUMN workspace_id SET NOT NULL;
+CREATE INDEX idx_budgets_workspace_id ON budgets (workspace_id);
+
+ALTER TABLE recurring_expenses ADD COLUMN workspace_id VARCHAR(255) REFERENCES workspaces (id) ON DELETE CASCADE;
+UPDATE recurring_expenses SET workspace_id = 'workspace_legacy';
+ALTER TABLE recurring_expenses ALTER COLUMN workspace_id SET NOT NULL;
+DROP INDEX idx_recurring_expenses_owner_id;
+CREATE INDEX idx_recurring_expenses_workspace_id ON recurring_expenses (workspace_id, owner_id);
+
+ALTER TABLE attachments ADD COLUMN workspace_id VARCHAR(255) REFERENCES workspaces (id) ON DELETE CASCADE;
+UPDATE attachments SET workspace_id = 'workspace_legacy';
+ALTER TABLE attachments ALTER COLUMN workspace_id SET NOT NULL;
\ No newline at end of file
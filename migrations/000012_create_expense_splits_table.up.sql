@@ -0,0 +1,21 @@
+CREATE TABLE expense_splits (
+    expense_id VARCHAR(255) NOT NULL REFERENCES expenses (id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+    PRIMARY KEY (expense_id, user_id)
+);
+
+CREATE INDEX idx_expense_splits_user_id ON expense_splits (user_id);
+
+CREATE TABLE settlements (
+    id VARCHAR(255) PRIMARY KEY,
+    workspace_id VARCHAR(255) NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    from_user_id VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    to_user_id VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    date_creation BIGINT NOT NULL,
+    CHECK (from_user_id <> to_user_id)
+);
+
+CREATE INDEX idx_settlements_workspace_id ON settlements (workspace_id, date_creation);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_expenses_workspace_status;
+ALTER TABLE expenses DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE expenses DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE expenses ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'submitted', 'approved', 'rejected', 'reimbursed'));
+ALTER TABLE expenses ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_expenses_workspace_status ON expenses (workspace_id, status);
\ No newline at end of file
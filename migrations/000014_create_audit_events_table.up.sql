@@ -0,0 +1,30 @@
+CREATE TABLE audit_events (
+    id VARCHAR(255) PRIMARY KEY,
+    workspace_id VARCHAR(255) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL,
+    api_key_id VARCHAR(255) NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    changes JSONB NOT NULL DEFAULT '{}',
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    date_creation BIGINT NOT NULL
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events (workspace_id, entity_id, date_creation);
+
+-- Events outlive what they describe, so nothing references other tables,
+-- and they can only be appended.
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_change BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+CREATE TRIGGER audit_events_no_truncate BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
\ No newline at end of file
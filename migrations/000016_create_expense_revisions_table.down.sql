@@ -0,0 +1 @@
+DROP TABLE IF EXISTS expense_revisions;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- One row per version an expense had before it was changed, numbered from
+-- 1 for the version it was created with. Rows are never updated; they go
+-- away with their expense when it is purged.
+CREATE TABLE expense_revisions (
+    expense_id VARCHAR(255) NOT NULL REFERENCES expenses (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    description VARCHAR(255) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    category_id VARCHAR(255),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(16) NOT NULL,
+    status_reason TEXT NOT NULL DEFAULT '',
+    replaced_by VARCHAR(255) NOT NULL,
+    replaced_at BIGINT NOT NULL,
+    PRIMARY KEY (expense_id, revision)
+);
\ No newline at end of file
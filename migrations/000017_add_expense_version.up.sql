@@ -0,0 +1 @@
+ALTER TABLE expenses ADD COLUMN version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
\ No newline at end of file
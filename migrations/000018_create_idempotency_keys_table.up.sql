@@ -0,0 +1,21 @@
+-- One row per Idempotency-Key a user sent within a workspace. The status
+-- code and response stay NULL while the first request holding the key is
+-- in progress. That request is told apart by a token changing on every
+-- claim, and the key is marked committed in the transaction creating what
+-- the request creates, after which it can no longer be taken over.
+CREATE TABLE idempotency_keys (
+    user_id VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    workspace_id VARCHAR(255) NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INTEGER,
+    response_header JSONB,
+    response_body BYTEA,
+    claim_token CHAR(32),
+    committed BOOLEAN NOT NULL DEFAULT FALSE,
+    claimed_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, workspace_id, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_expenses_external_ref;
+ALTER TABLE expenses DROP COLUMN IF EXISTS external_ref;
\ No newline at end of file
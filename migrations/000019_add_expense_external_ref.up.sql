@@ -0,0 +1,7 @@
+-- The reference a bank gave a transaction, such as the FITID of an OFX
+-- statement, for expenses imported from one. Trashed expenses keep theirs
+-- so a transaction is never imported twice into a workspace, whoever
+-- imports it.
+ALTER TABLE expenses ADD COLUMN external_ref TEXT;
+
+CREATE UNIQUE INDEX idx_expenses_external_ref ON expenses (workspace_id, external_ref) WHERE external_ref IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/lib/pq"
+)
+
+// APIKeyRepositoryInterface persists API keys. Create, GetByID, List and
+// Revoke are scoped to the user authenticated in ctx; GetByHash and
+// TouchLastUsed serve the authentication of a request, before there is one.
+type APIKeyRepositoryInterface interface {
+	Create(ctx context.Context, k *entities.APIKey, keyHash string) error
+	GetByID(ctx context.Context, id string) (*entities.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	List(ctx context.Context) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, id string, at int64) error
+	TouchLastUsed(ctx context.Context, id string, at int64) error
+}
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository.
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepositoryInterface {
+	return &APIKeyRepository{db: db}
+}
+
+// apiKeyColumns are the columns scanned by scanAPIKey, in order.
+const apiKeyColumns = `
+        id, name, prefix, scopes, owner_id, date_creation, expires_at, revoked_at, last_used_at
+`
+
+// Create saves a new API key, owned by the user in ctx, with the hash of
+// its key.
+func (r *APIKeyRepository) Create(ctx context.Context, k *entities.APIKey, keyHash string) error {
+	owner, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	k.OwnerID = owner
+
+	query := `
+        INSERT INTO api_keys (id, owner_id, name, prefix, key_hash, scopes, date_creation, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err = r.db.ExecContext(ctx, query, k.ID, k.OwnerID, k.Name, k.Prefix, keyHash, pq.Array(k.Scopes), k.DateCreation, k.ExpiresAt)
+	if err != nil {
+		log.Printf("Error creating API key: %v", err)
+		return fmt.Errorf("error creating API key: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an API key of the user in ctx by its ID.
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*entities.APIKey, error) {
+	owner, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE id = $1 AND owner_id = $2
+    `
+	k, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id, owner))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: API key with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving API key: %v", err)
+		return nil, fmt.Errorf("error retrieving API key: %w", err)
+	}
+	return k, nil
+}
+
+// GetByHash retrieves the API key, of any user, with the given key hash.
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE key_hash = $1
+    `
+	k, err := scanAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: API key", ErrNotFound)
+		}
+		log.Printf("Error retrieving API key: %v", err)
+		return nil, fmt.Errorf("error retrieving API key: %w", err)
+	}
+	return k, nil
+}
+
+// List retrieves the API keys of the user in ctx, revoked ones included.
+func (r *APIKeyRepository) List(ctx context.Context) ([]*entities.APIKey, error) {
+	owner, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE owner_id = $1
+        ORDER BY date_creation, id
+    `
+	rows, err := r.db.QueryContext(ctx, query, owner)
+	if err != nil {
+		log.Printf("Error listing API keys: %v", err)
+		return nil, fmt.Errorf("error listing API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*entities.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			log.Printf("Error scanning API key: %v", err)
+			return nil, fmt.Errorf("error scanning API key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing API keys: %v", err)
+		return nil, fmt.Errorf("error listing API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke makes an API key of the user in ctx stop working at the given
+// time, unless it was already revoked earlier.
+func (r *APIKeyRepository) Revoke(ctx context.Context, id string, at int64) error {
+	owner, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE api_keys
+        SET revoked_at = LEAST(COALESCE(revoked_at, $1), $1)
+        WHERE id = $2 AND owner_id = $3
+    `
+	_, err = r.db.ExecContext(ctx, query, at, id, owner)
+	if err != nil {
+		log.Printf("Error revoking API key: %v", err)
+		return fmt.Errorf("error revoking API key: %w", err)
+	}
+	return nil
+}
+
+// TouchLastUsed records that an API key was used at the given time. It is
+// written at most once a minute per key.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, at int64) error {
+	query := `
+        UPDATE api_keys
+        SET last_used_at = $1
+        WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $1 - 60)
+    `
+	_, err := r.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		log.Printf("Error recording API key use: %v", err)
+		return fmt.Errorf("error recording API key use: %w", err)
+	}
+	return nil
+}
+
+// scanAPIKey reads an API key selected with apiKeyColumns.
+func scanAPIKey(row rowScanner) (*entities.APIKey, error) {
+	var (
+		k                            entities.APIKey
+		scopes                       pq.StringArray
+		expiresAt, revokedAt, usedAt sql.NullInt64
+	)
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &k.OwnerID, &k.DateCreation, &expiresAt, &revokedAt, &usedAt)
+	if err != nil {
+		return nil, err
+	}
+	k.Scopes = scopes
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Int64
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Int64
+	}
+	if usedAt.Valid {
+		k.LastUsedAt = &usedAt.Int64
+	}
+	return &k, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+)
+
+// AttachmentRepositoryInterface persists attachment metadata. Every method
+// but CountBySHA256, which counts the references to a blob, and
+// LockContent is scoped to the workspace selected in ctx and fails with
+// auth.ErrNoWorkspace without one.
+type AttachmentRepositoryInterface interface {
+	Create(ctx context.Context, a *entities.Attachment) error
+	GetByID(ctx context.Context, expenseID, id string) (*entities.Attachment, error)
+	GetBySHA256(ctx context.Context, expenseID, sha256 string) (*entities.Attachment, error)
+	List(ctx context.Context, expenseID string) ([]*entities.Attachment, error)
+	CountBySHA256(ctx context.Context, sha256 string) (int, error)
+	Delete(ctx context.Context, id string) error
+	LockContent(ctx context.Context, sha256 string, fn func(AttachmentRepositoryInterface) error) error
+}
+
+type AttachmentRepository struct {
+	db *sql.DB
+	// tx is set when the repository acts under LockContent.
+	tx *sql.Tx
+}
+
+// NewAttachmentRepository creates a new instance of AttachmentRepository.
+func NewAttachmentRepository(db *sql.DB) AttachmentRepositoryInterface {
+	return &AttachmentRepository{db: db}
+}
+
+// Create saves the metadata of a new attachment in the database, in the
+// workspace of ctx.
+func (r *AttachmentRepository) Create(ctx context.Context, a *entities.Attachment) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO attachments (id, expense_id, file_name, content_type, size, sha256, date_creation, workspace_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err = r.conn().ExecContext(ctx, query, a.ID, a.ExpenseID, a.FileName, a.ContentType, a.Size, a.SHA256, a.DateCreation, workspace)
+	if err != nil {
+		log.Printf("Error creating attachment: %v", err)
+		return fmt.Errorf("error creating attachment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an attachment of an expense by its ID.
+func (r *AttachmentRepository) GetByID(ctx context.Context, expenseID, id string) (*entities.Attachment, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, expense_id, file_name, content_type, size, sha256, date_creation
+        FROM attachments
+        WHERE expense_id = $1 AND id = $2 AND workspace_id = $3
+    `
+	a, err := scanAttachment(r.conn().QueryRowContext(ctx, query, expenseID, id, workspace))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: attachment with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving attachment: %v", err)
+		return nil, fmt.Errorf("error retrieving attachment: %w", err)
+	}
+	return a, nil
+}
+
+// GetBySHA256 retrieves the attachment of an expense with the given
+// content hash.
+func (r *AttachmentRepository) GetBySHA256(ctx context.Context, expenseID, sha256 string) (*entities.Attachment, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, expense_id, file_name, content_type, size, sha256, date_creation
+        FROM attachments
+        WHERE expense_id = $1 AND sha256 = $2 AND workspace_id = $3
+    `
+	a, err := scanAttachment(r.conn().QueryRowContext(ctx, query, expenseID, sha256, workspace))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: attachment with SHA-256 %s", ErrNotFound, sha256)
+		}
+		log.Printf("Error retrieving attachment: %v", err)
+		return nil, fmt.Errorf("error retrieving attachment: %w", err)
+	}
+	return a, nil
+}
+
+// List retrieves the attachments of an expense, oldest first.
+func (r *AttachmentRepository) List(ctx context.Context, expenseID string) ([]*entities.Attachment, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, expense_id, file_name, content_type, size, sha256, date_creation
+        FROM attachments
+        WHERE expense_id = $1 AND workspace_id = $2
+        ORDER BY date_creation, id
+    `
+	rows, err := r.conn().QueryContext(ctx, query, expenseID, workspace)
+	if err != nil {
+		log.Printf("Error listing attachments: %v", err)
+		return nil, fmt.Errorf("error listing attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*entities.Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			log.Printf("Error scanning attachment: %v", err)
+			return nil, fmt.Errorf("error scanning attachment: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing attachments: %v", err)
+		return nil, fmt.Errorf("error listing attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// CountBySHA256 counts the attachments, of any expense in any workspace,
+// with the given content hash.
+func (r *AttachmentRepository) CountBySHA256(ctx context.Context, sha256 string) (int, error) {
+	var count int
+	err := r.conn().QueryRowContext(ctx, `SELECT COUNT(*) FROM attachments WHERE sha256 = $1`, sha256).Scan(&count)
+	if err != nil {
+		log.Printf("Error counting attachments: %v", err)
+		return 0, fmt.Errorf("error counting attachments: %w", err)
+	}
+	return count, nil
+}
+
+// Delete removes the metadata of an attachment by its ID.
+func (r *AttachmentRepository) Delete(ctx context.Context, id string) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        DELETE FROM attachments
+        WHERE id = $1 AND workspace_id = $2
+    `
+	_, err = r.conn().ExecContext(ctx, query, id, workspace)
+	if err != nil {
+		log.Printf("Error deleting attachment: %v", err)
+		return fmt.Errorf("error deleting attachment: %w", err)
+	}
+	return nil
+}
+
+// scanAttachment reads an attachment selected with all its columns.
+func scanAttachment(row rowScanner) (*entities.Attachment, error) {
+	var a entities.Attachment
+	if err := row.Scan(&a.ID, &a.ExpenseID, &a.FileName, &a.ContentType, &a.Size, &a.SHA256, &a.DateCreation); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// LockContent calls fn with a repository acting in a transaction holding
+// a lock on the content sha256, committed unless fn fails, so the blob of
+// the content can be stored or deleted in step with its references.
+func (r *AttachmentRepository) LockContent(ctx context.Context, sha256 string, fn func(AttachmentRepositoryInterface) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error locking attachment content: %v", err)
+		return fmt.Errorf("error locking attachment content: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, sha256); err != nil {
+		log.Printf("Error locking attachment content: %v", err)
+		return fmt.Errorf("error locking attachment content: %w", err)
+	}
+	if err := fn(&AttachmentRepository{db: r.db, tx: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error locking attachment content: %v", err)
+		return fmt.Errorf("error locking attachment content: %w", err)
+	}
+	return nil
+}
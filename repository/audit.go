@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+)
+
+// AuditRepositoryInterface appends to and reads the audit log of the
+// workspace selected in ctx. It has no way to change or remove events.
+type AuditRepositoryInterface interface {
+	Create(ctx context.Context, e *entities.AuditEvent) error
+	ListForEntity(ctx context.Context, entityID string) ([]*entities.AuditEvent, error)
+}
+
+type AuditRepository struct {
+	db *sql.DB
+	// tx is set when the repository belongs to a unit of work.
+	tx *sql.Tx
+}
+
+// NewAuditRepository creates a new instance of AuditRepository.
+func NewAuditRepository(db *sql.DB) AuditRepositoryInterface {
+	return &AuditRepository{db: db}
+}
+
+// Create appends an event to the audit log.
+func (r *AuditRepository) Create(ctx context.Context, e *entities.AuditEvent) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return fmt.Errorf("error encoding audit changes: %w", err)
+	}
+
+	query := `
+        INSERT INTO audit_events (id, workspace_id, entity_type, entity_id, action, actor_id, api_key_id,
+            before, after, changes, request_id, ip, date_creation)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    `
+	_, err = r.conn().ExecContext(ctx, query, e.ID, workspace, e.EntityType, e.EntityID, e.Action, e.ActorID, e.APIKeyID,
+		nullJSON(e.Before), nullJSON(e.After), string(changes), e.RequestID, e.IP, e.DateCreation)
+	if err != nil {
+		log.Printf("Error creating audit event: %v", err)
+		return fmt.Errorf("error creating audit event: %w", err)
+	}
+	return nil
+}
+
+// ListForEntity retrieves the audit events of an entity, oldest first.
+func (r *AuditRepository) ListForEntity(ctx context.Context, entityID string) ([]*entities.AuditEvent, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, entity_type, entity_id, action, actor_id, api_key_id,
+            COALESCE(before, 'null'), COALESCE(after, 'null'), changes, request_id, ip, date_creation
+        FROM audit_events
+        WHERE workspace_id = $1 AND entity_id = $2
+        ORDER BY date_creation, id
+    `
+	rows, err := r.conn().QueryContext(ctx, query, workspace, entityID)
+	if err != nil {
+		log.Printf("Error listing audit events: %v", err)
+		return nil, fmt.Errorf("error listing audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*entities.AuditEvent{}
+	for rows.Next() {
+		var e entities.AuditEvent
+		var before, after, changes []byte
+		if err := rows.Scan(&e.ID, &e.EntityType, &e.EntityID, &e.Action, &e.ActorID, &e.APIKeyID,
+			&before, &after, &changes, &e.RequestID, &e.IP, &e.DateCreation); err != nil {
+			log.Printf("Error scanning audit event: %v", err)
+			return nil, fmt.Errorf("error scanning audit event: %w", err)
+		}
+		e.Before, e.After = before, after
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, fmt.Errorf("error decoding audit changes: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing audit events: %v", err)
+		return nil, fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	return events, nil
+}
+
+// conn returns what the repository runs its statements on.
+func (r *AuditRepository) conn() dbtx {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// nullJSON stores an empty or null snapshot as SQL NULL. Snapshots are
+// sent as strings, as the driver would send bytes as bytea.
+func nullJSON(data json.RawMessage) interface{} {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+)
+
+// BudgetRepositoryInterface persists budgets. Every method is scoped to the
+// workspace selected in ctx and fails with auth.ErrNoWorkspace without one.
+type BudgetRepositoryInterface interface {
+	Create(ctx context.Context, b *entities.Budget) error
+	GetByID(ctx context.Context, id string) (*entities.Budget, error)
+	List(ctx context.Context, categoryID string) ([]*entities.Budget, error)
+	Update(ctx context.Context, b *entities.Budget) error
+	Delete(ctx context.Context, id string) error
+}
+
+type BudgetRepository struct {
+	db *sql.DB
+}
+
+// NewBudgetRepository creates a new instance of BudgetRepository.
+func NewBudgetRepository(db *sql.DB) BudgetRepositoryInterface {
+	return &BudgetRepository{db: db}
+}
+
+// Create saves a new budget in the database, in the workspace of ctx.
+func (r *BudgetRepository) Create(ctx context.Context, b *entities.Budget) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO budgets (id, category_id, period, limit_amount, rollover, date_creation, workspace_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err = r.db.ExecContext(ctx, query, b.ID, b.CategoryID, b.Period, b.Limit, b.Rollover, b.DateCreation, workspace)
+	if err != nil {
+		log.Printf("Error creating budget: %v", err)
+		return fmt.Errorf("error creating budget: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a budget from the database by its ID.
+func (r *BudgetRepository) GetByID(ctx context.Context, id string) (*entities.Budget, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, category_id, period, limit_amount, rollover, date_creation
+        FROM budgets
+        WHERE id = $1 AND workspace_id = $2
+    `
+	row := r.db.QueryRowContext(ctx, query, id, workspace)
+
+	var b entities.Budget
+	err = row.Scan(&b.ID, &b.CategoryID, &b.Period, &b.Limit, &b.Rollover, &b.DateCreation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: budget with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving budget: %v", err)
+		return nil, fmt.Errorf("error retrieving budget: %w", err)
+	}
+
+	return &b, nil
+}
+
+// List retrieves the budgets of a category, or every budget when
+// categoryID is empty.
+func (r *BudgetRepository) List(ctx context.Context, categoryID string) ([]*entities.Budget, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, category_id, period, limit_amount, rollover, date_creation
+        FROM budgets
+        WHERE workspace_id = $1 AND ($2 = '' OR category_id = $2)
+        ORDER BY date_creation, id
+    `
+	rows, err := r.db.QueryContext(ctx, query, workspace, categoryID)
+	if err != nil {
+		log.Printf("Error listing budgets: %v", err)
+		return nil, fmt.Errorf("error listing budgets: %w", err)
+	}
+	defer rows.Close()
+
+	budgets := []*entities.Budget{}
+	for rows.Next() {
+		var b entities.Budget
+		if err := rows.Scan(&b.ID, &b.CategoryID, &b.Period, &b.Limit, &b.Rollover, &b.DateCreation); err != nil {
+			log.Printf("Error scanning budget: %v", err)
+			return nil, fmt.Errorf("error scanning budget: %w", err)
+		}
+		budgets = append(budgets, &b)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing budgets: %v", err)
+		return nil, fmt.Errorf("error listing budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+// Update updates an existing budget in the database.
+func (r *BudgetRepository) Update(ctx context.Context, b *entities.Budget) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE budgets
+        SET category_id = $1, period = $2, limit_amount = $3, rollover = $4
+        WHERE id = $5 AND workspace_id = $6
+    `
+	_, err = r.db.ExecContext(ctx, query, b.CategoryID, b.Period, b.Limit, b.Rollover, b.ID, workspace)
+	if err != nil {
+		log.Printf("Error updating budget: %v", err)
+		return fmt.Errorf("error updating budget: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a budget from the database by its ID.
+func (r *BudgetRepository) Delete(ctx context.Context, id string) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        DELETE FROM budgets
+        WHERE id = $1 AND workspace_id = $2
+    `
+	_, err = r.db.ExecContext(ctx, query, id, workspace)
+	if err != nil {
+		log.Printf("Error deleting budget: %v", err)
+		return fmt.Errorf("error deleting budget: %w", err)
+	}
+	return nil
+}
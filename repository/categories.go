@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+)
+
+// ErrCategoryExists is returned when saving a category with the name of
+// another one of the workspace.
+var ErrCategoryExists = errors.New("category already exists")
+
+// CategoryRepositoryInterface persists categories. Every method is scoped
+// to the workspace selected in ctx and fails with auth.ErrNoWorkspace
+// without one.
+type CategoryRepositoryInterface interface {
+	Create(ctx context.Context, c *entities.Category) error
+	GetByID(ctx context.Context, id string) (*entities.Category, error)
+	List(ctx context.Context) ([]*entities.Category, error)
+	Update(ctx context.Context, c *entities.Category) error
+	Delete(ctx context.Context, id string) error
+}
+
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+// NewCategoryRepository creates a new instance of CategoryRepository.
+func NewCategoryRepository(db *sql.DB) CategoryRepositoryInterface {
+	return &CategoryRepository{db: db}
+}
+
+// Create saves a new category in the database, in the workspace of ctx.
+// It fails with ErrCategoryExists when the name is taken there.
+func (r *CategoryRepository) Create(ctx context.Context, c *entities.Category) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO categories (id, name, description, date_creation, workspace_id)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err = r.db.ExecContext(ctx, query, c.ID, c.Name, c.Description, c.DateCreation, workspace)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%w: %s", ErrCategoryExists, c.Name)
+	}
+	if err != nil {
+		log.Printf("Error creating category: %v", err)
+		return fmt.Errorf("error creating category: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a category from the database by its ID.
+func (r *CategoryRepository) GetByID(ctx context.Context, id string) (*entities.Category, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, name, description, date_creation
+        FROM categories
+        WHERE id = $1 AND workspace_id = $2
+    `
+	row := r.db.QueryRowContext(ctx, query, id, workspace)
+
+	var c entities.Category
+	err = row.Scan(&c.ID, &c.Name, &c.Description, &c.DateCreation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: category with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving category: %v", err)
+		return nil, fmt.Errorf("error retrieving category: %w", err)
+	}
+
+	return &c, nil
+}
+
+// List retrieves the categories of the workspace ordered by name.
+func (r *CategoryRepository) List(ctx context.Context) ([]*entities.Category, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, name, description, date_creation
+        FROM categories
+        WHERE workspace_id = $1
+        ORDER BY name
+    `
+	rows, err := r.db.QueryContext(ctx, query, workspace)
+	if err != nil {
+		log.Printf("Error listing categories: %v", err)
+		return nil, fmt.Errorf("error listing categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*entities.Category{}
+	for rows.Next() {
+		var c entities.Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.DateCreation); err != nil {
+			log.Printf("Error scanning category: %v", err)
+			return nil, fmt.Errorf("error scanning category: %w", err)
+		}
+		categories = append(categories, &c)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing categories: %v", err)
+		return nil, fmt.Errorf("error listing categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// Update updates an existing category in the database. It fails with
+// ErrCategoryExists when another category of the workspace has the name.
+func (r *CategoryRepository) Update(ctx context.Context, c *entities.Category) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE categories
+        SET name = $1, description = $2
+        WHERE id = $3 AND workspace_id = $4
+    `
+	_, err = r.db.ExecContext(ctx, query, c.Name, c.Description, c.ID, workspace)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%w: %s", ErrCategoryExists, c.Name)
+	}
+	if err != nil {
+		log.Printf("Error updating category: %v", err)
+		return fmt.Errorf("error updating category: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a category from the database by its ID. Expenses in the
+// category are left uncategorized.
+func (r *CategoryRepository) Delete(ctx context.Context, id string) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        DELETE FROM categories
+        WHERE id = $1 AND workspace_id = $2
+    `
+	_, err = r.db.ExecContext(ctx, query, id, workspace)
+	if err != nil {
+		log.Printf("Error deleting category: %v", err)
+		return fmt.Errorf("error deleting category: %w", err)
+	}
+	return nil
+}
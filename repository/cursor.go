@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/demo-talent/entities"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+// or was issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursor is the position of the last expense of a page: the value of the
+// sort column and the ID used as tie-breaker.
+type cursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	ID    string          `json:"id"`
+}
+
+// encodeCursor builds the opaque token pointing right after e.
+func encodeCursor(s entities.Sort, e *entities.Expense) (string, error) {
+	var value interface{}
+	switch s.Field {
+	case entities.SortByAmount:
+		value = e.Amount
+	case entities.SortByDescription:
+		value = e.Description
+	default:
+		value = e.DateCreation
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error encoding cursor: %w", err)
+	}
+	b, err := json.Marshal(cursor{Sort: s.String(), Value: raw, ID: e.ID})
+	if err != nil {
+		return "", fmt.Errorf("error encoding cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor parses token and returns the sort column value and ID it
+// points at.
+func decodeCursor(token string, s entities.Sort) (interface{}, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, "", ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, "", ErrInvalidCursor
+	}
+	if c.Sort != s.String() {
+		return nil, "", fmt.Errorf("%w: issued for sort %q", ErrInvalidCursor, c.Sort)
+	}
+
+	var value interface{}
+	switch s.Field {
+	case entities.SortByAmount:
+		var v entities.Money
+		err = json.Unmarshal(c.Value, &v)
+		value = v
+	case entities.SortByDescription:
+		var v string
+		err = json.Unmarshal(c.Value, &v)
+		value = v
+	default:
+		var v int64
+		err = json.Unmarshal(c.Value, &v)
+		value = v
+	}
+	if err != nil {
+		return nil, "", ErrInvalidCursor
+	}
+
+	return value, c.ID, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/demo-talent/entities"
+)
+
+type ExchangeRateRepositoryInterface interface {
+	Upsert(ctx context.Context, rates []entities.ExchangeRate) error
+	RateOn(ctx context.Context, currency string, date time.Time) (*entities.ExchangeRate, error)
+}
+
+type ExchangeRateRepository struct {
+	db *sql.DB
+}
+
+// NewExchangeRateRepository creates a new instance of ExchangeRateRepository.
+func NewExchangeRateRepository(db *sql.DB) ExchangeRateRepositoryInterface {
+	return &ExchangeRateRepository{db: db}
+}
+
+// Upsert saves the given rates in a single transaction, replacing the rate
+// already stored for the same currency and date.
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rates []entities.ExchangeRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error saving exchange rates: %v", err)
+		return fmt.Errorf("error saving exchange rates: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+        INSERT INTO exchange_rates (currency, rate_date, rate)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (currency, rate_date) DO UPDATE SET rate = EXCLUDED.rate
+    `)
+	if err != nil {
+		log.Printf("Error saving exchange rates: %v", err)
+		return fmt.Errorf("error saving exchange rates: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, rate := range rates {
+		if _, err := stmt.ExecContext(ctx, rate.Currency, rate.Date, rate.Rate); err != nil {
+			log.Printf("Error saving exchange rate: %v", err)
+			return fmt.Errorf("error saving exchange rate %s on %s: %w", rate.Currency, rate.Date.Format("2006-01-02"), err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error saving exchange rates: %v", err)
+		return fmt.Errorf("error saving exchange rates: %w", err)
+	}
+	return nil
+}
+
+// RateOn retrieves the rate of a currency in effect on the given date: the
+// latest one published on or before it.
+func (r *ExchangeRateRepository) RateOn(ctx context.Context, currency string, date time.Time) (*entities.ExchangeRate, error) {
+	query := `
+        SELECT currency, rate_date, rate::text
+        FROM exchange_rates
+        WHERE currency = $1 AND rate_date <= $2
+        ORDER BY rate_date DESC
+        LIMIT 1
+    `
+	row := r.db.QueryRowContext(ctx, query, currency, date)
+
+	var rate entities.ExchangeRate
+	err := row.Scan(&rate.Currency, &rate.Date, &rate.Rate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s exchange rate on %s", ErrNotFound, currency, date.Format("2006-01-02"))
+		}
+		log.Printf("Error retrieving exchange rate: %v", err)
+		return nil, fmt.Errorf("error retrieving exchange rate: %w", err)
+	}
+
+	return &rate, nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/entities"
+)
+
+// ErrIdempotencyClaimLost is returned when creating an expense for a
+// request whose Idempotency-Key was taken over by a retry.
+var ErrIdempotencyClaimLost = errors.New("idempotency key taken over")
+
+// IdempotencyRepositoryInterface persists the Idempotency-Keys of the user
+// authenticated in ctx within the workspace it selects. DeleteExpired is
+// left to the cleanup job and acts on every user.
+type IdempotencyRepositoryInterface interface {
+	Claim(ctx context.Context, r *entities.IdempotencyRecord, staleBefore int64) (bool, error)
+	Get(ctx context.Context, key string) (*entities.IdempotencyRecord, error)
+	Complete(ctx context.Context, key, token string, resp *entities.IdempotentResponse) error
+	Release(ctx context.Context, key, token string) error
+	DeleteExpired(ctx context.Context, now int64) (int64, error)
+}
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository.
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepositoryInterface {
+	return &IdempotencyRepository{db: db}
+}
+
+// Claim records r as in progress, held with r.Token, unless its key is
+// held, and reports whether it did. Keys expired or claimed by an
+// identical request before staleBefore without committing are taken over.
+func (r *IdempotencyRepository) Claim(ctx context.Context, rec *entities.IdempotencyRecord, staleBefore int64) (bool, error) {
+	user, workspace, err := tenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+        INSERT INTO idempotency_keys (user_id, workspace_id, idempotency_key, request_hash, claim_token, claimed_at, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (user_id, workspace_id, idempotency_key) DO UPDATE
+        SET request_hash = EXCLUDED.request_hash, claim_token = EXCLUDED.claim_token, committed = FALSE,
+            claimed_at = EXCLUDED.claimed_at, expires_at = EXCLUDED.expires_at,
+            status_code = NULL, response_header = NULL, response_body = NULL
+        WHERE idempotency_keys.expires_at <= EXCLUDED.claimed_at
+        OR (idempotency_keys.status_code IS NULL AND NOT idempotency_keys.committed
+            AND idempotency_keys.claimed_at < $8 AND idempotency_keys.request_hash = EXCLUDED.request_hash)
+    `
+	res, err := r.db.ExecContext(ctx, query, user, workspace, rec.Key, rec.RequestHash, rec.Token, rec.ClaimedAt, rec.ExpiresAt, staleBefore)
+	if err != nil {
+		log.Printf("Error claiming idempotency key: %v", err)
+		return false, fmt.Errorf("error claiming idempotency key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error checking affected rows: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Get retrieves the record of an Idempotency-Key.
+func (r *IdempotencyRepository) Get(ctx context.Context, key string) (*entities.IdempotencyRecord, error) {
+	user, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT idempotency_key, request_hash, COALESCE(claim_token, ''), committed, status_code,
+            COALESCE(response_header, 'null'), response_body, claimed_at, expires_at
+        FROM idempotency_keys
+        WHERE user_id = $1 AND workspace_id = $2 AND idempotency_key = $3
+    `
+	var (
+		rec    entities.IdempotencyRecord
+		status sql.NullInt64
+		header []byte
+		body   []byte
+	)
+	err = r.db.QueryRowContext(ctx, query, user, workspace, key).Scan(&rec.Key, &rec.RequestHash, &rec.Token, &rec.Committed, &status,
+		&header, &body, &rec.ClaimedAt, &rec.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: idempotency key %s", ErrNotFound, key)
+		}
+		log.Printf("Error retrieving idempotency key: %v", err)
+		return nil, fmt.Errorf("error retrieving idempotency key: %w", err)
+	}
+	if status.Valid {
+		rec.Response = &entities.IdempotentResponse{StatusCode: int(status.Int64), Body: body}
+		if err := json.Unmarshal(header, &rec.Response.Header); err != nil {
+			return nil, fmt.Errorf("error decoding idempotent response header: %w", err)
+		}
+	}
+
+	return &rec, nil
+}
+
+// Complete stores the response to the request holding an Idempotency-Key
+// with token.
+func (r *IdempotencyRepository) Complete(ctx context.Context, key, token string, resp *entities.IdempotentResponse) error {
+	user, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+	header, err := json.Marshal(resp.Header)
+	if err != nil {
+		return fmt.Errorf("error encoding idempotent response header: %w", err)
+	}
+
+	query := `
+        UPDATE idempotency_keys
+        SET status_code = $1, response_header = $2, response_body = $3
+        WHERE user_id = $4 AND workspace_id = $5 AND idempotency_key = $6 AND claim_token = $7
+            AND status_code IS NULL
+    `
+	_, err = r.db.ExecContext(ctx, query, resp.StatusCode, string(header), resp.Body, user, workspace, key, token)
+	if err != nil {
+		log.Printf("Error completing idempotency key: %v", err)
+		return fmt.Errorf("error completing idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release frees an Idempotency-Key held with token whose request is still
+// in progress and committed nothing, so a retry is processed again.
+func (r *IdempotencyRepository) Release(ctx context.Context, key, token string) error {
+	user, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        DELETE FROM idempotency_keys
+        WHERE user_id = $1 AND workspace_id = $2 AND idempotency_key = $3 AND claim_token = $4
+            AND status_code IS NULL AND NOT committed
+    `
+	if _, err := r.db.ExecContext(ctx, query, user, workspace, key, token); err != nil {
+		log.Printf("Error releasing idempotency key: %v", err)
+		return fmt.Errorf("error releasing idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes the Idempotency-Keys of every user that expired at
+// now, and returns how many it removed.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now int64) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		log.Printf("Error deleting expired idempotency keys: %v", err)
+		return 0, fmt.Errorf("error deleting expired idempotency keys: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error checking affected rows: %w", err)
+	}
+	return n, nil
+}
+
+type idempotencyClaimKey struct{}
+
+// idempotencyClaim is the hold of a request on its Idempotency-Key.
+type idempotencyClaim struct {
+	key, token string
+}
+
+// WithIdempotencyClaim returns a copy of ctx for processing the request
+// holding key with token. Expenses created with it are only saved while
+// the request still holds the key, which is marked committed in the same
+// transaction.
+func WithIdempotencyClaim(ctx context.Context, key, token string) context.Context {
+	return context.WithValue(ctx, idempotencyClaimKey{}, idempotencyClaim{key: key, token: token})
+}
+
+// commitIdempotencyClaim marks as committed, in tx, the Idempotency-Key
+// held by the request ctx processes, if any. It fails with
+// ErrIdempotencyClaimLost when another request took the key over, and
+// otherwise locks the key until tx ends so none can.
+func commitIdempotencyClaim(ctx context.Context, tx dbtx, user, workspace string) error {
+	claim, ok := ctx.Value(idempotencyClaimKey{}).(idempotencyClaim)
+	if !ok {
+		return nil
+	}
+	res, err := tx.ExecContext(ctx, `
+        UPDATE idempotency_keys SET committed = TRUE
+        WHERE user_id = $1 AND workspace_id = $2 AND idempotency_key = $3 AND claim_token = $4
+            AND status_code IS NULL
+    `, user, workspace, claim.key, claim.token)
+	if err != nil {
+		log.Printf("Error committing idempotency key: %v", err)
+		return fmt.Errorf("error committing idempotency key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s is held by another request", ErrIdempotencyClaimLost, claim.key)
+	}
+	return nil
+}
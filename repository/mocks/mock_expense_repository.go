@@ -50,17 +50,46 @@ func (mr *MockExpenseRepositoryInterfaceMockRecorder) Create(arg0, arg1 interfac
 }
 
 // Delete mocks base method.
-func (m *MockExpenseRepositoryInterface) Delete(arg0 context.Context, arg1 string) error {
+func (m *MockExpenseRepositoryInterface) Delete(arg0 context.Context, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockExpenseRepositoryInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Export mocks base method.
+func (m *MockExpenseRepositoryInterface) Export(arg0 context.Context, arg1 entities.ExpenseFilter, arg2 func(*entities.Expense) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) Export(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).Export), arg0, arg1, arg2)
+}
+
+// ExternalRefs mocks base method.
+func (m *MockExpenseRepositoryInterface) ExternalRefs(arg0 context.Context, arg1 []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExternalRefs", arg0, arg1)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExternalRefs indicates an expected call of ExternalRefs.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) ExternalRefs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExternalRefs", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).ExternalRefs), arg0, arg1)
 }
 
 // GetByID mocks base method.
@@ -78,6 +107,156 @@ func (mr *MockExpenseRepositoryInterfaceMockRecorder) GetByID(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).GetByID), arg0, arg1)
 }
 
+// GetInWorkspace mocks base method.
+func (m *MockExpenseRepositoryInterface) GetInWorkspace(arg0 context.Context, arg1 string) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInWorkspace indicates an expected call of GetInWorkspace.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) GetInWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInWorkspace", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).GetInWorkspace), arg0, arg1)
+}
+
+// GetRevision mocks base method.
+func (m *MockExpenseRepositoryInterface) GetRevision(arg0 context.Context, arg1 string, arg2 int) (*entities.ExpenseRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.ExpenseRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) GetRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).GetRevision), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockExpenseRepositoryInterface) List(arg0 context.Context, arg1 entities.ExpenseFilter, arg2 entities.PageRequest) (*entities.ExpensePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.ExpensePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).List), arg0, arg1, arg2)
+}
+
+// ListByStatus mocks base method.
+func (m *MockExpenseRepositoryInterface) ListByStatus(arg0 context.Context, arg1 entities.ExpenseStatus) ([]*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStatus", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) ListByStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).ListByStatus), arg0, arg1)
+}
+
+// ListDeleted mocks base method.
+func (m *MockExpenseRepositoryInterface) ListDeleted(arg0 context.Context) ([]*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", arg0)
+	ret0, _ := ret[0].([]*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) ListDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).ListDeleted), arg0)
+}
+
+// ListRevisions mocks base method.
+func (m *MockExpenseRepositoryInterface) ListRevisions(arg0 context.Context, arg1 string) ([]*entities.ExpenseRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.ExpenseRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) ListRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).ListRevisions), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockExpenseRepositoryInterface) Purge(arg0 context.Context, arg1 int64) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockExpenseRepositoryInterface) Restore(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).Restore), arg0, arg1)
+}
+
+// SetStatus mocks base method.
+func (m *MockExpenseRepositoryInterface) SetStatus(arg0 context.Context, arg1 string, arg2, arg3 entities.ExpenseStatus, arg4 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) SetStatus(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).SetStatus), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SumByCurrencyAndDate mocks base method.
+func (m *MockExpenseRepositoryInterface) SumByCurrencyAndDate(arg0 context.Context, arg1 entities.ExpenseFilter) ([]entities.DailyTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByCurrencyAndDate", arg0, arg1)
+	ret0, _ := ret[0].([]entities.DailyTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByCurrencyAndDate indicates an expected call of SumByCurrencyAndDate.
+func (mr *MockExpenseRepositoryInterfaceMockRecorder) SumByCurrencyAndDate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByCurrencyAndDate", reflect.TypeOf((*MockExpenseRepositoryInterface)(nil).SumByCurrencyAndDate), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockExpenseRepositoryInterface) Update(arg0 context.Context, arg1 *entities.Expense) error {
 	m.ctrl.T.Helper()
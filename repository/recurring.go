@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/entities"
+	"github.com/lib/pq"
+)
+
+// ErrOccurrenceClaimed is returned when creating the expense of an
+// occurrence of a recurring expense that was already created.
+var ErrOccurrenceClaimed = errors.New("recurring occurrence already claimed")
+
+// RecurringExpenseRepositoryInterface persists recurring expenses. The
+// CRUD methods are scoped to the user authenticated in ctx and the
+// workspace it selects; the scheduler methods, from ListDue on, work across
+// users and workspaces.
+type RecurringExpenseRepositoryInterface interface {
+	Create(ctx context.Context, r *entities.RecurringExpense) error
+	GetByID(ctx context.Context, id string) (*entities.RecurringExpense, error)
+	List(ctx context.Context) ([]*entities.RecurringExpense, error)
+	ListDue(ctx context.Context, now int64) ([]*entities.RecurringExpense, error)
+	Update(ctx context.Context, r *entities.RecurringExpense) error
+	Delete(ctx context.Context, id string) error
+	AdvanceNextRun(ctx context.Context, id string, from int64, next *int64) error
+}
+
+type RecurringExpenseRepository struct {
+	db *sql.DB
+}
+
+// NewRecurringExpenseRepository creates a new instance of RecurringExpenseRepository.
+func NewRecurringExpenseRepository(db *sql.DB) RecurringExpenseRepositoryInterface {
+	return &RecurringExpenseRepository{db: db}
+}
+
+// recurringColumns are the columns scanned by scanRecurringExpense, in order.
+const recurringColumns = `
+        id, description, amount, currency, COALESCE(category_id, ''), tags,
+        frequency, interval_count, start_at, end_at, next_run, owner_id, workspace_id, date_creation
+`
+
+// Create saves a new recurring expense in the database, owned by the user
+// in ctx within its workspace.
+func (r *RecurringExpenseRepository) Create(ctx context.Context, re *entities.RecurringExpense) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+	re.OwnerID, re.WorkspaceID = owner, workspace
+
+	query := `
+        INSERT INTO recurring_expenses (id, description, amount, currency, category_id, tags,
+            frequency, interval_count, start_at, end_at, next_run, owner_id, workspace_id, date_creation)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11, $12, $13, $14)
+    `
+	_, err = r.db.ExecContext(ctx, query, re.ID, re.Description, re.Amount, re.Currency, re.CategoryID, pq.Array(re.Tags),
+		re.Frequency, re.Interval, re.StartAt, re.EndAt, re.NextRun, re.OwnerID, re.WorkspaceID, re.DateCreation)
+	if err != nil {
+		log.Printf("Error creating recurring expense: %v", err)
+		return fmt.Errorf("error creating recurring expense: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a recurring expense from the database by its ID.
+func (r *RecurringExpenseRepository) GetByID(ctx context.Context, id string) (*entities.RecurringExpense, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + recurringColumns + `
+        FROM recurring_expenses
+        WHERE id = $1 AND owner_id = $2 AND workspace_id = $3
+    `
+	re, err := scanRecurringExpense(r.db.QueryRowContext(ctx, query, id, owner, workspace))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: recurring expense with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving recurring expense: %v", err)
+		return nil, fmt.Errorf("error retrieving recurring expense: %w", err)
+	}
+	return re, nil
+}
+
+// List retrieves the recurring expenses of the user in ctx within its
+// workspace.
+func (r *RecurringExpenseRepository) List(ctx context.Context) ([]*entities.RecurringExpense, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + recurringColumns + `
+        FROM recurring_expenses
+        WHERE owner_id = $1 AND workspace_id = $2
+        ORDER BY date_creation, id
+    `
+	return r.query(ctx, query, owner, workspace)
+}
+
+// ListDue retrieves the recurring expenses of every user and workspace
+// whose next run is at or before now.
+func (r *RecurringExpenseRepository) ListDue(ctx context.Context, now int64) ([]*entities.RecurringExpense, error) {
+	query := `SELECT ` + recurringColumns + `
+        FROM recurring_expenses
+        WHERE next_run IS NOT NULL AND next_run <= $1
+        ORDER BY next_run, id
+    `
+	return r.query(ctx, query, now)
+}
+
+// Update updates an existing recurring expense in the database.
+func (r *RecurringExpenseRepository) Update(ctx context.Context, re *entities.RecurringExpense) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE recurring_expenses
+        SET description = $1, amount = $2, currency = $3, category_id = NULLIF($4, ''), tags = $5,
+            frequency = $6, interval_count = $7, start_at = $8, end_at = $9, next_run = $10
+        WHERE id = $11 AND owner_id = $12 AND workspace_id = $13
+    `
+	_, err = r.db.ExecContext(ctx, query, re.Description, re.Amount, re.Currency, re.CategoryID, pq.Array(re.Tags),
+		re.Frequency, re.Interval, re.StartAt, re.EndAt, re.NextRun, re.ID, owner, workspace)
+	if err != nil {
+		log.Printf("Error updating recurring expense: %v", err)
+		return fmt.Errorf("error updating recurring expense: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a recurring expense from the database by its ID. The
+// expenses it already created are kept.
+func (r *RecurringExpenseRepository) Delete(ctx context.Context, id string) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        DELETE FROM recurring_expenses
+        WHERE id = $1 AND owner_id = $2 AND workspace_id = $3
+    `
+	_, err = r.db.ExecContext(ctx, query, id, owner, workspace)
+	if err != nil {
+		log.Printf("Error deleting recurring expense: %v", err)
+		return fmt.Errorf("error deleting recurring expense: %w", err)
+	}
+	return nil
+}
+
+type recurringOccurrenceKey struct{}
+
+// recurringOccurrence is an occurrence of a recurring expense.
+type recurringOccurrence struct {
+	id          string
+	scheduledAt int64
+}
+
+// WithRecurringOccurrence returns a copy of ctx for materializing the
+// occurrence of the recurring expense id scheduled at scheduledAt. The
+// expense created with it claims the occurrence in the same transaction,
+// so it is created at most once and a failed creation leaves it unclaimed.
+func WithRecurringOccurrence(ctx context.Context, id string, scheduledAt int64) context.Context {
+	return context.WithValue(ctx, recurringOccurrenceKey{}, recurringOccurrence{id: id, scheduledAt: scheduledAt})
+}
+
+// claimRecurringOccurrence claims, in tx, the occurrence ctx materializes,
+// if any, for the expense expenseID. It fails with ErrOccurrenceClaimed
+// when the occurrence was already claimed.
+func claimRecurringOccurrence(ctx context.Context, tx dbtx, expenseID string) error {
+	o, ok := ctx.Value(recurringOccurrenceKey{}).(recurringOccurrence)
+	if !ok {
+		return nil
+	}
+	res, err := tx.ExecContext(ctx, `
+        INSERT INTO recurring_occurrences (recurring_id, scheduled_at, expense_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT DO NOTHING
+    `, o.id, o.scheduledAt, expenseID)
+	if err != nil {
+		log.Printf("Error claiming recurring occurrence: %v", err)
+		return fmt.Errorf("error claiming recurring occurrence: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s at %d", ErrOccurrenceClaimed, o.id, o.scheduledAt)
+	}
+	return nil
+}
+
+// AdvanceNextRun moves the next run from one occurrence to the next, or
+// clears it when next is nil. It does nothing if the template was moved
+// meanwhile.
+func (r *RecurringExpenseRepository) AdvanceNextRun(ctx context.Context, id string, from int64, next *int64) error {
+	query := `
+        UPDATE recurring_expenses
+        SET next_run = $1
+        WHERE id = $2 AND next_run = $3
+    `
+	_, err := r.db.ExecContext(ctx, query, next, id, from)
+	if err != nil {
+		log.Printf("Error advancing recurring expense: %v", err)
+		return fmt.Errorf("error advancing recurring expense: %w", err)
+	}
+	return nil
+}
+
+func (r *RecurringExpenseRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.RecurringExpense, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error listing recurring expenses: %v", err)
+		return nil, fmt.Errorf("error listing recurring expenses: %w", err)
+	}
+	defer rows.Close()
+
+	recurring := []*entities.RecurringExpense{}
+	for rows.Next() {
+		re, err := scanRecurringExpense(rows)
+		if err != nil {
+			log.Printf("Error scanning recurring expense: %v", err)
+			return nil, fmt.Errorf("error scanning recurring expense: %w", err)
+		}
+		recurring = append(recurring, re)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing recurring expenses: %v", err)
+		return nil, fmt.Errorf("error listing recurring expenses: %w", err)
+	}
+	return recurring, nil
+}
+
+// scanRecurringExpense reads a recurring expense selected with recurringColumns.
+func scanRecurringExpense(row rowScanner) (*entities.RecurringExpense, error) {
+	var (
+		re            entities.RecurringExpense
+		tags          pq.StringArray
+		endAt, nextAt sql.NullInt64
+	)
+	err := row.Scan(&re.ID, &re.Description, &re.Amount, &re.Currency, &re.CategoryID, &tags,
+		&re.Frequency, &re.Interval, &re.StartAt, &endAt, &nextAt, &re.OwnerID, &re.WorkspaceID, &re.DateCreation)
+	if err != nil {
+		return nil, err
+	}
+	re.Tags = tags
+	if endAt.Valid {
+		re.EndAt = &endAt.Int64
+	}
+	if nextAt.Valid {
+		re.NextRun = &nextAt.Int64
+	}
+	return &re, nil
+}
@@ -3,22 +3,80 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/demo-talent/auth"
 	"github.com/demo-talent/entities"
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq" // PostgreSQL driver
 )
 
+// ErrNotFound is returned when the requested row does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionConflict is returned when changing an expense that is no
+// longer at the version the change was based on.
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrSplitMismatch is returned when changing the amount of an expense
+// that is split for another amount, or splitting it for another amount.
+var ErrSplitMismatch = errors.New("amount does not match splits")
+
+// ErrDuplicateExternalRef is returned when creating an expense with the
+// external reference of an expense already in the workspace.
+var ErrDuplicateExternalRef = errors.New("duplicate external reference")
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint
+// violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// expenseColumns are the columns scanned by scanExpense, in order. Tags are
+// aggregated from expense_tags so every read returns them sorted by name.
+const expenseColumns = `
+        id, description, amount, currency, date_creation, COALESCE(category_id, ''),
+        ARRAY(
+            SELECT t.name
+            FROM expense_tags et
+            JOIN tags t ON t.id = et.tag_id
+            WHERE et.expense_id = expenses.id
+            ORDER BY t.name
+        ),
+        owner_id, workspace_id, status, status_reason, deleted_at, version,
+        COALESCE(external_ref, '')
+`
+
+// ExpenseRepositoryInterface persists the expenses of the user
+// authenticated in ctx within the workspace it selects. The approval
+// methods act on every member's expenses, and Purge on every workspace.
 type ExpenseRepositoryInterface interface {
 	Create(ctx context.Context, e *entities.Expense) error
 	GetByID(ctx context.Context, id string) (*entities.Expense, error)
+	ExternalRefs(ctx context.Context, refs []string) (map[string]bool, error)
+	List(ctx context.Context, f entities.ExpenseFilter, p entities.PageRequest) (*entities.ExpensePage, error)
+	Export(ctx context.Context, f entities.ExpenseFilter, fn func(*entities.Expense) error) error
+	SumByCurrencyAndDate(ctx context.Context, f entities.ExpenseFilter) ([]entities.DailyTotal, error)
 	Update(ctx context.Context, e *entities.Expense) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, version int) error
+	GetInWorkspace(ctx context.Context, id string) (*entities.Expense, error)
+	ListByStatus(ctx context.Context, status entities.ExpenseStatus) ([]*entities.Expense, error)
+	SetStatus(ctx context.Context, id string, from, to entities.ExpenseStatus, reason string) (int, error)
+	Restore(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context) ([]*entities.Expense, error)
+	Purge(ctx context.Context, before int64) (int64, []string, error)
+	ListRevisions(ctx context.Context, expenseID string) ([]*entities.ExpenseRevision, error)
+	GetRevision(ctx context.Context, expenseID string, revision int) (*entities.ExpenseRevision, error)
 }
 
 type ExpenseRepository struct {
 	db *sql.DB
+	// tx is set when the repository belongs to a unit of work.
+	tx *sql.Tx
 }
 
 // NewExpenseRepository creates a new instance of ExpenseRepository.
@@ -26,67 +84,773 @@ func NewExpenseRepository(db *sql.DB) ExpenseRepositoryInterface {
 	return &ExpenseRepository{db: db}
 }
 
-// Create saves a new expense in the database.
+// Create saves a new expense and its tags, owned by the user in ctx within
+// its workspace, and commits the claims ctx carries; see
+// WithIdempotencyClaim and WithRecurringOccurrence. It fails with
+// ErrDuplicateExternalRef when the external reference is taken.
 func (r *ExpenseRepository) Create(ctx context.Context, e *entities.Expense) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+	e.OwnerID, e.WorkspaceID = owner, workspace
+
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error creating expense: %v", err)
+		return fmt.Errorf("error creating expense: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := commitIdempotencyClaim(ctx, tx, owner, workspace); err != nil {
+		return err
+	}
+	if err := claimRecurringOccurrence(ctx, tx, e.ID); err != nil {
+		return err
+	}
+
 	query := `
-        INSERT INTO expenses (id, description, amount, date_creation)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO expenses (id, description, amount, currency, date_creation, category_id, owner_id, workspace_id, status, version, external_ref)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10, NULLIF($11, ''))
+        ON CONFLICT (workspace_id, external_ref) WHERE external_ref IS NOT NULL DO NOTHING
     `
-	_, err := r.db.ExecContext(ctx, query, e.ID, e.Description, e.Amount, e.DateCreation)
+	res, err := tx.ExecContext(ctx, query, e.ID, e.Description, e.Amount, e.Currency, e.DateCreation, e.CategoryID, e.OwnerID, e.WorkspaceID, e.Status, e.Version, e.ExternalRef)
 	if err != nil {
 		log.Printf("Error creating expense: %v", err)
 		return fmt.Errorf("error creating expense: %w", err)
 	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s", ErrDuplicateExternalRef, e.ExternalRef)
+	}
+	if err := setTags(ctx, tx, workspace, e.ID, e.Tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error creating expense: %v", err)
+		return fmt.Errorf("error creating expense: %w", err)
+	}
 	return nil
 }
 
 // GetByID retrieves an expense from the database by its ID.
 func (r *ExpenseRepository) GetByID(ctx context.Context, id string) (*entities.Expense, error) {
-	query := `
-        SELECT id, description, amount, date_creation
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expenseColumns + `
         FROM expenses
-        WHERE id = $1
+        WHERE id = $1 AND owner_id = $2 AND workspace_id = $3 AND deleted_at IS NULL
     `
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.conn().QueryRowContext(ctx, query, id, owner, workspace)
 
-	var e entities.Expense
-	err := row.Scan(&e.ID, &e.Description, &e.Amount, &e.DateCreation)
+	e, err := scanExpense(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("expense not found with ID: %s", id)
+			return nil, fmt.Errorf("%w: expense with ID %s", ErrNotFound, id)
 		}
 		log.Printf("Error retrieving expense: %v", err)
 		return nil, fmt.Errorf("error retrieving expense: %w", err)
 	}
 
-	return &e, nil
+	return e, nil
+}
+
+// ExternalRefs reports which of the given external references expenses
+// of the workspace were already created with, by any member and counting
+// the expenses in the trash.
+func (r *ExpenseRepository) ExternalRefs(ctx context.Context, refs []string) (map[string]bool, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	if len(refs) == 0 {
+		return found, nil
+	}
+	query := `
+        SELECT external_ref
+        FROM expenses
+        WHERE workspace_id = $1 AND external_ref = ANY($2)
+    `
+	rows, err := r.conn().QueryContext(ctx, query, workspace, pq.Array(refs))
+	if err != nil {
+		log.Printf("Error retrieving external references: %v", err)
+		return nil, fmt.Errorf("error retrieving external references: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			log.Printf("Error scanning external reference: %v", err)
+			return nil, fmt.Errorf("error scanning external reference: %w", err)
+		}
+		found[ref] = true
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error retrieving external references: %v", err)
+		return nil, fmt.Errorf("error retrieving external references: %w", err)
+	}
+	return found, nil
+}
+
+// sortColumns maps the sortable fields to their column in the expenses table.
+var sortColumns = map[entities.SortField]string{
+	entities.SortByDate:        "date_creation",
+	entities.SortByAmount:      "amount",
+	entities.SortByDescription: "description",
+}
+
+// List retrieves a page of the expenses matching the given filter using
+// keyset pagination over the sort column and the ID.
+func (r *ExpenseRepository) List(ctx context.Context, f entities.ExpenseFilter, p entities.PageRequest) (*entities.ExpensePage, error) {
+	column, ok := sortColumns[p.Sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field: %s", p.Sort.Field)
+	}
+	direction, comparison := "ASC", ">"
+	if p.Sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args := filterConditions(owner, workspace, f)
+	if p.Cursor != "" {
+		value, id, err := decodeCursor(p.Cursor, p.Sort)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value, id)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + expenseColumns + `
+        FROM expenses
+        WHERE ` + strings.Join(conditions, " AND ")
+	// One extra row tells whether there is a next page.
+	args = append(args, p.Limit+1)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT $%d", column, direction, direction, len(args))
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error listing expenses: %v", err)
+		return nil, fmt.Errorf("error listing expenses: %w", err)
+	}
+	defer rows.Close()
+
+	page := &entities.ExpensePage{Items: []*entities.Expense{}}
+	for rows.Next() {
+		e, err := scanExpense(rows)
+		if err != nil {
+			log.Printf("Error scanning expense: %v", err)
+			return nil, fmt.Errorf("error scanning expense: %w", err)
+		}
+		page.Items = append(page.Items, e)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing expenses: %v", err)
+		return nil, fmt.Errorf("error listing expenses: %w", err)
+	}
+
+	if len(page.Items) > p.Limit {
+		page.Items = page.Items[:p.Limit]
+		page.HasMore = true
+		if page.NextCursor, err = encodeCursor(p.Sort, page.Items[p.Limit-1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// exportBatchSize is how many rows Export fetches from its cursor at a
+// time.
+const exportBatchSize = 500
+
+// Export calls fn with every expense matching the given filter, oldest
+// first. Rows are fetched in batches through a server-side cursor, so the
+// expenses are never all in memory. It stops at the first error fn
+// returns.
+func (r *ExpenseRepository) Export(ctx context.Context, f entities.ExpenseFilter, fn func(*entities.Expense) error) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Cursors only live as long as the transaction declaring them.
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return fmt.Errorf("error exporting expenses: %w", err)
+	}
+	defer tx.Rollback()
+
+	conditions, args := filterConditions(owner, workspace, f)
+	query := `DECLARE expense_export NO SCROLL CURSOR FOR
+        SELECT ` + expenseColumns + `
+        FROM expenses
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        ORDER BY date_creation, id
+    `
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return fmt.Errorf("error exporting expenses: %w", err)
+	}
+
+	for {
+		n, err := fetchExpenses(ctx, tx, fmt.Sprintf(`FETCH FORWARD %d FROM expense_export`, exportBatchSize), fn)
+		if err != nil {
+			return err
+		}
+		if n < exportBatchSize {
+			break
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `CLOSE expense_export`); err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return fmt.Errorf("error exporting expenses: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return fmt.Errorf("error exporting expenses: %w", err)
+	}
+	return nil
 }
 
-// Update updates an existing expense in the database.
+// fetchExpenses runs a query selecting expenseColumns and calls fn with
+// each expense it returns. It returns how many expenses it read.
+func fetchExpenses(ctx context.Context, tx dbtx, query string, fn func(*entities.Expense) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return 0, fmt.Errorf("error exporting expenses: %w", err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		e, err := scanExpense(rows)
+		if err != nil {
+			log.Printf("Error scanning expense: %v", err)
+			return n, fmt.Errorf("error scanning expense: %w", err)
+		}
+		n++
+		if err := fn(e); err != nil {
+			return n, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return n, fmt.Errorf("error exporting expenses: %w", err)
+	}
+	return n, nil
+}
+
+// SumByCurrencyAndDate sums the expenses matching the given filter per
+// currency and UTC day, so each sum can be converted at that day's rate.
+func (r *ExpenseRepository) SumByCurrencyAndDate(ctx context.Context, f entities.ExpenseFilter) ([]entities.DailyTotal, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args := filterConditions(owner, workspace, f)
+	query := `
+        SELECT currency, (to_timestamp(date_creation) AT TIME ZONE 'UTC')::date AS day, SUM(amount)
+        FROM expenses
+        WHERE ` + strings.Join(conditions, " AND ")
+	query += " GROUP BY currency, day ORDER BY currency, day"
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error summing expenses: %v", err)
+		return nil, fmt.Errorf("error summing expenses: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []entities.DailyTotal{}
+	for rows.Next() {
+		var t entities.DailyTotal
+		if err := rows.Scan(&t.Currency, &t.Date, &t.Amount); err != nil {
+			log.Printf("Error scanning expense sum: %v", err)
+			return nil, fmt.Errorf("error scanning expense sum: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error summing expenses: %v", err)
+		return nil, fmt.Errorf("error summing expenses: %w", err)
+	}
+
+	return totals, nil
+}
+
+// Update updates an existing expense still at e.Version, and its tags
+// unless e.Tags is nil, then moves e.Version to the next version. Locked
+// expenses are reported as not found, and changing the amount of a split
+// one fails with ErrSplitMismatch.
 func (r *ExpenseRepository) Update(ctx context.Context, e *entities.Expense) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error updating expense: %v", err)
+		return fmt.Errorf("error updating expense: %w", err)
+	}
+	defer tx.Rollback()
+
+	version, err := lockExpense(ctx, tx, e.ID, `owner_id = $2 AND workspace_id = $3
+        AND status NOT IN ('approved', 'reimbursed')`, owner, workspace)
+	if err == nil {
+		err = expectVersion(e.ID, version, e.Version)
+	}
+	if err == nil {
+		err = saveRevision(ctx, tx, e.ID, owner)
+	}
+	if err != nil {
+		return err
+	}
+
 	query := `
         UPDATE expenses
-        SET description = $1, amount = $2
-        WHERE id = $3
+        SET description = $1, amount = $2, currency = $3, category_id = NULLIF($4, ''), version = version + 1
+        WHERE id = $5
     `
-	_, err := r.db.ExecContext(ctx, query, e.Description, e.Amount, e.ID)
+	_, err = tx.ExecContext(ctx, query, e.Description, e.Amount, e.Currency, e.CategoryID, e.ID)
 	if err != nil {
 		log.Printf("Error updating expense: %v", err)
 		return fmt.Errorf("error updating expense: %w", err)
 	}
+	if e.Tags != nil {
+		if err := setTags(ctx, tx, workspace, e.ID, e.Tags); err != nil {
+			return err
+		}
+	}
+	// Splits that no longer add up to the amount would skew the balances,
+	// so the splits have to change first.
+	var split entities.Money
+	var splits int
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(SUM(amount), 0), COUNT(*)
+        FROM expense_splits
+        WHERE expense_id = $1
+    `, e.ID).Scan(&split, &splits)
+	if err != nil {
+		log.Printf("Error updating expense: %v", err)
+		return fmt.Errorf("error updating expense: %w", err)
+	}
+	if splits > 0 && split != e.Amount {
+		return fmt.Errorf("%w: expense %s is split for %s", ErrSplitMismatch, e.ID, split)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error updating expense: %v", err)
+		return fmt.Errorf("error updating expense: %w", err)
+	}
+	e.Version = version + 1
 	return nil
 }
 
-// Delete removes an expense from the database by its ID.
-func (r *ExpenseRepository) Delete(ctx context.Context, id string) error {
+// Delete moves an expense to the trash by its ID if it is still at the
+// given version. Like Update, it reports locked expenses as not found.
+func (r *ExpenseRepository) Delete(ctx context.Context, id string, version int) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error deleting expense: %v", err)
+		return fmt.Errorf("error deleting expense: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockExpense(ctx, tx, id, `owner_id = $2 AND workspace_id = $3
+        AND status NOT IN ('approved', 'reimbursed')`, owner, workspace)
+	if err == nil {
+		err = expectVersion(id, current, version)
+	}
+	if err != nil {
+		return err
+	}
+
 	query := `
-        DELETE FROM expenses
-        WHERE id = $1
+        UPDATE expenses
+        SET deleted_at = $1
+        WHERE id = $2
     `
-	_, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
+	if _, err := tx.ExecContext(ctx, query, time.Now().Unix(), id); err != nil {
 		log.Printf("Error deleting expense: %v", err)
 		return fmt.Errorf("error deleting expense: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error deleting expense: %v", err)
+		return fmt.Errorf("error deleting expense: %w", err)
+	}
+	return nil
+}
+
+// GetInWorkspace retrieves an expense of any member of the workspace in
+// ctx by its ID.
+func (r *ExpenseRepository) GetInWorkspace(ctx context.Context, id string) (*entities.Expense, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expenseColumns + `
+        FROM expenses
+        WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+    `
+	row := r.conn().QueryRowContext(ctx, query, id, workspace)
+
+	e, err := scanExpense(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: expense with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving expense: %v", err)
+		return nil, fmt.Errorf("error retrieving expense: %w", err)
+	}
+
+	return e, nil
+}
+
+// ListByStatus retrieves the expenses of every member of the workspace in
+// ctx that are in the given status, oldest first.
+func (r *ExpenseRepository) ListByStatus(ctx context.Context, status entities.ExpenseStatus) ([]*entities.Expense, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expenseColumns + `
+        FROM expenses
+        WHERE workspace_id = $1 AND status = $2 AND deleted_at IS NULL
+        ORDER BY date_creation, id
+    `
+	rows, err := r.conn().QueryContext(ctx, query, workspace, status)
+	if err != nil {
+		log.Printf("Error listing expenses: %v", err)
+		return nil, fmt.Errorf("error listing expenses: %w", err)
+	}
+	defer rows.Close()
+
+	expenses := []*entities.Expense{}
+	for rows.Next() {
+		e, err := scanExpense(rows)
+		if err != nil {
+			log.Printf("Error scanning expense: %v", err)
+			return nil, fmt.Errorf("error scanning expense: %w", err)
+		}
+		expenses = append(expenses, e)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing expenses: %v", err)
+		return nil, fmt.Errorf("error listing expenses: %w", err)
+	}
+
+	return expenses, nil
+}
+
+// SetStatus moves an expense of the workspace in ctx from one status to
+// another on behalf of the user in ctx, and returns the version it moved
+// the expense to. It reports the expense as not found unless it is still
+// in from, so concurrent transitions cannot both succeed.
+func (r *ExpenseRepository) SetStatus(ctx context.Context, id string, from, to entities.ExpenseStatus, reason string) (int, error) {
+	user, workspace, err := tenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error updating expense status: %v", err)
+		return 0, fmt.Errorf("error updating expense status: %w", err)
+	}
+	defer tx.Rollback()
+
+	version, err := lockExpense(ctx, tx, id, `workspace_id = $2 AND status = $3`, workspace, from)
+	if err == nil {
+		err = saveRevision(ctx, tx, id, user)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+        UPDATE expenses
+        SET status = $1, status_reason = $2, version = version + 1
+        WHERE id = $3
+    `
+	if _, err := tx.ExecContext(ctx, query, to, reason, id); err != nil {
+		log.Printf("Error updating expense status: %v", err)
+		return 0, fmt.Errorf("error updating expense status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error updating expense status: %v", err)
+		return 0, fmt.Errorf("error updating expense status: %w", err)
+	}
+	return version + 1, nil
+}
+
+// Restore takes an expense out of the trash by its ID. Expenses that are
+// not in the trash are reported as not found.
+func (r *ExpenseRepository) Restore(ctx context.Context, id string) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE expenses
+        SET deleted_at = NULL
+        WHERE id = $1 AND owner_id = $2 AND workspace_id = $3 AND deleted_at IS NOT NULL
+    `
+	res, err := r.conn().ExecContext(ctx, query, id, owner, workspace)
+	if err != nil {
+		log.Printf("Error restoring expense: %v", err)
+		return fmt.Errorf("error restoring expense: %w", err)
+	}
+	return expectOneRow(res, id)
+}
+
+// ListDeleted retrieves the expenses in the trash, most recently deleted
+// first.
+func (r *ExpenseRepository) ListDeleted(ctx context.Context) ([]*entities.Expense, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expenseColumns + `
+        FROM expenses
+        WHERE owner_id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+        ORDER BY deleted_at DESC, id
+    `
+	rows, err := r.conn().QueryContext(ctx, query, owner, workspace)
+	if err != nil {
+		log.Printf("Error listing deleted expenses: %v", err)
+		return nil, fmt.Errorf("error listing deleted expenses: %w", err)
+	}
+	defer rows.Close()
+
+	expenses := []*entities.Expense{}
+	for rows.Next() {
+		e, err := scanExpense(rows)
+		if err != nil {
+			log.Printf("Error scanning expense: %v", err)
+			return nil, fmt.Errorf("error scanning expense: %w", err)
+		}
+		expenses = append(expenses, e)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing deleted expenses: %v", err)
+		return nil, fmt.Errorf("error listing deleted expenses: %w", err)
+	}
+
+	return expenses, nil
+}
+
+// Purge removes for good the expenses of every workspace moved to the
+// trash before the given time. It returns how many it removed and the
+// hashes of the attachment blobs left unreferenced, for the caller to
+// delete.
+func (r *ExpenseRepository) Purge(ctx context.Context, before int64) (int64, []string, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error purging expenses: %v", err)
+		return 0, nil, fmt.Errorf("error purging expenses: %w", err)
+	}
+	defer tx.Rollback()
+
+	var hashes pq.StringArray
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(ARRAY_AGG(DISTINCT a.sha256), '{}')
+        FROM attachments a
+        JOIN expenses e ON e.id = a.expense_id
+        WHERE e.deleted_at IS NOT NULL AND e.deleted_at < $1
+    `, before).Scan(&hashes)
+	if err != nil {
+		log.Printf("Error purging expenses: %v", err)
+		return 0, nil, fmt.Errorf("error purging expenses: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+        DELETE FROM expenses
+        WHERE deleted_at IS NOT NULL AND deleted_at < $1
+    `, before)
+	if err != nil {
+		log.Printf("Error purging expenses: %v", err)
+		return 0, nil, fmt.Errorf("error purging expenses: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, nil, fmt.Errorf("error checking affected rows: %w", err)
+	}
+
+	// Attachments of other expenses may share the content.
+	var orphans pq.StringArray
+	if len(hashes) > 0 {
+		err = tx.QueryRowContext(ctx, `
+            SELECT COALESCE(ARRAY_AGG(h), '{}')
+            FROM unnest($1::text[]) AS h
+            WHERE NOT EXISTS (SELECT 1 FROM attachments WHERE sha256 = h)
+        `, hashes).Scan(&orphans)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		log.Printf("Error purging expenses: %v", err)
+		return 0, nil, fmt.Errorf("error purging expenses: %w", err)
+	}
+	return n, orphans, nil
+}
+
+// tenant returns the user ctx acts on behalf of and the workspace it
+// selects, failing like auth.UserID and auth.WorkspaceID.
+func tenant(ctx context.Context) (owner, workspace string, err error) {
+	if owner, err = auth.UserID(ctx); err != nil {
+		return "", "", err
+	}
+	if workspace, err = auth.WorkspaceID(ctx); err != nil {
+		return "", "", err
+	}
+	return owner, workspace, nil
+}
+
+// expectOneRow reports the expense as not found when res affected no row.
+func expectOneRow(res sql.Result, id string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: expense with ID %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+// setTags replaces the tags of an expense of workspace, creating the tags
+// that do not exist in the workspace yet.
+func setTags(ctx context.Context, tx dbtx, workspace, expenseID string, tags []string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM expense_tags WHERE expense_id = $1`, expenseID)
+	if err == nil && len(tags) > 0 {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO tags (workspace_id, name)
+            SELECT $1, unnest($2::text[])
+            ON CONFLICT (workspace_id, name) DO NOTHING
+        `, workspace, pq.Array(tags))
+	}
+	if err == nil && len(tags) > 0 {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO expense_tags (expense_id, tag_id)
+            SELECT $1, id FROM tags WHERE workspace_id = $2 AND name = ANY($3)
+        `, expenseID, workspace, pq.Array(tags))
+	}
+	if err != nil {
+		log.Printf("Error setting expense tags: %v", err)
+		return fmt.Errorf("error setting expense tags: %w", err)
+	}
 	return nil
 }
+
+// filterConditions translates f into SQL conditions over the expenses of
+// owner, or of anyone with f.AllOwners, in workspace and their positional
+// arguments.
+func filterConditions(owner, workspace string, f entities.ExpenseFilter) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	addCondition := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+
+	if !f.AllOwners {
+		addCondition("owner_id = $%d", owner)
+	}
+	addCondition("workspace_id = $%d", workspace)
+	conditions = append(conditions, "deleted_at IS NULL")
+	if f.MinAmount != nil {
+		addCondition("amount >= $%d", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		addCondition("amount <= $%d", *f.MaxAmount)
+	}
+	if f.From != nil {
+		addCondition("date_creation >= $%d", *f.From)
+	}
+	if f.To != nil {
+		addCondition("date_creation <= $%d", *f.To)
+	}
+	if f.Description != "" {
+		addCondition("description ILIKE '%%' || $%d || '%%'", escapeLike(f.Description))
+	}
+	if f.CategoryID != "" {
+		addCondition("category_id = $%d", f.CategoryID)
+	}
+	if f.Currency != "" {
+		addCondition("currency = $%d", f.Currency)
+	}
+	if f.Status != "" {
+		addCondition("status = $%d", f.Status)
+	}
+	if len(f.Tags) > 0 {
+		tagged := `(
+            SELECT COUNT(DISTINCT t.name)
+            FROM expense_tags et
+            JOIN tags t ON t.id = et.tag_id
+            WHERE et.expense_id = expenses.id AND t.name = ANY($%d)
+        )`
+		if f.TagMatch == entities.TagMatchAll {
+			addCondition(tagged+fmt.Sprintf(" = %d", len(f.Tags)), pq.Array(f.Tags))
+		} else {
+			addCondition(tagged+" > 0", pq.Array(f.Tags))
+		}
+	}
+
+	return conditions, args
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanExpense reads an expense selected with expenseColumns.
+func scanExpense(row rowScanner) (*entities.Expense, error) {
+	var e entities.Expense
+	var tags pq.StringArray
+	if err := row.Scan(&e.ID, &e.Description, &e.Amount, &e.Currency, &e.DateCreation, &e.CategoryID, &tags, &e.OwnerID, &e.WorkspaceID, &e.Status, &e.StatusReason, &e.DeletedAt, &e.Version, &e.ExternalRef); err != nil {
+		return nil, err
+	}
+	e.Tags = tags
+	return &e, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s so it is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/lib/pq"
+)
+
+// ListRevisions retrieves the prior versions of an expense of the user in
+// ctx, oldest first.
+func (r *ExpenseRepository) ListRevisions(ctx context.Context, expenseID string) ([]*entities.ExpenseRevision, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT r.expense_id, r.revision, r.description, r.amount, r.currency, COALESCE(r.category_id, ''),
+            r.tags, r.status, r.status_reason, r.replaced_by, r.replaced_at
+        FROM expense_revisions r
+        JOIN expenses e ON e.id = r.expense_id
+        WHERE r.expense_id = $1 AND e.owner_id = $2 AND e.workspace_id = $3 AND e.deleted_at IS NULL
+        ORDER BY r.revision
+    `
+	rows, err := r.conn().QueryContext(ctx, query, expenseID, owner, workspace)
+	if err != nil {
+		log.Printf("Error listing expense revisions: %v", err)
+		return nil, fmt.Errorf("error listing expense revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*entities.ExpenseRevision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			log.Printf("Error scanning expense revision: %v", err)
+			return nil, fmt.Errorf("error scanning expense revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing expense revisions: %v", err)
+		return nil, fmt.Errorf("error listing expense revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision retrieves a prior version of an expense of the user in ctx
+// by its number.
+func (r *ExpenseRepository) GetRevision(ctx context.Context, expenseID string, revision int) (*entities.ExpenseRevision, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT r.expense_id, r.revision, r.description, r.amount, r.currency, COALESCE(r.category_id, ''),
+            r.tags, r.status, r.status_reason, r.replaced_by, r.replaced_at
+        FROM expense_revisions r
+        JOIN expenses e ON e.id = r.expense_id
+        WHERE r.expense_id = $1 AND r.revision = $2
+        AND e.owner_id = $3 AND e.workspace_id = $4 AND e.deleted_at IS NULL
+    `
+	row := r.conn().QueryRowContext(ctx, query, expenseID, revision, owner, workspace)
+
+	rev, err := scanRevision(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: revision %d of expense %s", ErrNotFound, revision, expenseID)
+		}
+		log.Printf("Error retrieving expense revision: %v", err)
+		return nil, fmt.Errorf("error retrieving expense revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// lockExpense locks an expense that is not in the trash until the end of
+// tx and returns its version. cond further restricts the expense, with id
+// as $1 and args from $2. The expense is reported as not found when there
+// is no such expense.
+func lockExpense(ctx context.Context, tx dbtx, id, cond string, args ...interface{}) (int, error) {
+	query := `
+        SELECT version FROM expenses
+        WHERE id = $1 AND deleted_at IS NULL AND ` + cond + `
+        FOR UPDATE
+    `
+	var version int
+	err := tx.QueryRowContext(ctx, query, append([]interface{}{id}, args...)...).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w: expense with ID %s", ErrNotFound, id)
+	}
+	if err != nil {
+		log.Printf("Error locking expense: %v", err)
+		return 0, fmt.Errorf("error locking expense: %w", err)
+	}
+	return version, nil
+}
+
+// expectVersion reports a version conflict unless the version an expense
+// is at is the expected one.
+func expectVersion(id string, version, expected int) error {
+	if version != expected {
+		return fmt.Errorf("%w: expense %s is at version %d, not %d", ErrVersionConflict, id, version, expected)
+	}
+	return nil
+}
+
+// saveRevision keeps the current version of an expense locked by tx as
+// its next revision, replaced by the given user now.
+func saveRevision(ctx context.Context, tx dbtx, id, replacedBy string) error {
+	query := `
+        INSERT INTO expense_revisions (expense_id, revision, description, amount, currency, category_id,
+            tags, status, status_reason, replaced_by, replaced_at)
+        SELECT e.id,
+            (SELECT COALESCE(MAX(revision), 0) + 1 FROM expense_revisions WHERE expense_id = e.id),
+            e.description, e.amount, e.currency, e.category_id,
+            ARRAY(
+                SELECT t.name
+                FROM expense_tags et
+                JOIN tags t ON t.id = et.tag_id
+                WHERE et.expense_id = e.id
+                ORDER BY t.name
+            ),
+            e.status, e.status_reason, $2, $3
+        FROM expenses e
+        WHERE e.id = $1
+    `
+	if _, err := tx.ExecContext(ctx, query, id, replacedBy, time.Now().Unix()); err != nil {
+		log.Printf("Error saving expense revision: %v", err)
+		return fmt.Errorf("error saving expense revision: %w", err)
+	}
+	return nil
+}
+
+// scanRevision reads a revision selected by ListRevisions or GetRevision.
+func scanRevision(row rowScanner) (*entities.ExpenseRevision, error) {
+	var rev entities.ExpenseRevision
+	var tags pq.StringArray
+	if err := row.Scan(&rev.ExpenseID, &rev.Revision, &rev.Description, &rev.Amount, &rev.Currency, &rev.CategoryID,
+		&tags, &rev.Status, &rev.StatusReason, &rev.ReplacedBy, &rev.ReplacedAt); err != nil {
+		return nil, err
+	}
+	rev.Tags = tags
+	return &rev, nil
+}
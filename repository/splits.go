@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+)
+
+// SplitRepositoryInterface persists how expenses are split and the
+// settlements between members. The splits of an expense are only read and
+// written by its owner; balances and settlements cover the whole workspace
+// selected in ctx.
+type SplitRepositoryInterface interface {
+	SetSplits(ctx context.Context, expenseID string, splits []entities.ExpenseSplit) error
+	ListSplits(ctx context.Context, expenseID string) ([]entities.ExpenseSplit, error)
+	Balances(ctx context.Context) ([]entities.Balance, error)
+	CreateSettlement(ctx context.Context, s *entities.Settlement) error
+	ListSettlements(ctx context.Context) ([]*entities.Settlement, error)
+}
+
+type SplitRepository struct {
+	db *sql.DB
+}
+
+// NewSplitRepository creates a new instance of SplitRepository.
+func NewSplitRepository(db *sql.DB) SplitRepositoryInterface {
+	return &SplitRepository{db: db}
+}
+
+// SetSplits replaces the splits of an expense of the user in ctx. An empty
+// list removes them. It fails with ErrSplitMismatch when the splits do not
+// add up to the amount of the expense.
+func (r *SplitRepository) SetSplits(ctx context.Context, expenseID string, splits []entities.ExpenseSplit) error {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error setting expense splits: %v", err)
+		return fmt.Errorf("error setting expense splits: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the expense serializes concurrent changes to its splits and
+	// to its amount, which may have changed since the splits were computed.
+	var amount entities.Money
+	err = tx.QueryRowContext(ctx, `
+        SELECT amount FROM expenses
+        WHERE id = $1 AND owner_id = $2 AND workspace_id = $3 AND deleted_at IS NULL
+        FOR UPDATE
+    `, expenseID, owner, workspace).Scan(&amount)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: expense with ID %s", ErrNotFound, expenseID)
+	}
+	if err == nil && len(splits) > 0 {
+		var total entities.Money
+		for _, s := range splits {
+			total += s.Amount
+		}
+		if total != amount {
+			return fmt.Errorf("%w: expense %s is for %s, not %s", ErrSplitMismatch, expenseID, amount, total)
+		}
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM expense_splits WHERE expense_id = $1`, expenseID)
+	}
+	for _, s := range splits {
+		if err != nil {
+			break
+		}
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO expense_splits (expense_id, user_id, amount)
+            VALUES ($1, $2, $3)
+        `, expenseID, s.UserID, s.Amount)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		log.Printf("Error setting expense splits: %v", err)
+		return fmt.Errorf("error setting expense splits: %w", err)
+	}
+	return nil
+}
+
+// ListSplits retrieves the splits of an expense of the user in ctx,
+// ordered by user.
+func (r *SplitRepository) ListSplits(ctx context.Context, expenseID string) ([]entities.ExpenseSplit, error) {
+	owner, workspace, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT s.expense_id, s.user_id, s.amount
+        FROM expense_splits s
+        JOIN expenses e ON e.id = s.expense_id
+        WHERE s.expense_id = $1 AND e.owner_id = $2 AND e.workspace_id = $3 AND e.deleted_at IS NULL
+        ORDER BY s.user_id
+    `
+	rows, err := r.db.QueryContext(ctx, query, expenseID, owner, workspace)
+	if err != nil {
+		log.Printf("Error listing expense splits: %v", err)
+		return nil, fmt.Errorf("error listing expense splits: %w", err)
+	}
+	defer rows.Close()
+
+	splits := []entities.ExpenseSplit{}
+	for rows.Next() {
+		var s entities.ExpenseSplit
+		if err := rows.Scan(&s.ExpenseID, &s.UserID, &s.Amount); err != nil {
+			log.Printf("Error scanning expense split: %v", err)
+			return nil, fmt.Errorf("error scanning expense split: %w", err)
+		}
+		splits = append(splits, s)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing expense splits: %v", err)
+		return nil, fmt.Errorf("error listing expense splits: %w", err)
+	}
+
+	return splits, nil
+}
+
+// Balances computes the non-zero balances of the members of the workspace
+// in ctx per currency. The owner of a split expense is owed every part of
+// it, each participant owes its own part, and settlements move money from
+// their payer to their payee. Expenses in the trash do not count.
+func (r *SplitRepository) Balances(ctx context.Context) ([]entities.Balance, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT user_id, currency, SUM(amount)
+        FROM (
+            SELECT e.owner_id AS user_id, e.currency, s.amount
+            FROM expense_splits s
+            JOIN expenses e ON e.id = s.expense_id
+            WHERE e.workspace_id = $1 AND e.deleted_at IS NULL
+            UNION ALL
+            SELECT s.user_id, e.currency, -s.amount
+            FROM expense_splits s
+            JOIN expenses e ON e.id = s.expense_id
+            WHERE e.workspace_id = $1 AND e.deleted_at IS NULL
+            UNION ALL
+            SELECT from_user_id, currency, amount
+            FROM settlements
+            WHERE workspace_id = $1
+            UNION ALL
+            SELECT to_user_id, currency, -amount
+            FROM settlements
+            WHERE workspace_id = $1
+        ) movements
+        GROUP BY user_id, currency
+        HAVING SUM(amount) <> 0
+        ORDER BY currency, user_id
+    `
+	rows, err := r.db.QueryContext(ctx, query, workspace)
+	if err != nil {
+		log.Printf("Error computing balances: %v", err)
+		return nil, fmt.Errorf("error computing balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := []entities.Balance{}
+	for rows.Next() {
+		var b entities.Balance
+		if err := rows.Scan(&b.UserID, &b.Currency, &b.Amount); err != nil {
+			log.Printf("Error scanning balance: %v", err)
+			return nil, fmt.Errorf("error scanning balance: %w", err)
+		}
+		balances = append(balances, b)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error computing balances: %v", err)
+		return nil, fmt.Errorf("error computing balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// CreateSettlement saves a new settlement in the workspace of ctx.
+func (r *SplitRepository) CreateSettlement(ctx context.Context, s *entities.Settlement) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO settlements (id, workspace_id, from_user_id, to_user_id, amount, currency, date_creation)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err = r.db.ExecContext(ctx, query, s.ID, workspace, s.FromUserID, s.ToUserID, s.Amount, s.Currency, s.DateCreation)
+	if err != nil {
+		log.Printf("Error creating settlement: %v", err)
+		return fmt.Errorf("error creating settlement: %w", err)
+	}
+	return nil
+}
+
+// ListSettlements retrieves the settlements of the workspace in ctx,
+// oldest first.
+func (r *SplitRepository) ListSettlements(ctx context.Context) ([]*entities.Settlement, error) {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT id, from_user_id, to_user_id, amount, currency, date_creation
+        FROM settlements
+        WHERE workspace_id = $1
+        ORDER BY date_creation, id
+    `
+	rows, err := r.db.QueryContext(ctx, query, workspace)
+	if err != nil {
+		log.Printf("Error listing settlements: %v", err)
+		return nil, fmt.Errorf("error listing settlements: %w", err)
+	}
+	defer rows.Close()
+
+	settlements := []*entities.Settlement{}
+	for rows.Next() {
+		var s entities.Settlement
+		if err := rows.Scan(&s.ID, &s.FromUserID, &s.ToUserID, &s.Amount, &s.Currency, &s.DateCreation); err != nil {
+			log.Printf("Error scanning settlement: %v", err)
+			return nil, fmt.Errorf("error scanning settlement: %w", err)
+		}
+		settlements = append(settlements, &s)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing settlements: %v", err)
+		return nil, fmt.Errorf("error listing settlements: %w", err)
+	}
+
+	return settlements, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// dbtx runs statements on the database or within a transaction.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// UnitOfWork groups changes to expenses, and the audit events recording
+// them, or to users and their workspaces, in a single database
+// transaction: none of them is visible to others before Commit, and
+// Rollback undoes all of them.
+type UnitOfWork interface {
+	Expenses() ExpenseRepositoryInterface
+	Audit() AuditRepositoryInterface
+	Users() UserRepositoryInterface
+	Workspaces() WorkspaceRepositoryInterface
+	Try(ctx context.Context, fn func() error) error
+	Commit() error
+	Rollback() error
+}
+
+// UnitOfWorkFactory starts units of work.
+type UnitOfWorkFactory interface {
+	Begin(ctx context.Context) (UnitOfWork, error)
+}
+
+type unitOfWorkFactory struct {
+	db *sql.DB
+}
+
+// NewUnitOfWorkFactory creates a new instance of UnitOfWorkFactory.
+func NewUnitOfWorkFactory(db *sql.DB) UnitOfWorkFactory {
+	return &unitOfWorkFactory{db: db}
+}
+
+// Begin starts a unit of work in a new transaction.
+func (f *unitOfWorkFactory) Begin(ctx context.Context) (UnitOfWork, error) {
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Error beginning unit of work: %v", err)
+		return nil, fmt.Errorf("error beginning unit of work: %w", err)
+	}
+	return &unitOfWork{
+		tx:         tx,
+		expenses:   &ExpenseRepository{db: f.db, tx: tx},
+		audit:      &AuditRepository{db: f.db, tx: tx},
+		users:      &UserRepository{db: f.db, tx: tx},
+		workspaces: &WorkspaceRepository{db: f.db, tx: tx},
+	}, nil
+}
+
+type unitOfWork struct {
+	tx         *sql.Tx
+	expenses   *ExpenseRepository
+	audit      *AuditRepository
+	users      *UserRepository
+	workspaces *WorkspaceRepository
+}
+
+// Expenses returns the expense repository running in the unit of work.
+func (u *unitOfWork) Expenses() ExpenseRepositoryInterface {
+	return u.expenses
+}
+
+// Audit returns the audit repository running in the unit of work.
+func (u *unitOfWork) Audit() AuditRepositoryInterface {
+	return u.audit
+}
+
+// Users returns the user repository running in the unit of work.
+func (u *unitOfWork) Users() UserRepositoryInterface {
+	return u.users
+}
+
+// Workspaces returns the workspace repository running in the unit of
+// work.
+func (u *unitOfWork) Workspaces() WorkspaceRepositoryInterface {
+	return u.workspaces
+}
+
+// Try runs fn in a savepoint, so that when fn fails only its own changes
+// are undone and the unit of work can go on.
+func (u *unitOfWork) Try(ctx context.Context, fn func() error) error {
+	if _, err := u.tx.ExecContext(ctx, `SAVEPOINT unit_of_work_try`); err != nil {
+		log.Printf("Error creating savepoint: %v", err)
+		return fmt.Errorf("error creating savepoint: %w", err)
+	}
+	if err := fn(); err != nil {
+		if _, rerr := u.tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT unit_of_work_try`); rerr != nil {
+			log.Printf("Error rolling back to savepoint: %v", rerr)
+			return fmt.Errorf("error rolling back to savepoint: %w", rerr)
+		}
+		return err
+	}
+	if _, err := u.tx.ExecContext(ctx, `RELEASE SAVEPOINT unit_of_work_try`); err != nil {
+		log.Printf("Error releasing savepoint: %v", err)
+		return fmt.Errorf("error releasing savepoint: %w", err)
+	}
+	return nil
+}
+
+// Commit makes the changes of the unit of work visible.
+func (u *unitOfWork) Commit() error {
+	if err := u.tx.Commit(); err != nil {
+		log.Printf("Error committing unit of work: %v", err)
+		return fmt.Errorf("error committing unit of work: %w", err)
+	}
+	return nil
+}
+
+// Rollback undoes the changes of the unit of work. It does nothing once
+// the unit of work is committed.
+func (u *unitOfWork) Rollback() error {
+	if err := u.tx.Rollback(); err != nil && err != sql.ErrTxDone {
+		return fmt.Errorf("error rolling back unit of work: %w", err)
+	}
+	return nil
+}
+
+// txn is the transaction a single change of ExpenseRepository runs in.
+// When the repository belongs to a unit of work the change joins its
+// transaction, and leaves committing or rolling it back to the unit of
+// work.
+type txn struct {
+	*sql.Tx
+	joined bool
+}
+
+func (t txn) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t txn) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// conn returns what the repository runs its statements on.
+func (r *ExpenseRepository) conn() dbtx {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// begin starts the transaction of a single change.
+func (r *ExpenseRepository) begin(ctx context.Context) (txn, error) {
+	if r.tx != nil {
+		return txn{Tx: r.tx, joined: true}, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	return txn{Tx: tx}, err
+}
+
+// conn returns what the repository runs its statements on.
+func (r *AttachmentRepository) conn() dbtx {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// conn returns what the repository runs its statements on.
+func (r *UserRepository) conn() dbtx {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// conn returns what the repository runs its statements on.
+func (r *WorkspaceRepository) conn() dbtx {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// begin starts the transaction of a single change.
+func (r *WorkspaceRepository) begin(ctx context.Context) (txn, error) {
+	if r.tx != nil {
+		return txn{Tx: r.tx, joined: true}, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	return txn{Tx: tx}, err
+}
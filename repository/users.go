@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/entities"
+)
+
+// ErrUserExists is returned when creating a user with the ID or the email
+// of another user.
+var ErrUserExists = errors.New("user already exists")
+
+type UserRepositoryInterface interface {
+	Create(ctx context.Context, u *entities.User) error
+	GetByID(ctx context.Context, id string) (*entities.User, error)
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	SetRole(ctx context.Context, id, role string) error
+}
+
+type UserRepository struct {
+	db *sql.DB
+	// tx is set when the repository belongs to a unit of work.
+	tx *sql.Tx
+}
+
+// NewUserRepository creates a new instance of UserRepository.
+func NewUserRepository(db *sql.DB) UserRepositoryInterface {
+	return &UserRepository{db: db}
+}
+
+// Create saves a new user in the database. It fails with ErrUserExists
+// when the ID or the email is taken.
+func (r *UserRepository) Create(ctx context.Context, u *entities.User) error {
+	query := `
+        INSERT INTO users (id, email, name, role, date_creation)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.conn().ExecContext(ctx, query, u.ID, u.Email, u.Name, u.Role, u.DateCreation)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%w: %s", ErrUserExists, u.ID)
+	}
+	if err != nil {
+		log.Printf("Error creating user: %v", err)
+		return fmt.Errorf("error creating user: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a user from the database by its ID.
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	query := `
+        SELECT id, email, name, role, date_creation
+        FROM users
+        WHERE id = $1
+    `
+	return r.get(ctx, query, id)
+}
+
+// GetByEmail retrieves a user from the database by its email.
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	query := `
+        SELECT id, email, name, role, date_creation
+        FROM users
+        WHERE email = $1
+    `
+	return r.get(ctx, query, email)
+}
+
+// SetRole changes the instance role of a user.
+func (r *UserRepository) SetRole(ctx context.Context, id, role string) error {
+	query := `
+        UPDATE users
+        SET role = $1
+        WHERE id = $2
+    `
+	_, err := r.conn().ExecContext(ctx, query, role, id)
+	if err != nil {
+		log.Printf("Error setting user role: %v", err)
+		return fmt.Errorf("error setting user role: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) get(ctx context.Context, query, key string) (*entities.User, error) {
+	var u entities.User
+	err := r.conn().QueryRowContext(ctx, query, key).Scan(&u.ID, &u.Email, &u.Name, &u.Role, &u.DateCreation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: user %s", ErrNotFound, key)
+		}
+		log.Printf("Error retrieving user: %v", err)
+		return nil, fmt.Errorf("error retrieving user: %w", err)
+	}
+	return &u, nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/demo-talent/entities"
+)
+
+// WorkspaceRepositoryInterface persists workspaces and their members.
+// Unlike the domain repositories, it is not scoped by ctx: the workspaces
+// of a user are looked up before one is selected.
+type WorkspaceRepositoryInterface interface {
+	Create(ctx context.Context, w *entities.Workspace) error
+	GetForMember(ctx context.Context, id, userID string) (*entities.Workspace, error)
+	ListForMember(ctx context.Context, userID string) ([]*entities.Workspace, error)
+	AddMember(ctx context.Context, workspaceID, userID string, joinedAt int64) error
+	ListMembers(ctx context.Context, workspaceID string) ([]*entities.WorkspaceMember, error)
+	SetMemberRole(ctx context.Context, workspaceID, userID, role string) error
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+}
+
+type WorkspaceRepository struct {
+	db *sql.DB
+	// tx is set when the repository belongs to a unit of work.
+	tx *sql.Tx
+}
+
+// NewWorkspaceRepository creates a new instance of WorkspaceRepository.
+func NewWorkspaceRepository(db *sql.DB) WorkspaceRepositoryInterface {
+	return &WorkspaceRepository{db: db}
+}
+
+// Create saves a new workspace in the database with its owner as its first
+// member, an admin.
+func (r *WorkspaceRepository) Create(ctx context.Context, w *entities.Workspace) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		log.Printf("Error creating workspace: %v", err)
+		return fmt.Errorf("error creating workspace: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO workspaces (id, name, owner_id, date_creation)
+        VALUES ($1, $2, $3, $4)
+    `, w.ID, w.Name, w.OwnerID, w.DateCreation)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO workspace_members (workspace_id, user_id, role, date_joined)
+            VALUES ($1, $2, 'admin', $3)
+        `, w.ID, w.OwnerID, w.DateCreation)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		log.Printf("Error creating workspace: %v", err)
+		return fmt.Errorf("error creating workspace: %w", err)
+	}
+	return nil
+}
+
+// GetForMember retrieves a workspace by its ID, with the role of userID in
+// it, if userID is one of its members.
+func (r *WorkspaceRepository) GetForMember(ctx context.Context, id, userID string) (*entities.Workspace, error) {
+	query := `
+        SELECT w.id, w.name, w.owner_id, w.date_creation, m.role
+        FROM workspaces w
+        JOIN workspace_members m ON m.workspace_id = w.id
+        WHERE w.id = $1 AND m.user_id = $2
+    `
+	var w entities.Workspace
+	err := r.conn().QueryRowContext(ctx, query, id, userID).Scan(&w.ID, &w.Name, &w.OwnerID, &w.DateCreation, &w.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: workspace with ID %s", ErrNotFound, id)
+		}
+		log.Printf("Error retrieving workspace: %v", err)
+		return nil, fmt.Errorf("error retrieving workspace: %w", err)
+	}
+	return &w, nil
+}
+
+// ListForMember retrieves the workspaces userID is a member of, with its
+// role in each, in the order they were joined.
+func (r *WorkspaceRepository) ListForMember(ctx context.Context, userID string) ([]*entities.Workspace, error) {
+	query := `
+        SELECT w.id, w.name, w.owner_id, w.date_creation, m.role
+        FROM workspaces w
+        JOIN workspace_members m ON m.workspace_id = w.id
+        WHERE m.user_id = $1
+        ORDER BY m.date_joined, w.id
+    `
+	rows, err := r.conn().QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Printf("Error listing workspaces: %v", err)
+		return nil, fmt.Errorf("error listing workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := []*entities.Workspace{}
+	for rows.Next() {
+		var w entities.Workspace
+		if err := rows.Scan(&w.ID, &w.Name, &w.OwnerID, &w.DateCreation, &w.Role); err != nil {
+			log.Printf("Error scanning workspace: %v", err)
+			return nil, fmt.Errorf("error scanning workspace: %w", err)
+		}
+		workspaces = append(workspaces, &w)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing workspaces: %v", err)
+		return nil, fmt.Errorf("error listing workspaces: %w", err)
+	}
+
+	return workspaces, nil
+}
+
+// AddMember adds userID to a workspace as a member. Adding a member twice
+// does nothing.
+func (r *WorkspaceRepository) AddMember(ctx context.Context, workspaceID, userID string, joinedAt int64) error {
+	query := `
+        INSERT INTO workspace_members (workspace_id, user_id, date_joined)
+        VALUES ($1, $2, $3)
+        ON CONFLICT DO NOTHING
+    `
+	_, err := r.conn().ExecContext(ctx, query, workspaceID, userID, joinedAt)
+	if err != nil {
+		log.Printf("Error adding workspace member: %v", err)
+		return fmt.Errorf("error adding workspace member: %w", err)
+	}
+	return nil
+}
+
+// ListMembers retrieves the members of a workspace, in the order they
+// joined.
+func (r *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID string) ([]*entities.WorkspaceMember, error) {
+	query := `
+        SELECT m.workspace_id, u.id, u.email, u.name, m.role, m.date_joined
+        FROM workspace_members m
+        JOIN users u ON u.id = m.user_id
+        WHERE m.workspace_id = $1
+        ORDER BY m.date_joined, u.id
+    `
+	rows, err := r.conn().QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		log.Printf("Error listing workspace members: %v", err)
+		return nil, fmt.Errorf("error listing workspace members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*entities.WorkspaceMember{}
+	for rows.Next() {
+		var m entities.WorkspaceMember
+		if err := rows.Scan(&m.WorkspaceID, &m.UserID, &m.Email, &m.Name, &m.Role, &m.DateJoined); err != nil {
+			log.Printf("Error scanning workspace member: %v", err)
+			return nil, fmt.Errorf("error scanning workspace member: %w", err)
+		}
+		members = append(members, &m)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error listing workspace members: %v", err)
+		return nil, fmt.Errorf("error listing workspace members: %w", err)
+	}
+
+	return members, nil
+}
+
+// SetMemberRole changes the role of userID in a workspace.
+func (r *WorkspaceRepository) SetMemberRole(ctx context.Context, workspaceID, userID, role string) error {
+	query := `
+        UPDATE workspace_members SET role = $3
+        WHERE workspace_id = $1 AND user_id = $2
+    `
+	res, err := r.conn().ExecContext(ctx, query, workspaceID, userID, role)
+	if err != nil {
+		log.Printf("Error setting workspace member role: %v", err)
+		return fmt.Errorf("error setting workspace member role: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: member %s of workspace %s", ErrNotFound, userID, workspaceID)
+	}
+	return nil
+}
+
+// RemoveMember removes userID from a workspace. The data it created there
+// is kept.
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	query := `
+        DELETE FROM workspace_members
+        WHERE workspace_id = $1 AND user_id = $2
+    `
+	res, err := r.conn().ExecContext(ctx, query, workspaceID, userID)
+	if err != nil {
+		log.Printf("Error removing workspace member: %v", err)
+		return fmt.Errorf("error removing workspace member: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: member %s of workspace %s", ErrNotFound, userID, workspaceID)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+var (
+	// ErrInvalidAPIKey is returned when an API key fails validation.
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrUnknownAPIKey is returned when a presented API key does not
+	// exist, was revoked or has expired.
+	ErrUnknownAPIKey = errors.New("unknown, revoked or expired API key")
+)
+
+// apiKeyPrefix starts every issued key, so leaked keys are easy to scan for.
+const apiKeyPrefix = "dtk_"
+
+// apiKeyDisplayLength is how much of a key is kept to tell keys apart.
+const apiKeyDisplayLength = 12
+
+// maxRotationGrace bounds how long a rotated key keeps working.
+const maxRotationGrace = 7 * 24 * time.Hour
+
+// APIKeyService defines the interface for API key issuance and
+// authentication.
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, k *entities.APIKey) error
+	ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	RotateAPIKey(ctx context.Context, id string, grace time.Duration) (*entities.APIKey, error)
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+type apiKeyServiceImpl struct {
+	repo repository.APIKeyRepositoryInterface
+}
+
+// NewAPIKeyService creates a new instance of APIKeyService.
+func NewAPIKeyService(repo repository.APIKeyRepositoryInterface) APIKeyService {
+	return &apiKeyServiceImpl{repo: repo}
+}
+
+// CreateAPIKey issues a new API key for the user in ctx and sets k.Key,
+// the only time the key is available.
+func (s *apiKeyServiceImpl) CreateAPIKey(ctx context.Context, k *entities.APIKey) error {
+	k.Name = strings.TrimSpace(k.Name)
+	if k.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	}
+	scopes, err := normalizeScopes(k.Scopes)
+	if err != nil {
+		return err
+	}
+	k.Scopes = scopes
+	now := time.Now()
+	if k.ExpiresAt != nil && *k.ExpiresAt <= now.Unix() {
+		return fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAPIKey)
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return err
+	}
+	k.ID = generateID("apikey")
+	k.Key = key
+	k.Prefix = key[:apiKeyDisplayLength]
+	k.DateCreation = now.Unix()
+	k.RevokedAt = nil
+	k.LastUsedAt = nil
+
+	return s.repo.Create(ctx, k, hashAPIKey(key))
+}
+
+// ListAPIKeys retrieves the API keys of the user in ctx.
+func (s *apiKeyServiceImpl) ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	return s.repo.List(ctx)
+}
+
+// RevokeAPIKey makes an API key of the user in ctx stop working now.
+func (s *apiKeyServiceImpl) RevokeAPIKey(ctx context.Context, id string) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Revoke(ctx, id, time.Now().Unix())
+}
+
+// RotateAPIKey issues a replacement for an API key, with the same name,
+// scopes and expiry, and revokes the old key once grace has elapsed so
+// clients can switch over.
+func (s *apiKeyServiceImpl) RotateAPIKey(ctx context.Context, id string, grace time.Duration) (*entities.APIKey, error) {
+	if grace < 0 || grace > maxRotationGrace {
+		return nil, fmt.Errorf("%w: grace must be between 0 and %s", ErrInvalidAPIKey, maxRotationGrace)
+	}
+	old, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if old.RevokedAt != nil && *old.RevokedAt <= now.Unix() {
+		return nil, fmt.Errorf("%w: the key was revoked", ErrInvalidAPIKey)
+	}
+
+	replacement := &entities.APIKey{Name: old.Name, Scopes: old.Scopes}
+	if old.ExpiresAt != nil && *old.ExpiresAt > now.Unix() {
+		replacement.ExpiresAt = old.ExpiresAt
+	}
+	if err := s.CreateAPIKey(ctx, replacement); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Revoke(ctx, id, now.Add(grace).Unix()); err != nil {
+		return nil, err
+	}
+	return replacement, nil
+}
+
+// Authenticate returns the principal of a presented API key, limited to
+// its scopes.
+func (s *apiKeyServiceImpl) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return auth.Principal{}, ErrUnknownAPIKey
+	}
+	k, err := s.repo.GetByHash(ctx, hashAPIKey(key))
+	if errors.Is(err, repository.ErrNotFound) {
+		return auth.Principal{}, ErrUnknownAPIKey
+	}
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	now := time.Now().Unix()
+	if (k.RevokedAt != nil && *k.RevokedAt <= now) || (k.ExpiresAt != nil && *k.ExpiresAt <= now) {
+		return auth.Principal{}, ErrUnknownAPIKey
+	}
+	if err := s.repo.TouchLastUsed(ctx, k.ID, now); err != nil {
+		log.Printf("Error recording use of API key %s: %v", k.ID, err)
+	}
+
+	return auth.Principal{UserID: k.OwnerID, APIKeyID: k.ID, Scopes: k.Scopes}, nil
+}
+
+// normalizeScopes checks that every scope is known and removes duplicates.
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKey)
+	}
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, scope := range scopes {
+		if !isKnownScope(scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q, want one of %s", ErrInvalidAPIKey, scope, strings.Join(auth.Scopes, ", "))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range auth.Scopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
+
+// generateAPIKey returns a new key with 256 random bits.
+func generateAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating API key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIKey is what is stored for a key. Keys are random enough that a
+// plain SHA-256 cannot be brute-forced, and it can be looked up directly.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
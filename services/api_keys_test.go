@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_apiKeyServiceImpl_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepositoryInterface(ctrl)
+
+	past := time.Now().Add(-time.Hour).Unix()
+	tests := []struct {
+		name       string
+		key        *entities.APIKey
+		wantScopes []string
+		wantErr    error
+		setupMock  func()
+	}{
+		{
+			name:       "CreateAPIKey_Success",
+			key:        &entities.APIKey{Name: "CI", Scopes: []string{"expenses:write", "expenses:read", "expenses:write"}},
+			wantScopes: []string{"expenses:read", "expenses:write"},
+			setupMock: func() {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *entities.APIKey, keyHash string) error {
+					if keyHash != hashAPIKey(k.Key) || strings.Contains(keyHash, k.Key) {
+						t.Errorf("stored hash %q does not hash the key", keyHash)
+					}
+					if !strings.HasPrefix(k.Key, apiKeyPrefix) || !strings.HasPrefix(k.Key, k.Prefix) {
+						t.Errorf("issued key %q with prefix %q", k.Key, k.Prefix)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name:      "CreateAPIKey_UnknownScope",
+			key:       &entities.APIKey{Name: "CI", Scopes: []string{"expenses:delete"}},
+			wantErr:   ErrInvalidAPIKey,
+			setupMock: func() {},
+		},
+		{
+			name:      "CreateAPIKey_NoScopes",
+			key:       &entities.APIKey{Name: "CI"},
+			wantErr:   ErrInvalidAPIKey,
+			setupMock: func() {},
+		},
+		{
+			name:      "CreateAPIKey_Expired",
+			key:       &entities.APIKey{Name: "CI", Scopes: []string{"admin"}, ExpiresAt: &past},
+			wantErr:   ErrInvalidAPIKey,
+			setupMock: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &apiKeyServiceImpl{repo: mockRepo}
+			err := s.CreateAPIKey(context.TODO(), tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("apiKeyServiceImpl.CreateAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(tt.key.Scopes, tt.wantScopes) {
+				t.Errorf("apiKeyServiceImpl.CreateAPIKey() scopes = %v, want %v", tt.key.Scopes, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func Test_apiKeyServiceImpl_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepositoryInterface(ctrl)
+
+	key := apiKeyPrefix + "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3I"
+	past, future := time.Now().Add(-time.Minute).Unix(), time.Now().Add(time.Hour).Unix()
+	stored := func(revokedAt, expiresAt *int64) *entities.APIKey {
+		return &entities.APIKey{ID: "apikey_1", OwnerID: "user_1", Scopes: []string{"expenses:read"}, RevokedAt: revokedAt, ExpiresAt: expiresAt}
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		want      auth.Principal
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name: "Authenticate_Success",
+			key:  key,
+			want: auth.Principal{UserID: "user_1", APIKeyID: "apikey_1", Scopes: []string{"expenses:read"}},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Return(stored(nil, nil), nil)
+				mockRepo.EXPECT().TouchLastUsed(gomock.Any(), "apikey_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Authenticate_InRotationGrace",
+			key:  key,
+			want: auth.Principal{UserID: "user_1", APIKeyID: "apikey_1", Scopes: []string{"expenses:read"}},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Return(stored(&future, nil), nil)
+				mockRepo.EXPECT().TouchLastUsed(gomock.Any(), "apikey_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "Authenticate_Revoked",
+			key:     key,
+			wantErr: ErrUnknownAPIKey,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Return(stored(&past, nil), nil)
+			},
+		},
+		{
+			name:    "Authenticate_Expired",
+			key:     key,
+			wantErr: ErrUnknownAPIKey,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Return(stored(nil, &past), nil)
+			},
+		},
+		{
+			name:    "Authenticate_Unknown",
+			key:     key,
+			wantErr: ErrUnknownAPIKey,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Return(nil, repository.ErrNotFound)
+			},
+		},
+		{
+			name:      "Authenticate_NotAnAPIKey",
+			key:       "eyJhbGciOiJIUzI1NiJ9",
+			wantErr:   ErrUnknownAPIKey,
+			setupMock: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &apiKeyServiceImpl{repo: mockRepo}
+			got, err := s.Authenticate(context.TODO(), tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("apiKeyServiceImpl.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apiKeyServiceImpl.Authenticate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiKeyServiceImpl_RotateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepositoryInterface(ctrl)
+
+	old := &entities.APIKey{ID: "apikey_1", Name: "CI", Scopes: []string{"expenses:write"}}
+	mockRepo.EXPECT().GetByID(gomock.Any(), "apikey_1").Return(old, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().Revoke(gomock.Any(), "apikey_1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, at int64) error {
+		if at < time.Now().Add(59*time.Minute).Unix() {
+			t.Errorf("old key revoked at %d, want after the one hour grace", at)
+		}
+		return nil
+	})
+
+	s := &apiKeyServiceImpl{repo: mockRepo}
+	got, err := s.RotateAPIKey(context.TODO(), "apikey_1", time.Hour)
+	if err != nil {
+		t.Fatalf("apiKeyServiceImpl.RotateAPIKey() error = %v", err)
+	}
+	if got.ID == old.ID || got.Key == "" || got.Name != "CI" || !reflect.DeepEqual(got.Scopes, old.Scopes) {
+		t.Errorf("apiKeyServiceImpl.RotateAPIKey() = %+v, want a new key like %+v", got, old)
+	}
+}
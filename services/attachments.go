@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/storage"
+)
+
+var (
+	// ErrInvalidAttachment is returned when an upload cannot be read.
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	// ErrAttachmentTooLarge is returned when an upload exceeds
+	// MaxAttachmentSize.
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	// ErrUnsupportedAttachment is returned when an upload is neither an
+	// image nor a PDF.
+	ErrUnsupportedAttachment = errors.New("unsupported attachment type")
+)
+
+// MaxAttachmentSize is the largest attachment accepted, in bytes.
+const MaxAttachmentSize = 10 << 20
+
+// maxFileNameLength matches the file_name column.
+const maxFileNameLength = 255
+
+// attachmentTypes are the sniffed content types accepted for receipts.
+var attachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/gif":       true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+}
+
+// AttachmentService defines the interface for expense attachments.
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, expenseID, fileName string, r io.Reader) (*entities.Attachment, bool, error)
+	ListAttachments(ctx context.Context, expenseID string) ([]*entities.Attachment, error)
+	GetAttachment(ctx context.Context, expenseID, id string) (*entities.Attachment, error)
+	OpenAttachment(ctx context.Context, a *entities.Attachment) (io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, expenseID, id string) error
+}
+
+type attachmentServiceImpl struct {
+	repo     repository.AttachmentRepositoryInterface
+	expenses repository.ExpenseRepositoryInterface
+	blobs    storage.BlobStore
+}
+
+// NewAttachmentService creates a new instance of AttachmentService storing
+// the attachment bytes in blobs.
+func NewAttachmentService(repo repository.AttachmentRepositoryInterface, expenses repository.ExpenseRepositoryInterface, blobs storage.BlobStore) AttachmentService {
+	return &attachmentServiceImpl{repo: repo, expenses: expenses, blobs: blobs}
+}
+
+// UploadAttachment attaches the file read from r to an expense. Its content
+// type is sniffed from the bytes rather than trusted from the client. When
+// the expense already has an identical file, that attachment is returned
+// and the reported bool, whether one was created, is false.
+func (s *attachmentServiceImpl) UploadAttachment(ctx context.Context, expenseID, fileName string, r io.Reader) (*entities.Attachment, bool, error) {
+	if _, err := s.expenses.GetByID(ctx, expenseID); err != nil {
+		return nil, false, err
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(r, MaxAttachmentSize+1)); err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidAttachment, err)
+	}
+	data := buf.Bytes()
+	if len(data) == 0 {
+		return nil, false, fmt.Errorf("%w: file is empty", ErrInvalidAttachment)
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, false, fmt.Errorf("%w: the limit is %d bytes", ErrAttachmentTooLarge, MaxAttachmentSize)
+	}
+	contentType := http.DetectContentType(data)
+	if !attachmentTypes[contentType] {
+		return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedAttachment, contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	existing, err := s.repo.GetBySHA256(ctx, expenseID, hash)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, false, err
+	}
+
+	a := &entities.Attachment{
+		ID:           generateID("attachment"),
+		ExpenseID:    expenseID,
+		FileName:     sanitizeFileName(fileName),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		SHA256:       hash,
+		DateCreation: time.Now().Unix(),
+	}
+	// A failed Put rolls the row back.
+	err = s.repo.LockContent(ctx, hash, func(repo repository.AttachmentRepositoryInterface) error {
+		if err := repo.Create(ctx, a); err != nil {
+			return err
+		}
+		return s.blobs.Put(ctx, hash, bytes.NewReader(data))
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return a, true, nil
+}
+
+// ListAttachments retrieves the attachments of an expense.
+func (s *attachmentServiceImpl) ListAttachments(ctx context.Context, expenseID string) ([]*entities.Attachment, error) {
+	if _, err := s.expenses.GetByID(ctx, expenseID); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, expenseID)
+}
+
+// GetAttachment retrieves the metadata of an attachment of an expense.
+func (s *attachmentServiceImpl) GetAttachment(ctx context.Context, expenseID, id string) (*entities.Attachment, error) {
+	if _, err := s.expenses.GetByID(ctx, expenseID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, expenseID, id)
+}
+
+// OpenAttachment opens the bytes of an attachment. The caller must close
+// the returned reader.
+func (s *attachmentServiceImpl) OpenAttachment(ctx context.Context, a *entities.Attachment) (io.ReadCloser, error) {
+	return s.blobs.Get(ctx, a.SHA256)
+}
+
+// DeleteAttachment removes an attachment from an expense, and its bytes
+// once no other attachment has the same content. Uploads of the same
+// content wait for it, so they never lose their bytes to it.
+func (s *attachmentServiceImpl) DeleteAttachment(ctx context.Context, expenseID, id string) error {
+	a, err := s.GetAttachment(ctx, expenseID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.LockContent(ctx, a.SHA256, func(repo repository.AttachmentRepositoryInterface) error {
+		if err := repo.Delete(ctx, a.ID); err != nil {
+			return err
+		}
+		remaining, err := repo.CountBySHA256(ctx, a.SHA256)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+		return s.blobs.Delete(ctx, a.SHA256)
+	})
+}
+
+// sanitizeFileName keeps the base name of a client supplied file name,
+// without control characters, so it is safe to echo in headers.
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "receipt"
+	}
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	return name
+}
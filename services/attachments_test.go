@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	storagemocks "github.com/demo-talent/storage/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_attachmentServiceImpl_UploadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockBlobs := storagemocks.NewMockBlobStore(ctrl)
+	// LockContent runs its function with the mock, as if in its
+	// transaction.
+	lockContent := func(hash interface{}) {
+		mockRepo.EXPECT().LockContent(gomock.Any(), hash, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, fn func(repository.AttachmentRepositoryInterface) error) error {
+			return fn(mockRepo)
+		})
+	}
+
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+	tests := []struct {
+		name        string
+		expenseID   string
+		data        []byte
+		wantCreated bool
+		wantErr     error
+		setupMock   func()
+	}{
+		{
+			name:        "UploadAttachment_Success",
+			expenseID:   "expense_1",
+			data:        pdf,
+			wantCreated: true,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+				mockRepo.EXPECT().GetBySHA256(gomock.Any(), "expense_1", gomock.Any()).Return(nil, repository.ErrNotFound)
+				lockContent(gomock.Any())
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *entities.Attachment) error {
+					if a.ContentType != "application/pdf" || a.Size != int64(len(pdf)) || a.FileName != "receipt.pdf" || len(a.SHA256) != 64 {
+						t.Errorf("created attachment = %+v", a)
+					}
+					return nil
+				})
+				mockBlobs.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "UploadAttachment_Duplicate",
+			expenseID:   "expense_1",
+			data:        pdf,
+			wantCreated: false,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+				mockRepo.EXPECT().GetBySHA256(gomock.Any(), "expense_1", gomock.Any()).Return(&entities.Attachment{ID: "attachment_1", ExpenseID: "expense_1"}, nil)
+			},
+		},
+		{
+			name:      "UploadAttachment_ExpenseNotFound",
+			expenseID: "expense_404",
+			data:      pdf,
+			wantErr:   ErrNotFound,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_404").Return(nil, fmt.Errorf("%w: expense with ID expense_404", repository.ErrNotFound))
+			},
+		},
+		{
+			name:      "UploadAttachment_Unsupported",
+			expenseID: "expense_1",
+			data:      []byte("#!/bin/sh\nrm -rf /\n"),
+			wantErr:   ErrUnsupportedAttachment,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+			},
+		},
+		{
+			name:      "UploadAttachment_TooLarge",
+			expenseID: "expense_1",
+			data:      append(append([]byte{}, pdf...), make([]byte, MaxAttachmentSize)...),
+			wantErr:   ErrAttachmentTooLarge,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+			},
+		},
+		{
+			name:      "UploadAttachment_BlobStoreFails",
+			expenseID: "expense_1",
+			data:      pdf,
+			wantErr:   errBlobStore,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+				mockRepo.EXPECT().GetBySHA256(gomock.Any(), "expense_1", gomock.Any()).Return(nil, repository.ErrNotFound)
+				lockContent(gomock.Any())
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				// Failing the locked function rolls the row back.
+				mockBlobs.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(errBlobStore)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &attachmentServiceImpl{
+				repo:     mockRepo,
+				expenses: mockExpenses,
+				blobs:    mockBlobs,
+			}
+			_, created, err := s.UploadAttachment(context.TODO(), tt.expenseID, "C:\\scans\\receipt.pdf", bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("attachmentServiceImpl.UploadAttachment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("attachmentServiceImpl.UploadAttachment() created = %v, want %v", created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+var errBlobStore = errors.New("disk full")
+
+func Test_attachmentServiceImpl_DeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockBlobs := storagemocks.NewMockBlobStore(ctrl)
+	lockContent := func(hash interface{}) {
+		mockRepo.EXPECT().LockContent(gomock.Any(), hash, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, fn func(repository.AttachmentRepositoryInterface) error) error {
+			return fn(mockRepo)
+		})
+	}
+
+	a := &entities.Attachment{ID: "attachment_1", ExpenseID: "expense_1", SHA256: "abc123"}
+	tests := []struct {
+		name      string
+		setupMock func()
+	}{
+		{
+			name: "DeleteAttachment_LastReference",
+			setupMock: func() {
+				mockRepo.EXPECT().CountBySHA256(gomock.Any(), "abc123").Return(0, nil)
+				mockBlobs.EXPECT().Delete(gomock.Any(), "abc123").Return(nil)
+			},
+		},
+		{
+			name: "DeleteAttachment_SharedBlob",
+			setupMock: func() {
+				// Another expense has the same receipt, so its bytes are kept.
+				mockRepo.EXPECT().CountBySHA256(gomock.Any(), "abc123").Return(1, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+			mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1", "attachment_1").Return(a, nil)
+			lockContent("abc123")
+			mockRepo.EXPECT().Delete(gomock.Any(), "attachment_1").Return(nil)
+			tt.setupMock()
+			s := &attachmentServiceImpl{repo: mockRepo, expenses: mockExpenses, blobs: mockBlobs}
+			if err := s.DeleteAttachment(context.TODO(), "expense_1", "attachment_1"); err != nil {
+				t.Errorf("attachmentServiceImpl.DeleteAttachment() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_sanitizeFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "Plain", in: "receipt.pdf", want: "receipt.pdf"},
+		{name: "UnixPath", in: "../../etc/passwd", want: "passwd"},
+		{name: "WindowsPath", in: `C:\scans\taxi.jpg`, want: "taxi.jpg"},
+		{name: "ControlCharacters", in: "a\r\nb\".png", want: "ab.png"},
+		{name: "Empty", in: "", want: "receipt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeFileName(tt.in); got != tt.want {
+				t.Errorf("sanitizeFileName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/audit"
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// Auditor records the changes made to entities. Services record a change
+// through the Auditor InUnit returns for the unit of work making it, so
+// that the change is undone when it cannot be recorded.
+type Auditor interface {
+	Record(ctx context.Context, entityType, entityID string, action entities.AuditAction, before, after interface{}) error
+	InUnit(uow repository.UnitOfWork) Auditor
+}
+
+// AuditService defines the interface for the audit log.
+type AuditService interface {
+	Auditor
+	ListAuditEvents(ctx context.Context, entityID string) ([]*entities.AuditEvent, error)
+}
+
+type auditServiceImpl struct {
+	repo repository.AuditRepositoryInterface
+}
+
+// NewAuditService creates a new instance of AuditService.
+func NewAuditService(repo repository.AuditRepositoryInterface) AuditService {
+	return &auditServiceImpl{repo: repo}
+}
+
+// Record appends an event to the audit log of the workspace in ctx, with
+// the principal in ctx as its actor and the request in ctx. before and
+// after are the entity around the change, nil on create and delete.
+func (s *auditServiceImpl) Record(ctx context.Context, entityType, entityID string, action entities.AuditAction, before, after interface{}) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return auth.ErrUnauthenticated
+	}
+	req := audit.RequestFromContext(ctx)
+
+	e := &entities.AuditEvent{
+		ID:           generateID("audit"),
+		EntityType:   entityType,
+		EntityID:     entityID,
+		Action:       action,
+		ActorID:      principal.UserID,
+		APIKeyID:     principal.APIKeyID,
+		RequestID:    req.ID,
+		IP:           req.IP,
+		DateCreation: time.Now().Unix(),
+	}
+	var err error
+	if e.Before, err = audit.Snapshot(before); err != nil {
+		return err
+	}
+	if e.After, err = audit.Snapshot(after); err != nil {
+		return err
+	}
+	if e.Changes, err = audit.Diff(e.Before, e.After); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, e)
+}
+
+// InUnit returns an Auditor appending to the audit log in the transaction
+// of uow.
+func (s *auditServiceImpl) InUnit(uow repository.UnitOfWork) Auditor {
+	return &auditServiceImpl{repo: uow.Audit()}
+}
+
+// ListAuditEvents retrieves the audit events of an entity of the workspace
+// in ctx, oldest first.
+func (s *auditServiceImpl) ListAuditEvents(ctx context.Context, entityID string) ([]*entities.AuditEvent, error) {
+	entityID = strings.TrimSpace(entityID)
+	if entityID == "" {
+		return nil, fmt.Errorf("%w: entity_id is required", ErrInvalidFilter)
+	}
+	return s.repo.ListForEntity(ctx, entityID)
+}
+
+// auditedExpense returns a copy of e as recorded in the audit log, without
+// what is not stored with it. Nil stays nil, so the result can be passed
+// to Auditor.Record as is.
+func auditedExpense(e *entities.Expense) interface{} {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.Warnings = nil
+	return c
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/demo-talent/audit"
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_auditServiceImpl_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuditRepositoryInterface(ctrl)
+	ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", APIKeyID: "apikey_1", WorkspaceID: "workspace_1"})
+	ctx = audit.WithRequest(ctx, audit.Request{ID: "request_1", IP: "203.0.113.7"})
+
+	var got *entities.AuditEvent
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *entities.AuditEvent) error {
+		got = e
+		return nil
+	})
+
+	s := &auditServiceImpl{repo: mockRepo}
+	before := &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1000}
+	after := &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1250}
+	if err := s.Record(ctx, entities.AuditEntityExpense, "expense_1", entities.AuditUpdate, auditedExpense(before), auditedExpense(after)); err != nil {
+		t.Fatalf("auditServiceImpl.Record() error = %v", err)
+	}
+
+	if got == nil {
+		t.Fatal("auditServiceImpl.Record() recorded nothing")
+	}
+	if got.ActorID != "user_1" || got.APIKeyID != "apikey_1" || got.RequestID != "request_1" || got.IP != "203.0.113.7" {
+		t.Errorf("auditServiceImpl.Record() recorded %+v", got)
+	}
+	if len(got.Changes) != 1 || string(got.Changes["amount"].Before) != "10.00" || string(got.Changes["amount"].After) != "12.50" {
+		changes, _ := json.Marshal(got.Changes)
+		t.Errorf("auditServiceImpl.Record() changes = %s, want only amount from 10.00 to 12.50", changes)
+	}
+}
+
+func Test_auditServiceImpl_ListAuditEvents_NoEntity(t *testing.T) {
+	s := &auditServiceImpl{}
+	if _, err := s.ListAuditEvents(context.TODO(), " "); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("auditServiceImpl.ListAuditEvents() error = %v, want %v", err, ErrInvalidFilter)
+	}
+}
@@ -0,0 +1,540 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// The services below wrap the others to check, through auth.Authorize,
+// that the role of the principal in the context permits each operation
+// before delegating it. Handlers get the wrapped services; the services
+// acting on their own, like the recurring scheduler, use the plain ones.
+//
+// WorkspaceService and APIKeyService have no wrapper, as the role of the
+// selected workspace says nothing about what they act on. Workspaces are
+// managed according to the role of the user in the workspace named by the
+// request, which WorkspaceService checks itself, and API keys are the
+// user's own, never granting more than the role of the user in the
+// workspace a request selects.
+
+type authorizedExpenseService struct {
+	next ExpenseService
+}
+
+// NewAuthorizedExpenseService wraps next so that reading expenses needs
+// auth.PermReadExpenses, changing and submitting them
+// auth.PermWriteExpenses, and reviewing them auth.PermApproveExpenses.
+// PurgeTrash is left to the purge job, which acts on every workspace.
+func NewAuthorizedExpenseService(next ExpenseService) ExpenseService {
+	return &authorizedExpenseService{next: next}
+}
+
+func (s *authorizedExpenseService) CreateExpense(ctx context.Context, e *entities.Expense) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.CreateExpense(ctx, e)
+}
+
+func (s *authorizedExpenseService) ImportExpense(ctx context.Context, e *entities.Expense) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.ImportExpense(ctx, e)
+}
+
+func (s *authorizedExpenseService) GetExpenseByID(ctx context.Context, id string) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetExpenseByID(ctx, id)
+}
+
+func (s *authorizedExpenseService) ListExpenses(ctx context.Context, f entities.ExpenseFilter, p entities.PageRequest) (*entities.ExpensePage, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListExpenses(ctx, f, p)
+}
+
+func (s *authorizedExpenseService) ExportExpenses(ctx context.Context, f entities.ExpenseFilter, fn func(*entities.Expense) error) error {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return err
+	}
+	return s.next.ExportExpenses(ctx, f, fn)
+}
+
+func (s *authorizedExpenseService) UpdateExpense(ctx context.Context, e *entities.Expense) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.UpdateExpense(ctx, e)
+}
+
+func (s *authorizedExpenseService) DeleteExpense(ctx context.Context, id string, version int) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.DeleteExpense(ctx, id, version)
+}
+
+func (s *authorizedExpenseService) SubmitExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.SubmitExpense(ctx, id)
+}
+
+func (s *authorizedExpenseService) ApproveExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermApproveExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ApproveExpense(ctx, id)
+}
+
+func (s *authorizedExpenseService) RejectExpense(ctx context.Context, id, reason string) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermApproveExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.RejectExpense(ctx, id, reason)
+}
+
+func (s *authorizedExpenseService) ReimburseExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermApproveExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ReimburseExpense(ctx, id)
+}
+
+func (s *authorizedExpenseService) ListPendingApprovals(ctx context.Context) ([]*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermApproveExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListPendingApprovals(ctx)
+}
+
+func (s *authorizedExpenseService) RestoreExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.RestoreExpense(ctx, id)
+}
+
+func (s *authorizedExpenseService) ListTrash(ctx context.Context) ([]*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListTrash(ctx)
+}
+
+func (s *authorizedExpenseService) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	return s.next.PurgeTrash(ctx, before)
+}
+
+func (s *authorizedExpenseService) ListExpenseRevisions(ctx context.Context, id string) ([]*entities.ExpenseRevision, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListExpenseRevisions(ctx, id)
+}
+
+func (s *authorizedExpenseService) GetExpenseRevision(ctx context.Context, id string, revision int) (*entities.ExpenseRevision, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetExpenseRevision(ctx, id, revision)
+}
+
+func (s *authorizedExpenseService) RevertExpense(ctx context.Context, id string, revision, version int) (*entities.Expense, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.RevertExpense(ctx, id, revision, version)
+}
+
+func (s *authorizedExpenseService) RunBatch(ctx context.Context, b *entities.ExpenseBatch) (*entities.ExpenseBatchResult, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.RunBatch(ctx, b)
+}
+
+type authorizedCategoryService struct {
+	next CategoryService
+}
+
+// NewAuthorizedCategoryService wraps next so that reading categories needs
+// auth.PermReadExpenses and changing them auth.PermManageCategories.
+func NewAuthorizedCategoryService(next CategoryService) CategoryService {
+	return &authorizedCategoryService{next: next}
+}
+
+func (s *authorizedCategoryService) CreateCategory(ctx context.Context, c *entities.Category) error {
+	if err := auth.Authorize(ctx, auth.PermManageCategories); err != nil {
+		return err
+	}
+	return s.next.CreateCategory(ctx, c)
+}
+
+func (s *authorizedCategoryService) GetCategoryByID(ctx context.Context, id string) (*entities.Category, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetCategoryByID(ctx, id)
+}
+
+func (s *authorizedCategoryService) ListCategories(ctx context.Context) ([]*entities.Category, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListCategories(ctx)
+}
+
+func (s *authorizedCategoryService) UpdateCategory(ctx context.Context, c *entities.Category) error {
+	if err := auth.Authorize(ctx, auth.PermManageCategories); err != nil {
+		return err
+	}
+	return s.next.UpdateCategory(ctx, c)
+}
+
+func (s *authorizedCategoryService) DeleteCategory(ctx context.Context, id string) error {
+	if err := auth.Authorize(ctx, auth.PermManageCategories); err != nil {
+		return err
+	}
+	return s.next.DeleteCategory(ctx, id)
+}
+
+type authorizedBudgetService struct {
+	next BudgetService
+}
+
+// NewAuthorizedBudgetService wraps next so that reading budgets needs
+// auth.PermReadExpenses and changing them auth.PermManageCategories.
+func NewAuthorizedBudgetService(next BudgetService) BudgetService {
+	return &authorizedBudgetService{next: next}
+}
+
+func (s *authorizedBudgetService) CheckExpense(ctx context.Context, e *entities.Expense) ([]entities.BudgetWarning, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.CheckExpense(ctx, e)
+}
+
+func (s *authorizedBudgetService) CreateBudget(ctx context.Context, b *entities.Budget) error {
+	if err := auth.Authorize(ctx, auth.PermManageCategories); err != nil {
+		return err
+	}
+	return s.next.CreateBudget(ctx, b)
+}
+
+func (s *authorizedBudgetService) GetBudgetByID(ctx context.Context, id string) (*entities.Budget, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetBudgetByID(ctx, id)
+}
+
+func (s *authorizedBudgetService) ListBudgets(ctx context.Context, categoryID string) ([]*entities.Budget, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListBudgets(ctx, categoryID)
+}
+
+func (s *authorizedBudgetService) UpdateBudget(ctx context.Context, b *entities.Budget) error {
+	if err := auth.Authorize(ctx, auth.PermManageCategories); err != nil {
+		return err
+	}
+	return s.next.UpdateBudget(ctx, b)
+}
+
+func (s *authorizedBudgetService) DeleteBudget(ctx context.Context, id string) error {
+	if err := auth.Authorize(ctx, auth.PermManageCategories); err != nil {
+		return err
+	}
+	return s.next.DeleteBudget(ctx, id)
+}
+
+func (s *authorizedBudgetService) GetBudgetStatus(ctx context.Context, id string, at time.Time) (*entities.BudgetStatus, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetBudgetStatus(ctx, id, at)
+}
+
+type authorizedCurrencyService struct {
+	next CurrencyService
+}
+
+// NewAuthorizedCurrencyService wraps next so that converting amounts and
+// expense totals need auth.PermReadExpenses, and importing rates
+// auth.PermManageExchangeRates.
+func NewAuthorizedCurrencyService(next CurrencyService) CurrencyService {
+	return &authorizedCurrencyService{next: next}
+}
+
+func (s *authorizedCurrencyService) BaseCurrency() string {
+	return s.next.BaseCurrency()
+}
+
+func (s *authorizedCurrencyService) Convert(ctx context.Context, amount entities.Money, currency string, on time.Time) (entities.Money, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return 0, err
+	}
+	return s.next.Convert(ctx, amount, currency, on)
+}
+
+func (s *authorizedCurrencyService) ImportRates(ctx context.Context, r io.Reader, format RateFormat) (int, error) {
+	if err := auth.Authorize(ctx, auth.PermManageExchangeRates); err != nil {
+		return 0, err
+	}
+	return s.next.ImportRates(ctx, r, format)
+}
+
+func (s *authorizedCurrencyService) ExpenseTotals(ctx context.Context, f entities.ExpenseFilter) (*entities.ExpenseTotals, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ExpenseTotals(ctx, f)
+}
+
+type authorizedRecurringExpenseService struct {
+	next RecurringExpenseService
+}
+
+// NewAuthorizedRecurringExpenseService wraps next so that reading templates
+// needs auth.PermReadExpenses and changing them auth.PermWriteExpenses.
+// RunDue is left to the scheduler, which acts as the owner of each template.
+func NewAuthorizedRecurringExpenseService(next RecurringExpenseService) RecurringExpenseService {
+	return &authorizedRecurringExpenseService{next: next}
+}
+
+func (s *authorizedRecurringExpenseService) CreateRecurringExpense(ctx context.Context, r *entities.RecurringExpense) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.CreateRecurringExpense(ctx, r)
+}
+
+func (s *authorizedRecurringExpenseService) GetRecurringExpenseByID(ctx context.Context, id string) (*entities.RecurringExpense, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetRecurringExpenseByID(ctx, id)
+}
+
+func (s *authorizedRecurringExpenseService) ListRecurringExpenses(ctx context.Context) ([]*entities.RecurringExpense, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListRecurringExpenses(ctx)
+}
+
+func (s *authorizedRecurringExpenseService) UpdateRecurringExpense(ctx context.Context, r *entities.RecurringExpense) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.UpdateRecurringExpense(ctx, r)
+}
+
+func (s *authorizedRecurringExpenseService) DeleteRecurringExpense(ctx context.Context, id string) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.DeleteRecurringExpense(ctx, id)
+}
+
+func (s *authorizedRecurringExpenseService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	return s.next.RunDue(ctx, now)
+}
+
+type authorizedAttachmentService struct {
+	next AttachmentService
+}
+
+// NewAuthorizedAttachmentService wraps next so that reading attachments
+// needs auth.PermReadExpenses and changing them auth.PermWriteExpenses.
+func NewAuthorizedAttachmentService(next AttachmentService) AttachmentService {
+	return &authorizedAttachmentService{next: next}
+}
+
+func (s *authorizedAttachmentService) UploadAttachment(ctx context.Context, expenseID, fileName string, r io.Reader) (*entities.Attachment, bool, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, false, err
+	}
+	return s.next.UploadAttachment(ctx, expenseID, fileName, r)
+}
+
+func (s *authorizedAttachmentService) ListAttachments(ctx context.Context, expenseID string) ([]*entities.Attachment, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListAttachments(ctx, expenseID)
+}
+
+func (s *authorizedAttachmentService) GetAttachment(ctx context.Context, expenseID, id string) (*entities.Attachment, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.GetAttachment(ctx, expenseID, id)
+}
+
+func (s *authorizedAttachmentService) OpenAttachment(ctx context.Context, a *entities.Attachment) (io.ReadCloser, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.OpenAttachment(ctx, a)
+}
+
+func (s *authorizedAttachmentService) DeleteAttachment(ctx context.Context, expenseID, id string) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.DeleteAttachment(ctx, expenseID, id)
+}
+
+type authorizedUserService struct {
+	next UserService
+}
+
+// NewAuthorizedUserService wraps next so that assigning roles needs
+// auth.PermManageUsers. Registering and looking users up stay open, as
+// they happen before a principal exists.
+func NewAuthorizedUserService(next UserService) UserService {
+	return &authorizedUserService{next: next}
+}
+
+func (s *authorizedUserService) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
+	return s.next.GetUserByID(ctx, id)
+}
+
+func (s *authorizedUserService) ProvisionUser(ctx context.Context, p auth.Principal) (*entities.User, error) {
+	return s.next.ProvisionUser(ctx, p)
+}
+
+func (s *authorizedUserService) CurrentUser(ctx context.Context) (*entities.User, error) {
+	return s.next.CurrentUser(ctx)
+}
+
+func (s *authorizedUserService) SetUserRole(ctx context.Context, id string, role auth.Role) error {
+	if err := auth.Authorize(ctx, auth.PermManageUsers); err != nil {
+		return err
+	}
+	return s.next.SetUserRole(ctx, id, role)
+}
+
+type authorizedSplitService struct {
+	next SplitService
+}
+
+// NewAuthorizedSplitService wraps next so that reading splits, balances and
+// settlements needs auth.PermReadExpenses and recording them
+// auth.PermWriteExpenses.
+func NewAuthorizedSplitService(next SplitService) SplitService {
+	return &authorizedSplitService{next: next}
+}
+
+func (s *authorizedSplitService) SetSplits(ctx context.Context, expenseID string, req entities.SplitRequest) ([]entities.ExpenseSplit, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.SetSplits(ctx, expenseID, req)
+}
+
+func (s *authorizedSplitService) ListSplits(ctx context.Context, expenseID string) ([]entities.ExpenseSplit, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListSplits(ctx, expenseID)
+}
+
+func (s *authorizedSplitService) Balances(ctx context.Context) ([]entities.Balance, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.Balances(ctx)
+}
+
+func (s *authorizedSplitService) SettleUp(ctx context.Context) ([]entities.Transfer, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.SettleUp(ctx)
+}
+
+func (s *authorizedSplitService) RecordSettlement(ctx context.Context, st *entities.Settlement) error {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return err
+	}
+	return s.next.RecordSettlement(ctx, st)
+}
+
+func (s *authorizedSplitService) ListSettlements(ctx context.Context) ([]*entities.Settlement, error) {
+	if err := auth.Authorize(ctx, auth.PermReadExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListSettlements(ctx)
+}
+
+type authorizedAuditService struct {
+	next AuditService
+}
+
+// NewAuthorizedAuditService wraps next so that reading the audit log needs
+// auth.PermApproveExpenses. Recording stays open, as services record the
+// changes they were authorized to make.
+func NewAuthorizedAuditService(next AuditService) AuditService {
+	return &authorizedAuditService{next: next}
+}
+
+func (s *authorizedAuditService) Record(ctx context.Context, entityType, entityID string, action entities.AuditAction, before, after interface{}) error {
+	return s.next.Record(ctx, entityType, entityID, action, before, after)
+}
+
+func (s *authorizedAuditService) InUnit(uow repository.UnitOfWork) Auditor {
+	return s.next.InUnit(uow)
+}
+
+func (s *authorizedAuditService) ListAuditEvents(ctx context.Context, entityID string) ([]*entities.AuditEvent, error) {
+	if err := auth.Authorize(ctx, auth.PermApproveExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ListAuditEvents(ctx, entityID)
+}
+
+type authorizedImportService struct {
+	next ImportService
+}
+
+// NewAuthorizedImportService wraps next so that importing expenses needs
+// auth.PermWriteExpenses, even on a dry run.
+func NewAuthorizedImportService(next ImportService) ImportService {
+	return &authorizedImportService{next: next}
+}
+
+func (s *authorizedImportService) ImportCSV(ctx context.Context, r io.Reader, opts entities.CSVImportOptions) (*entities.ImportSummary, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ImportCSV(ctx, r, opts)
+}
+
+func (s *authorizedImportService) ImportOFX(ctx context.Context, r io.Reader, dryRun bool) (*entities.ImportSummary, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ImportOFX(ctx, r, dryRun)
+}
+
+func (s *authorizedImportService) ImportQIF(ctx context.Context, r io.Reader, opts entities.QIFImportOptions) (*entities.ImportSummary, error) {
+	if err := auth.Authorize(ctx, auth.PermWriteExpenses); err != nil {
+		return nil, err
+	}
+	return s.next.ImportQIF(ctx, r, opts)
+}
@@ -0,0 +1,268 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/services"
+	"github.com/demo-talent/services/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_authorizedExpenseService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mocks.NewMockExpenseService(ctrl)
+	s := services.NewAuthorizedExpenseService(mockSvc)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		call      func(ctx context.Context) error
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name: "GetExpense_Viewer",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleViewer}),
+			call: func(ctx context.Context) error {
+				_, err := s.GetExpenseByID(ctx, "expense_1")
+				return err
+			},
+			setupMock: func() {
+				mockSvc.EXPECT().GetExpenseByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+			},
+		},
+		{
+			name:      "DeleteExpense_Viewer",
+			ctx:       auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleViewer}),
+			call:      func(ctx context.Context) error { return s.DeleteExpense(ctx, "expense_1", 1) },
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {},
+		},
+		{
+			name: "DeleteExpense_Member",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleMember}),
+			call: func(ctx context.Context) error { return s.DeleteExpense(ctx, "expense_1", 1) },
+			setupMock: func() {
+				mockSvc.EXPECT().DeleteExpense(gomock.Any(), "expense_1", 1).Return(nil)
+			},
+		},
+		{
+			name:      "CreateExpense_Unauthenticated",
+			ctx:       context.TODO(),
+			call:      func(ctx context.Context) error { return s.CreateExpense(ctx, &entities.Expense{}) },
+			wantErr:   auth.ErrUnauthenticated,
+			setupMock: func() {},
+		},
+		{
+			name: "ApproveExpense_Member",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleMember}),
+			call: func(ctx context.Context) error {
+				_, err := s.ApproveExpense(ctx, "expense_1")
+				return err
+			},
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {},
+		},
+		{
+			name: "ApproveExpense_Approver",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleApprover}),
+			call: func(ctx context.Context) error {
+				_, err := s.ApproveExpense(ctx, "expense_1")
+				return err
+			},
+			setupMock: func() {
+				mockSvc.EXPECT().ApproveExpense(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1"}, nil)
+			},
+		},
+		{
+			name: "RestoreExpense_Viewer",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleViewer}),
+			call: func(ctx context.Context) error {
+				_, err := s.RestoreExpense(ctx, "expense_1")
+				return err
+			},
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {},
+		},
+		{
+			name: "RevertExpense_Viewer",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleViewer}),
+			call: func(ctx context.Context) error {
+				_, err := s.RevertExpense(ctx, "expense_1", 1, 1)
+				return err
+			},
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {},
+		},
+		{
+			name: "RunBatch_Viewer",
+			ctx:  auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: auth.RoleViewer}),
+			call: func(ctx context.Context) error {
+				_, err := s.RunBatch(ctx, &entities.ExpenseBatch{})
+				return err
+			},
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {},
+		},
+		{
+			name: "PurgeTrash_Unauthenticated",
+			ctx:  context.TODO(),
+			call: func(ctx context.Context) error {
+				_, err := s.PurgeTrash(ctx, time.Unix(1700000000, 0))
+				return err
+			},
+			setupMock: func() {
+				mockSvc.EXPECT().PurgeTrash(gomock.Any(), time.Unix(1700000000, 0)).Return(int64(2), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			if err := tt.call(tt.ctx); !errors.Is(err, tt.wantErr) {
+				t.Errorf("authorizedExpenseService error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authorizedCategoryService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mocks.NewMockCategoryService(ctrl)
+	s := services.NewAuthorizedCategoryService(mockSvc)
+
+	tests := []struct {
+		name      string
+		role      auth.Role
+		wantErr   error
+		setupMock func()
+	}{
+		{name: "CreateCategory_Member", role: auth.RoleMember, wantErr: auth.ErrForbidden, setupMock: func() {}},
+		{name: "CreateCategory_Approver", role: auth.RoleApprover, wantErr: auth.ErrForbidden, setupMock: func() {}},
+		{
+			name: "CreateCategory_Admin",
+			role: auth.RoleAdmin,
+			setupMock: func() {
+				mockSvc.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: tt.role})
+			if err := s.CreateCategory(ctx, &entities.Category{Name: "Travel"}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("authorizedCategoryService.CreateCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authorizedCurrencyService_Convert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mocks.NewMockCurrencyService(ctrl)
+	s := services.NewAuthorizedCurrencyService(mockSvc)
+	on := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		role      auth.Role
+		wantErr   error
+		setupMock func()
+	}{
+		{name: "NoWorkspace", wantErr: auth.ErrForbidden, setupMock: func() {}},
+		{
+			name: "Viewer",
+			role: auth.RoleViewer,
+			setupMock: func() {
+				mockSvc.EXPECT().Convert(gomock.Any(), entities.Money(1000), "EUR", on).Return(entities.Money(1100), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: tt.role})
+			if _, err := s.Convert(ctx, 1000, "EUR", on); !errors.Is(err, tt.wantErr) {
+				t.Errorf("authorizedCurrencyService.Convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authorizedImportService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSvc := mocks.NewMockImportService(ctrl)
+	s := services.NewAuthorizedImportService(mockSvc)
+
+	importCSV := func(ctx context.Context) error {
+		_, err := s.ImportCSV(ctx, strings.NewReader(""), entities.CSVImportOptions{DryRun: true})
+		return err
+	}
+	importOFX := func(ctx context.Context) error {
+		_, err := s.ImportOFX(ctx, strings.NewReader(""), true)
+		return err
+	}
+	importQIF := func(ctx context.Context) error {
+		_, err := s.ImportQIF(ctx, strings.NewReader(""), entities.QIFImportOptions{DryRun: true})
+		return err
+	}
+	tests := []struct {
+		name      string
+		role      auth.Role
+		call      func(ctx context.Context) error
+		wantErr   error
+		setupMock func()
+	}{
+		{name: "ImportCSV_Viewer", role: auth.RoleViewer, call: importCSV, wantErr: auth.ErrForbidden, setupMock: func() {}},
+		{
+			name: "ImportCSV_Member",
+			role: auth.RoleMember,
+			call: importCSV,
+			setupMock: func() {
+				mockSvc.EXPECT().ImportCSV(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entities.ImportSummary{}, nil)
+			},
+		},
+		{name: "ImportOFX_Viewer", role: auth.RoleViewer, call: importOFX, wantErr: auth.ErrForbidden, setupMock: func() {}},
+		{
+			name: "ImportOFX_Member",
+			role: auth.RoleMember,
+			call: importOFX,
+			setupMock: func() {
+				mockSvc.EXPECT().ImportOFX(gomock.Any(), gomock.Any(), true).Return(&entities.ImportSummary{}, nil)
+			},
+		},
+		{name: "ImportQIF_Viewer", role: auth.RoleViewer, call: importQIF, wantErr: auth.ErrForbidden, setupMock: func() {}},
+		{
+			name: "ImportQIF_Member",
+			role: auth.RoleMember,
+			call: importQIF,
+			setupMock: func() {
+				mockSvc.EXPECT().ImportQIF(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entities.ImportSummary{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", Role: tt.role})
+			if err := tt.call(ctx); !errors.Is(err, tt.wantErr) {
+				t.Errorf("authorizedImportService error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
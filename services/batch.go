@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/demo-talent/entities"
+)
+
+// MaxBatchOperations is the largest number of operations a batch may
+// hold.
+const MaxBatchOperations = 100
+
+// ErrInvalidBatch is returned when a batch is empty, too large or holds a
+// malformed operation.
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// ErrVersionRequired is returned when a batch updates or deletes an
+// expense without the version the change is based on.
+var ErrVersionRequired = errors.New("version required")
+
+// ErrBatchAborted is reported for the operations of an all-or-nothing
+// batch that were undone or skipped because another one failed.
+var ErrBatchAborted = errors.New("batch aborted")
+
+// RunBatch makes the changes of b in a single transaction, in order. In
+// the all-or-nothing mode the first failed operation undoes the whole
+// batch; in the per-item mode it undoes only its own changes, and the
+// batch is committed with the operations that succeeded. Changes are
+// audited in the transaction, so the events of undone operations go with
+// them, and batches are not checked against budgets.
+func (s *expenseServiceImpl) RunBatch(ctx context.Context, b *entities.ExpenseBatch) (*entities.ExpenseBatchResult, error) {
+	if err := validateBatch(b); err != nil {
+		return nil, err
+	}
+
+	uow, err := s.units.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer uow.Rollback()
+
+	tx := s.withUnit(uow)
+
+	result := &entities.ExpenseBatchResult{Results: make([]entities.ExpenseOperationResult, len(b.Operations))}
+	for i, op := range b.Operations {
+		res := &result.Results[i]
+		res.Index, res.Op = i, op.Op
+		run := func() (err error) {
+			res.Expense, err = tx.apply(ctx, op)
+			return err
+		}
+		if b.Mode == entities.BatchPerItem {
+			err = uow.Try(ctx, run)
+		} else {
+			err = run()
+		}
+		if err == nil {
+			continue
+		}
+
+		res.Expense, res.Err = nil, err
+		if b.Mode != entities.BatchPerItem {
+			for j := range result.Results {
+				if j != i {
+					result.Results[j] = entities.ExpenseOperationResult{Index: j, Op: b.Operations[j].Op, Err: ErrBatchAborted}
+				}
+			}
+			return result, nil
+		}
+	}
+
+	if err := uow.Commit(); err != nil {
+		return nil, err
+	}
+	result.Committed = true
+	return result, nil
+}
+
+// apply makes a single operation of a batch and returns the expense it
+// left.
+func (s *expenseServiceImpl) apply(ctx context.Context, op entities.ExpenseOperation) (*entities.Expense, error) {
+	switch op.Op {
+	case entities.BatchCreate:
+		e := *op.Expense
+		if err := s.CreateExpense(ctx, &e); err != nil {
+			return nil, err
+		}
+		return &e, nil
+	case entities.BatchUpdate:
+		e := *op.Expense
+		e.ID, e.Version = op.ID, op.Version
+		if err := s.UpdateExpense(ctx, &e); err != nil {
+			return nil, err
+		}
+		return s.repo.GetByID(ctx, op.ID)
+	default:
+		return nil, s.DeleteExpense(ctx, op.ID, op.Version)
+	}
+}
+
+// validateBatch checks the shape of a batch before any of it runs, and
+// defaults its mode.
+func validateBatch(b *entities.ExpenseBatch) error {
+	switch b.Mode {
+	case "":
+		b.Mode = entities.BatchAllOrNothing
+	case entities.BatchAllOrNothing, entities.BatchPerItem:
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidBatch, b.Mode)
+	}
+	if len(b.Operations) == 0 || len(b.Operations) > MaxBatchOperations {
+		return fmt.Errorf("%w: a batch holds 1 to %d operations", ErrInvalidBatch, MaxBatchOperations)
+	}
+
+	for i, op := range b.Operations {
+		switch op.Op {
+		case entities.BatchCreate:
+			if op.Expense == nil {
+				return fmt.Errorf("%w: operation %d creates no expense", ErrInvalidBatch, i)
+			}
+		case entities.BatchUpdate:
+			if op.ID == "" || op.Expense == nil {
+				return fmt.Errorf("%w: operation %d needs an id and an expense", ErrInvalidBatch, i)
+			}
+			if op.Version <= 0 {
+				return fmt.Errorf("%w: operation %d needs the version of expense %s", ErrVersionRequired, i, op.ID)
+			}
+		case entities.BatchDelete:
+			if op.ID == "" {
+				return fmt.Errorf("%w: operation %d needs an id", ErrInvalidBatch, i)
+			}
+			if op.Version <= 0 {
+				return fmt.Errorf("%w: operation %d needs the version of expense %s", ErrVersionRequired, i, op.ID)
+			}
+		default:
+			return fmt.Errorf("%w: operation %d has unknown op %q", ErrInvalidBatch, i, op.Op)
+		}
+	}
+	return nil
+}
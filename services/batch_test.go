@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_expenseServiceImpl_RunBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	draft := &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1000, Currency: "USD", Status: entities.StatusDraft, Version: 1}
+	operations := []entities.ExpenseOperation{
+		{Op: entities.BatchCreate, Expense: &entities.Expense{Description: "Lunch", Amount: 1500}},
+		{Op: entities.BatchDelete, ID: "expense_1", Version: 1},
+	}
+
+	tests := []struct {
+		name          string
+		batch         entities.ExpenseBatch
+		setupMock     func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork)
+		wantErr       error
+		wantCommitted bool
+		wantErrs      []error
+		wantActions   []entities.AuditAction
+	}{
+		{
+			name:  "AllOrNothing",
+			batch: entities.ExpenseBatch{Operations: operations},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(draft, nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), "expense_1", 1).Return(nil)
+				mockUnit.EXPECT().Commit().Return(nil)
+			},
+			wantCommitted: true,
+			wantErrs:      []error{nil, nil},
+			wantActions:   []entities.AuditAction{entities.AuditCreate, entities.AuditDelete},
+		},
+		{
+			name:  "AllOrNothingFailed",
+			batch: entities.ExpenseBatch{Mode: entities.BatchAllOrNothing, Operations: operations},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(nil, repository.ErrNotFound)
+			},
+			wantErrs: []error{ErrBatchAborted, ErrNotFound},
+			// Recorded in the transaction that is rolled back.
+			wantActions: []entities.AuditAction{entities.AuditCreate},
+		},
+		{
+			name:  "PerItem",
+			batch: entities.ExpenseBatch{Mode: entities.BatchPerItem, Operations: operations},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {
+				mockUnit.EXPECT().Try(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func() error) error {
+					return fn()
+				}).Times(2)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Status: entities.StatusApproved, Version: 1}, nil)
+				mockUnit.EXPECT().Commit().Return(nil)
+			},
+			wantCommitted: true,
+			wantErrs:      []error{nil, ErrExpenseLocked},
+			wantActions:   []entities.AuditAction{entities.AuditCreate},
+		},
+		{
+			name:      "Empty",
+			batch:     entities.ExpenseBatch{},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {},
+			wantErr:   ErrInvalidBatch,
+		},
+		{
+			name:      "UnknownOp",
+			batch:     entities.ExpenseBatch{Operations: []entities.ExpenseOperation{{Op: "merge", ID: "expense_1"}}},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {},
+			wantErr:   ErrInvalidBatch,
+		},
+		{
+			name:      "UpdateWithoutID",
+			batch:     entities.ExpenseBatch{Operations: []entities.ExpenseOperation{{Op: entities.BatchUpdate, Expense: draft}}},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {},
+			wantErr:   ErrInvalidBatch,
+		},
+		{
+			name:      "UpdateWithoutVersion",
+			batch:     entities.ExpenseBatch{Operations: []entities.ExpenseOperation{{Op: entities.BatchUpdate, ID: "expense_1", Expense: draft}}},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {},
+			wantErr:   ErrVersionRequired,
+		},
+		{
+			name:      "DeleteWithoutVersion",
+			batch:     entities.ExpenseBatch{Operations: []entities.ExpenseOperation{operations[0], {Op: entities.BatchDelete, ID: "expense_1"}}},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface, mockUnit *mocks.MockUnitOfWork) {},
+			wantErr:   ErrVersionRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			mockUnit := mocks.NewMockUnitOfWork(ctrl)
+			mockUnits := mocks.NewMockUnitOfWorkFactory(ctrl)
+			if tt.wantErr == nil {
+				mockUnits.EXPECT().Begin(gomock.Any()).Return(mockUnit, nil)
+				mockUnit.EXPECT().Expenses().Return(mockRepo)
+				mockUnit.EXPECT().Rollback().Return(nil)
+			}
+			tt.setupMock(mockRepo, mockUnit)
+
+			auditor := &recordingAuditor{}
+			s := &expenseServiceImpl{units: mockUnits, audit: auditor, defaultCurrency: "USD"}
+			got, err := s.RunBatch(context.TODO(), &tt.batch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expenseServiceImpl.RunBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Committed != tt.wantCommitted {
+				t.Errorf("expenseServiceImpl.RunBatch() committed = %v, want %v", got.Committed, tt.wantCommitted)
+			}
+			for i, res := range got.Results {
+				if res.Index != i || !errors.Is(res.Err, tt.wantErrs[i]) || (res.Err != nil) != (tt.wantErrs[i] != nil) {
+					t.Errorf("expenseServiceImpl.RunBatch() result %d = %+v, want error %v", i, res, tt.wantErrs[i])
+				}
+			}
+			if !reflect.DeepEqual(auditor.actions, tt.wantActions) {
+				t.Errorf("expenseServiceImpl.RunBatch() recorded %v, want %v", auditor.actions, tt.wantActions)
+			}
+		})
+	}
+}
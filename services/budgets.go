@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// ErrInvalidBudget is returned when a budget fails validation.
+var ErrInvalidBudget = errors.New("invalid budget")
+
+// BudgetChecker reports the budgets an expense has pushed over their limit.
+type BudgetChecker interface {
+	CheckExpense(ctx context.Context, e *entities.Expense) ([]entities.BudgetWarning, error)
+}
+
+// BudgetService defines the interface for budget-related operations.
+type BudgetService interface {
+	BudgetChecker
+	CreateBudget(ctx context.Context, b *entities.Budget) error
+	GetBudgetByID(ctx context.Context, id string) (*entities.Budget, error)
+	ListBudgets(ctx context.Context, categoryID string) ([]*entities.Budget, error)
+	UpdateBudget(ctx context.Context, b *entities.Budget) error
+	DeleteBudget(ctx context.Context, id string) error
+	GetBudgetStatus(ctx context.Context, id string, at time.Time) (*entities.BudgetStatus, error)
+}
+
+type budgetServiceImpl struct {
+	repo       repository.BudgetRepositoryInterface
+	categories repository.CategoryRepositoryInterface
+	currencies CurrencyService
+}
+
+// NewBudgetService creates a new instance of BudgetService. Spending is
+// summed in the base currency of currencies.
+func NewBudgetService(repo repository.BudgetRepositoryInterface, categories repository.CategoryRepositoryInterface, currencies CurrencyService) BudgetService {
+	return &budgetServiceImpl{repo: repo, categories: categories, currencies: currencies}
+}
+
+// CreateBudget creates a new budget.
+func (s *budgetServiceImpl) CreateBudget(ctx context.Context, b *entities.Budget) error {
+	if err := s.validate(ctx, b); err != nil {
+		return err
+	}
+
+	b.ID = generateID("budget")
+	b.DateCreation = time.Now().Unix()
+
+	return s.repo.Create(ctx, b)
+}
+
+// GetBudgetByID retrieves a budget by its ID.
+func (s *budgetServiceImpl) GetBudgetByID(ctx context.Context, id string) (*entities.Budget, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListBudgets retrieves the budgets of a category, or all of them when
+// categoryID is empty.
+func (s *budgetServiceImpl) ListBudgets(ctx context.Context, categoryID string) ([]*entities.Budget, error) {
+	return s.repo.List(ctx, categoryID)
+}
+
+// UpdateBudget updates an existing budget.
+func (s *budgetServiceImpl) UpdateBudget(ctx context.Context, b *entities.Budget) error {
+	_, err := s.repo.GetByID(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.validate(ctx, b); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, b)
+}
+
+// DeleteBudget deletes a budget by its ID.
+func (s *budgetServiceImpl) DeleteBudget(ctx context.Context, id string) error {
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// GetBudgetStatus computes how much of a budget was spent in the period
+// containing at.
+func (s *budgetServiceImpl) GetBudgetStatus(ctx context.Context, id string, at time.Time) (*entities.BudgetStatus, error) {
+	b, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(ctx, b, at)
+}
+
+// CheckExpense warns about every budget of the expense category that the
+// expense has just pushed over its limit.
+func (s *budgetServiceImpl) CheckExpense(ctx context.Context, e *entities.Expense) ([]entities.BudgetWarning, error) {
+	if e.CategoryID == "" {
+		return nil, nil
+	}
+	budgets, err := s.repo.List(ctx, e.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Unix(e.DateCreation, 0)
+	var warnings []entities.BudgetWarning
+	for _, b := range budgets {
+		status, err := s.status(ctx, b, at)
+		if err != nil {
+			return nil, err
+		}
+		if !status.OverLimit {
+			continue
+		}
+
+		amount, err := s.currencies.Convert(ctx, e.Amount, e.Currency, at)
+		if err != nil {
+			return nil, err
+		}
+		// The budget was already over its limit before this expense.
+		if status.Spent-amount > status.Limit+status.RolledOver {
+			continue
+		}
+
+		warnings = append(warnings, entities.BudgetWarning{
+			BudgetID: b.ID,
+			Message: fmt.Sprintf("%s budget exceeded: spent %s of %s %s",
+				b.Period, status.Spent, status.Limit+status.RolledOver, status.Currency),
+		})
+	}
+	return warnings, nil
+}
+
+// status computes the status of b in the period containing at.
+func (s *budgetServiceImpl) status(ctx context.Context, b *entities.Budget, at time.Time) (*entities.BudgetStatus, error) {
+	start, end := b.Period.Bounds(at)
+	spent, err := s.spent(ctx, b.CategoryID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &entities.BudgetStatus{
+		BudgetID:    b.ID,
+		Currency:    s.currencies.BaseCurrency(),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Limit:       b.Limit,
+		Spent:       spent,
+	}
+
+	if b.Rollover {
+		previousStart, _ := b.Period.Bounds(start.Add(-time.Second))
+		previousSpent, err := s.spent(ctx, b.CategoryID, previousStart, start)
+		if err != nil {
+			return nil, err
+		}
+		if previousSpent < b.Limit {
+			status.RolledOver = b.Limit - previousSpent
+		}
+	}
+
+	available := status.Limit + status.RolledOver
+	status.Remaining = available - spent
+	status.OverLimit = spent > available
+	status.Percentage = math.Round(float64(spent)/float64(available)*10000) / 100
+
+	return status, nil
+}
+
+// spent sums the expenses of a category in [start, end) in the base
+// currency, whoever in the workspace made them, as budgets are shared by
+// the workspace.
+func (s *budgetServiceImpl) spent(ctx context.Context, categoryID string, start, end time.Time) (entities.Money, error) {
+	from, to := start.Unix(), end.Unix()-1
+	totals, err := s.currencies.ExpenseTotals(ctx, entities.ExpenseFilter{CategoryID: categoryID, From: &from, To: &to, AllOwners: true})
+	if err != nil {
+		return 0, err
+	}
+	return totals.Total, nil
+}
+
+// validate checks the fields of a budget and that its category exists.
+func (s *budgetServiceImpl) validate(ctx context.Context, b *entities.Budget) error {
+	if b.CategoryID == "" {
+		return fmt.Errorf("%w: category_id is required", ErrInvalidBudget)
+	}
+	if !b.Period.Valid() {
+		return fmt.Errorf("%w: period must be month, quarter or year", ErrInvalidBudget)
+	}
+	if b.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be positive", ErrInvalidBudget)
+	}
+
+	_, err := s.categories.GetByID(ctx, b.CategoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrUnknownCategory, b.CategoryID)
+	}
+	return err
+}
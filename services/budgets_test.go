@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_budgetServiceImpl_GetBudgetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBudgetRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+
+	at := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	spentIn := func(amount entities.Money) []entities.DailyTotal {
+		return []entities.DailyTotal{{Currency: "USD", Date: at, Amount: amount}}
+	}
+
+	tests := []struct {
+		name      string
+		budget    *entities.Budget
+		want      entities.BudgetStatus
+		setupMock func()
+	}{
+		{
+			name:   "GetBudgetStatus_Quarter",
+			budget: &entities.Budget{ID: "budget_1", CategoryID: "travel", Period: entities.BudgetPeriodQuarter, Limit: 10000},
+			want: entities.BudgetStatus{
+				PeriodStart: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				Limit:       10000,
+				Spent:       2500,
+				Remaining:   7500,
+				Percentage:  25,
+			},
+			setupMock: func() {
+				from, to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Unix()-1
+				mockExpenses.EXPECT().SumByCurrencyAndDate(gomock.Any(), entities.ExpenseFilter{CategoryID: "travel", From: &from, To: &to, AllOwners: true}).Return(spentIn(2500), nil)
+			},
+		},
+		{
+			name:   "GetBudgetStatus_RolloverOverLimit",
+			budget: &entities.Budget{ID: "budget_2", CategoryID: "food", Period: entities.BudgetPeriodMonth, Limit: 10000, Rollover: true},
+			want: entities.BudgetStatus{
+				PeriodStart: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				Limit:       10000,
+				RolledOver:  4000,
+				Spent:       15400,
+				Remaining:   -1400,
+				Percentage:  110,
+				OverLimit:   true,
+			},
+			setupMock: func() {
+				gomock.InOrder(
+					mockExpenses.EXPECT().SumByCurrencyAndDate(gomock.Any(), gomock.Any()).Return(spentIn(15400), nil),
+					// April, the previous period, left 40.00 unspent.
+					mockExpenses.EXPECT().SumByCurrencyAndDate(gomock.Any(), gomock.Any()).Return(spentIn(6000), nil),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetByID(gomock.Any(), tt.budget.ID).Return(tt.budget, nil)
+			tt.setupMock()
+			s := &budgetServiceImpl{
+				repo:       mockRepo,
+				currencies: &currencyServiceImpl{expenses: mockExpenses, baseCurrency: "USD"},
+			}
+			got, err := s.GetBudgetStatus(context.TODO(), tt.budget.ID, at)
+			if err != nil {
+				t.Fatalf("budgetServiceImpl.GetBudgetStatus() error = %v", err)
+			}
+			tt.want.BudgetID = tt.budget.ID
+			tt.want.Currency = "USD"
+			if *got != tt.want {
+				t.Errorf("budgetServiceImpl.GetBudgetStatus() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_budgetServiceImpl_CheckExpense(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBudgetRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+
+	at := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	budget := &entities.Budget{ID: "budget_1", CategoryID: "food", Period: entities.BudgetPeriodMonth, Limit: 10000}
+
+	tests := []struct {
+		name         string
+		spent        entities.Money
+		wantWarnings int
+	}{
+		{name: "CheckExpense_UnderLimit", spent: 9000, wantWarnings: 0},
+		{name: "CheckExpense_PushesOverLimit", spent: 12000, wantWarnings: 1},
+		{name: "CheckExpense_AlreadyOverLimit", spent: 16000, wantWarnings: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().List(gomock.Any(), "food").Return([]*entities.Budget{budget}, nil)
+			mockExpenses.EXPECT().SumByCurrencyAndDate(gomock.Any(), gomock.Any()).
+				Return([]entities.DailyTotal{{Currency: "USD", Date: at, Amount: tt.spent}}, nil)
+			s := &budgetServiceImpl{
+				repo:       mockRepo,
+				currencies: &currencyServiceImpl{expenses: mockExpenses, baseCurrency: "USD"},
+			}
+			e := &entities.Expense{ID: "expense_1", CategoryID: "food", Amount: 5000, Currency: "USD", DateCreation: at.Unix()}
+			got, err := s.CheckExpense(context.TODO(), e)
+			if err != nil {
+				t.Fatalf("budgetServiceImpl.CheckExpense() error = %v", err)
+			}
+			if len(got) != tt.wantWarnings {
+				t.Errorf("budgetServiceImpl.CheckExpense() = %+v, want %d warnings", got, tt.wantWarnings)
+			}
+		})
+	}
+}
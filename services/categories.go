@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// ErrInvalidCategory is returned when a category fails validation.
+var ErrInvalidCategory = errors.New("invalid category: name is required")
+
+// ErrCategoryExists is returned when another category of the workspace
+// has the same name.
+var ErrCategoryExists = repository.ErrCategoryExists
+
+// CategoryService defines the interface for category-related operations.
+type CategoryService interface {
+	CreateCategory(ctx context.Context, c *entities.Category) error
+	GetCategoryByID(ctx context.Context, id string) (*entities.Category, error)
+	ListCategories(ctx context.Context) ([]*entities.Category, error)
+	UpdateCategory(ctx context.Context, c *entities.Category) error
+	DeleteCategory(ctx context.Context, id string) error
+}
+
+type categoryServiceImpl struct {
+	repo repository.CategoryRepositoryInterface
+}
+
+// NewCategoryService creates a new instance of CategoryService.
+func NewCategoryService(repo repository.CategoryRepositoryInterface) CategoryService {
+	return &categoryServiceImpl{repo: repo}
+}
+
+// CreateCategory creates a new category.
+func (s *categoryServiceImpl) CreateCategory(ctx context.Context, c *entities.Category) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return ErrInvalidCategory
+	}
+
+	c.ID = generateID("category")
+	c.DateCreation = time.Now().Unix()
+
+	return s.repo.Create(ctx, c)
+}
+
+// GetCategoryByID retrieves a category by its ID.
+func (s *categoryServiceImpl) GetCategoryByID(ctx context.Context, id string) (*entities.Category, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListCategories retrieves all categories.
+func (s *categoryServiceImpl) ListCategories(ctx context.Context) ([]*entities.Category, error) {
+	return s.repo.List(ctx)
+}
+
+// UpdateCategory updates an existing category.
+func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, c *entities.Category) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return ErrInvalidCategory
+	}
+
+	_, err := s.repo.GetByID(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, c)
+}
+
+// DeleteCategory deletes a category by its ID.
+func (s *categoryServiceImpl) DeleteCategory(ctx context.Context, id string) error {
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
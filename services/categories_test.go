@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_categoryServiceImpl_CreateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCategoryRepositoryInterface(ctrl)
+
+	tests := []struct {
+		name      string
+		c         *entities.Category
+		wantErr   bool
+		setupMock func(*mocks.MockCategoryRepositoryInterface)
+	}{
+		{
+			name:    "CreateCategory_Success",
+			c:       &entities.Category{Name: "  Travel "},
+			wantErr: false,
+			setupMock: func(m *mocks.MockCategoryRepositoryInterface) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "CreateCategory_MissingName",
+			c:         &entities.Category{Name: "   "},
+			wantErr:   true,
+			setupMock: func(m *mocks.MockCategoryRepositoryInterface) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock(mockRepo)
+			s := &categoryServiceImpl{
+				repo: mockRepo,
+			}
+			if err := s.CreateCategory(context.TODO(), tt.c); (err != nil) != tt.wantErr {
+				t.Errorf("categoryServiceImpl.CreateCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (tt.c.ID == "" || tt.c.Name != "Travel") {
+				t.Errorf("categoryServiceImpl.CreateCategory() got %+v, want an ID and a trimmed name", tt.c)
+			}
+		})
+	}
+}
+
+func Test_categoryServiceImpl_DeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCategoryRepositoryInterface(ctrl)
+
+	tests := []struct {
+		name      string
+		id        string
+		wantErr   bool
+		setupMock func(*mocks.MockCategoryRepositoryInterface)
+	}{
+		{
+			name:    "DeleteCategory_Success",
+			id:      "category_1",
+			wantErr: false,
+			setupMock: func(m *mocks.MockCategoryRepositoryInterface) {
+				m.EXPECT().GetByID(gomock.Any(), "category_1").Return(&entities.Category{ID: "category_1"}, nil)
+				m.EXPECT().Delete(gomock.Any(), "category_1").Return(nil)
+			},
+		},
+		{
+			name:    "DeleteCategory_NotFound",
+			id:      "category_2",
+			wantErr: true,
+			setupMock: func(m *mocks.MockCategoryRepositoryInterface) {
+				m.EXPECT().GetByID(gomock.Any(), "category_2").Return(nil, fmt.Errorf("%w: category_2", repository.ErrNotFound))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock(mockRepo)
+			s := &categoryServiceImpl{
+				repo: mockRepo,
+			}
+			if err := s.DeleteCategory(context.TODO(), tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("categoryServiceImpl.DeleteCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// RateFormat is a file format exchange rates can be imported from.
+type RateFormat string
+
+const (
+	// RateFormatECB is the ECB euro foreign exchange reference rates XML
+	// (eurofxref-daily.xml, eurofxref-hist.xml and friends).
+	RateFormatECB RateFormat = "ecb"
+	// RateFormatCSV is a CSV file with a date,currency,rate header and one
+	// rate per row, quoted per EUR like the ECB ones.
+	RateFormatCSV RateFormat = "csv"
+)
+
+// ErrInvalidRates is returned when an exchange rates file cannot be parsed.
+var ErrInvalidRates = errors.New("invalid exchange rates")
+
+// ErrMissingRate is returned when no exchange rate is known for a currency
+// on or before the date of an expense.
+var ErrMissingRate = errors.New("missing exchange rate")
+
+// CurrencyService defines the interface for exchange rates and conversions
+// to the base currency.
+type CurrencyService interface {
+	BaseCurrency() string
+	Convert(ctx context.Context, amount entities.Money, currency string, on time.Time) (entities.Money, error)
+	ImportRates(ctx context.Context, r io.Reader, format RateFormat) (int, error)
+	ExpenseTotals(ctx context.Context, f entities.ExpenseFilter) (*entities.ExpenseTotals, error)
+}
+
+type currencyServiceImpl struct {
+	rates        repository.ExchangeRateRepositoryInterface
+	expenses     repository.ExpenseRepositoryInterface
+	baseCurrency string
+}
+
+// NewCurrencyService creates a new instance of CurrencyService converting
+// to baseCurrency.
+func NewCurrencyService(rates repository.ExchangeRateRepositoryInterface, expenses repository.ExpenseRepositoryInterface, baseCurrency string) CurrencyService {
+	return &currencyServiceImpl{rates: rates, expenses: expenses, baseCurrency: baseCurrency}
+}
+
+// BaseCurrency returns the currency totals are converted to.
+func (s *currencyServiceImpl) BaseCurrency() string {
+	return s.baseCurrency
+}
+
+// Convert converts amount from currency to the base currency at the rates
+// in effect on the given date, rounding half away from zero to the cent.
+func (s *currencyServiceImpl) Convert(ctx context.Context, amount entities.Money, currency string, on time.Time) (entities.Money, error) {
+	return s.convert(ctx, amount, currency, on, nil)
+}
+
+// ImportRates parses an exchange rates file and stores every rate in it.
+// It returns how many rates were stored.
+func (s *currencyServiceImpl) ImportRates(ctx context.Context, r io.Reader, format RateFormat) (int, error) {
+	var (
+		rates []entities.ExchangeRate
+		err   error
+	)
+	switch format {
+	case RateFormatECB:
+		rates, err = parseECBRates(r)
+	case RateFormatCSV:
+		rates, err = parseCSVRates(r)
+	default:
+		return 0, fmt.Errorf("%w: unknown format %q", ErrInvalidRates, format)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(rates) == 0 {
+		return 0, fmt.Errorf("%w: no rates found", ErrInvalidRates)
+	}
+
+	if err := s.rates.Upsert(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+// ExpenseTotals sums the expenses matching the given filter per currency
+// and converted to the base currency, each day at that day's rate.
+func (s *currencyServiceImpl) ExpenseTotals(ctx context.Context, f entities.ExpenseFilter) (*entities.ExpenseTotals, error) {
+	daily, err := s.expenses.SumByCurrencyAndDate(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := &entities.ExpenseTotals{Currency: s.baseCurrency, ByCurrency: []entities.CurrencyTotal{}}
+	cache := make(map[string]*big.Rat)
+	for _, d := range daily {
+		converted, err := s.convert(ctx, d.Amount, d.Currency, d.Date, cache)
+		if err != nil {
+			return nil, err
+		}
+		totals.Total += converted
+
+		// daily is ordered by currency, so each currency is contiguous.
+		n := len(totals.ByCurrency)
+		if n == 0 || totals.ByCurrency[n-1].Currency != d.Currency {
+			totals.ByCurrency = append(totals.ByCurrency, entities.CurrencyTotal{Currency: d.Currency})
+			n++
+		}
+		totals.ByCurrency[n-1].Amount += d.Amount
+	}
+
+	return totals, nil
+}
+
+// convert converts amount to the base currency, memoizing the rates it
+// looks up in cache when one is given.
+func (s *currencyServiceImpl) convert(ctx context.Context, amount entities.Money, currency string, on time.Time, cache map[string]*big.Rat) (entities.Money, error) {
+	if currency == s.baseCurrency {
+		return amount, nil
+	}
+
+	from, err := s.rateOn(ctx, currency, on, cache)
+	if err != nil {
+		return 0, err
+	}
+	to, err := s.rateOn(ctx, s.baseCurrency, on, cache)
+	if err != nil {
+		return 0, err
+	}
+
+	// Rates are quoted per EUR, so amount / from is in EUR and * to in base.
+	v := new(big.Rat).SetInt64(int64(amount))
+	v.Mul(v, to)
+	v.Quo(v, from)
+	return roundToMoney(v), nil
+}
+
+// rateOn returns the rate of currency on the given date.
+func (s *currencyServiceImpl) rateOn(ctx context.Context, currency string, on time.Time, cache map[string]*big.Rat) (*big.Rat, error) {
+	if currency == entities.ReferenceCurrency {
+		return big.NewRat(1, 1), nil
+	}
+
+	key := currency + on.Format("2006-01-02")
+	if rate, ok := cache[key]; ok {
+		return rate, nil
+	}
+
+	stored, err := s.rates.RateOn(ctx, currency, on)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s on %s", ErrMissingRate, currency, on.Format("2006-01-02"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := new(big.Rat).SetString(stored.Rate)
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: stored %s rate %q", ErrInvalidRates, currency, stored.Rate)
+	}
+
+	if cache != nil {
+		cache[key] = rate
+	}
+	return rate, nil
+}
+
+// roundToMoney rounds v, expressed in minor units, half away from zero.
+func roundToMoney(v *big.Rat) entities.Money {
+	num, den := new(big.Int).Set(v.Num()), v.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return entities.Money(q.Int64())
+}
+
+// parseECBRates parses the ECB euro foreign exchange reference rates XML.
+func parseECBRates(r io.Reader) ([]entities.ExchangeRate, error) {
+	var envelope struct {
+		Cube struct {
+			Days []struct {
+				Time  string `xml:"time,attr"`
+				Rates []struct {
+					Currency string `xml:"currency,attr"`
+					Rate     string `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+
+	var rates []entities.ExchangeRate
+	for _, day := range envelope.Cube.Days {
+		for _, rate := range day.Rates {
+			parsed, err := newExchangeRate(day.Time, rate.Currency, rate.Rate)
+			if err != nil {
+				return nil, err
+			}
+			rates = append(rates, parsed)
+		}
+	}
+	return rates, nil
+}
+
+// parseCSVRates parses a CSV file with a date,currency,rate header.
+func parseCSVRates(r io.Reader) ([]entities.ExchangeRate, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "currency", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidRates, name)
+		}
+	}
+
+	rates := make([]entities.ExchangeRate, 0, len(records)-1)
+	for _, record := range records[1:] {
+		rate, err := newExchangeRate(record[columns["date"]], record[columns["currency"]], record[columns["rate"]])
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// newExchangeRate validates the textual fields of a rate.
+func newExchangeRate(date, currency, rate string) (entities.ExchangeRate, error) {
+	day, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil {
+		return entities.ExchangeRate{}, fmt.Errorf("%w: date %q", ErrInvalidRates, date)
+	}
+	code, err := entities.NormalizeCurrency(currency)
+	if err != nil {
+		return entities.ExchangeRate{}, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+	rate = strings.TrimSpace(rate)
+	// big.Rat also accepts fractions and exponents the NUMERIC column does not.
+	if v, ok := new(big.Rat).SetString(rate); !ok || v.Sign() <= 0 || strings.ContainsAny(rate, "/eE") {
+		return entities.ExchangeRate{}, fmt.Errorf("%w: %s rate %q", ErrInvalidRates, code, rate)
+	}
+	return entities.ExchangeRate{Currency: code, Date: day, Rate: rate}, nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+const ecbDaily = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-03-01">
+			<Cube currency="USD" rate="1.0830"/>
+			<Cube currency="MXN" rate="18.4860"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func Test_parseECBRates(t *testing.T) {
+	rates, err := parseECBRates(strings.NewReader(ecbDaily))
+	if err != nil {
+		t.Fatalf("parseECBRates() error = %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("parseECBRates() returned %d rates, want 2", len(rates))
+	}
+	want := entities.ExchangeRate{Currency: "MXN", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Rate: "18.4860"}
+	if rates[1] != want {
+		t.Errorf("parseECBRates() = %+v, want %+v", rates[1], want)
+	}
+}
+
+func Test_parseCSVRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		wantLen int
+		wantErr bool
+	}{
+		{name: "Valid", csv: "date,currency,rate\n2024-03-01,usd,1.083\n2024-03-01,MXN,18.486\n", wantLen: 2},
+		{name: "ReorderedColumns", csv: "rate,date,currency\n1.083,2024-03-01,USD\n", wantLen: 1},
+		{name: "MissingColumn", csv: "date,rate\n2024-03-01,1.083\n", wantErr: true},
+		{name: "UnknownCurrency", csv: "date,currency,rate\n2024-03-01,ABC,1.0\n", wantErr: true},
+		{name: "NegativeRate", csv: "date,currency,rate\n2024-03-01,USD,-1.0\n", wantErr: true},
+		{name: "BadDate", csv: "date,currency,rate\n01/03/2024,USD,1.0\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := parseCSVRates(strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCSVRates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRates) {
+				t.Errorf("parseCSVRates() error = %v, want ErrInvalidRates", err)
+			}
+			if len(rates) != tt.wantLen {
+				t.Errorf("parseCSVRates() returned %d rates, want %d", len(rates), tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_currencyServiceImpl_ExpenseTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRates := mocks.NewMockExchangeRateRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(currency, value string) *entities.ExchangeRate {
+		return &entities.ExchangeRate{Currency: currency, Date: day, Rate: value}
+	}
+
+	tests := []struct {
+		name      string
+		wantTotal entities.Money
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name:      "ExpenseTotals_Success",
+			wantTotal: 1000 + 1083 + 108, // 10 USD, 10 EUR and 18.48 MXN
+			setupMock: func() {
+				mockExpenses.EXPECT().SumByCurrencyAndDate(gomock.Any(), gomock.Any()).Return([]entities.DailyTotal{
+					{Currency: "EUR", Date: day, Amount: 1000},
+					{Currency: "MXN", Date: day, Amount: 1848},
+					{Currency: "USD", Date: day, Amount: 1000},
+				}, nil)
+				// The USD rate is looked up once thanks to the cache.
+				mockRates.EXPECT().RateOn(gomock.Any(), "USD", day).Return(rate("USD", "1.0830"), nil)
+				mockRates.EXPECT().RateOn(gomock.Any(), "MXN", day).Return(rate("MXN", "18.4860"), nil)
+			},
+		},
+		{
+			name:    "ExpenseTotals_MissingRate",
+			wantErr: ErrMissingRate,
+			setupMock: func() {
+				mockExpenses.EXPECT().SumByCurrencyAndDate(gomock.Any(), gomock.Any()).Return([]entities.DailyTotal{
+					{Currency: "MXN", Date: day, Amount: 1000},
+				}, nil)
+				mockRates.EXPECT().RateOn(gomock.Any(), "MXN", day).Return(nil, repository.ErrNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &currencyServiceImpl{
+				rates:        mockRates,
+				expenses:     mockExpenses,
+				baseCurrency: "USD",
+			}
+			got, err := s.ExpenseTotals(context.TODO(), entities.ExpenseFilter{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("currencyServiceImpl.ExpenseTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("currencyServiceImpl.ExpenseTotals() total = %s, want %s", got.Total, tt.wantTotal)
+			}
+			if len(got.ByCurrency) != 3 {
+				t.Errorf("currencyServiceImpl.ExpenseTotals() by currency = %+v, want 3 currencies", got.ByCurrency)
+			}
+		})
+	}
+}
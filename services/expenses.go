@@ -2,37 +2,168 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/demo-talent/auth"
 	"github.com/demo-talent/entities"
 	"github.com/demo-talent/repository"
+	"github.com/demo-talent/storage"
+)
+
+// ErrNotFound is returned when the requested expense or category does not
+// exist.
+var ErrNotFound = repository.ErrNotFound
+
+// ErrUnknownCategory is returned when an expense refers to a category that
+// does not exist.
+var ErrUnknownCategory = errors.New("unknown category")
+
+// ErrInvalidCurrency is returned when an expense currency is not an ISO
+// 4217 code.
+var ErrInvalidCurrency = entities.ErrInvalidCurrency
+
+// ErrInvalidTag is returned when a tag is blank or too long.
+var ErrInvalidTag = errors.New("invalid tag")
+
+// maxTagLength matches the size of the tags.name column.
+const maxTagLength = 64
+
+// ErrInvalidTransition is returned when the approval workflow does not
+// allow moving an expense to the requested status.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// ErrExpenseLocked is returned when updating or deleting an expense that
+// was approved.
+var ErrExpenseLocked = errors.New("expense locked")
+
+// ErrVersionConflict is returned when updating or deleting an expense
+// that changed since the version the request was based on.
+var ErrVersionConflict = repository.ErrVersionConflict
+
+// ErrSplitMismatch is returned when changing the amount of a split
+// expense; its splits have to be set for the new amount, or removed, first.
+var ErrSplitMismatch = repository.ErrSplitMismatch
+
+// ErrReasonRequired is returned when rejecting an expense without a
+// reason.
+var ErrReasonRequired = errors.New("reason required")
+
+// ErrInvalidFilter is returned when a listing filter or page request is
+// contradictory or out of range.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+const (
+	// DefaultPageLimit is the page size used when none is requested.
+	DefaultPageLimit = 50
+	// MaxPageLimit is the largest page size a client may request.
+	MaxPageLimit = 200
 )
 
 // ExpenseService defines the interface for expense-related operations.
 type ExpenseService interface {
 	CreateExpense(ctx context.Context, e *entities.Expense) error
+	ImportExpense(ctx context.Context, e *entities.Expense) error
 	GetExpenseByID(ctx context.Context, id string) (*entities.Expense, error)
+	ListExpenses(ctx context.Context, f entities.ExpenseFilter, p entities.PageRequest) (*entities.ExpensePage, error)
+	ExportExpenses(ctx context.Context, f entities.ExpenseFilter, fn func(*entities.Expense) error) error
 	UpdateExpense(ctx context.Context, e *entities.Expense) error
-	DeleteExpense(ctx context.Context, id string) error
+	DeleteExpense(ctx context.Context, id string, version int) error
+	SubmitExpense(ctx context.Context, id string) (*entities.Expense, error)
+	ApproveExpense(ctx context.Context, id string) (*entities.Expense, error)
+	RejectExpense(ctx context.Context, id, reason string) (*entities.Expense, error)
+	ReimburseExpense(ctx context.Context, id string) (*entities.Expense, error)
+	ListPendingApprovals(ctx context.Context) ([]*entities.Expense, error)
+	RestoreExpense(ctx context.Context, id string) (*entities.Expense, error)
+	ListTrash(ctx context.Context) ([]*entities.Expense, error)
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+	ListExpenseRevisions(ctx context.Context, id string) ([]*entities.ExpenseRevision, error)
+	GetExpenseRevision(ctx context.Context, id string, revision int) (*entities.ExpenseRevision, error)
+	RevertExpense(ctx context.Context, id string, revision, version int) (*entities.Expense, error)
+	RunBatch(ctx context.Context, b *entities.ExpenseBatch) (*entities.ExpenseBatchResult, error)
 }
 
 type expenseServiceImpl struct {
-	repo repository.ExpenseRepositoryInterface
+	repo            repository.ExpenseRepositoryInterface
+	units           repository.UnitOfWorkFactory
+	categories      repository.CategoryRepositoryInterface
+	budgets         BudgetChecker
+	audit           Auditor
+	blobs           storage.BlobStore
+	defaultCurrency string
 }
 
-// NewExpenseService creates a new instance of ExpenseService.
-func NewExpenseService(repo repository.ExpenseRepositoryInterface) ExpenseService {
-	return &expenseServiceImpl{repo: repo}
+// NewExpenseService creates a new instance of ExpenseService. Each change
+// runs in a transaction from units, along with its audit events. Expenses
+// created without a currency are recorded in defaultCurrency, new
+// expenses are checked against budgets when it is not nil, and every
+// change is recorded with audit when it is not nil. Purging the trash
+// deletes from blobs the attachment bytes nothing refers to anymore.
+func NewExpenseService(repo repository.ExpenseRepositoryInterface, units repository.UnitOfWorkFactory, categories repository.CategoryRepositoryInterface, budgets BudgetChecker, audit Auditor, blobs storage.BlobStore, defaultCurrency string) ExpenseService {
+	return &expenseServiceImpl{repo: repo, units: units, categories: categories, budgets: budgets, audit: audit, blobs: blobs, defaultCurrency: defaultCurrency}
 }
 
-// CreateExpense creates a new expense.
+// CreateExpense creates a new expense. Budgets it pushes over their limit
+// are reported in e.Warnings; failing to check them does not fail the
+// creation.
 func (s *expenseServiceImpl) CreateExpense(ctx context.Context, e *entities.Expense) error {
+	e.ExternalRef = ""
+	return s.create(ctx, e, time.Now().Unix())
+}
+
+// ImportExpense creates an expense that was recorded elsewhere first, such
+// as on a bank statement, keeping its DateCreation and ExternalRef. It
+// fails with ErrAlreadyImported when an expense has the same ExternalRef.
+func (s *expenseServiceImpl) ImportExpense(ctx context.Context, e *entities.Expense) error {
+	return s.create(ctx, e, e.DateCreation)
+}
+
+// create creates a new expense dated at the given Unix time.
+func (s *expenseServiceImpl) create(ctx context.Context, e *entities.Expense, date int64) error {
+	if err := s.checkCategory(ctx, e.CategoryID); err != nil {
+		return err
+	}
+	tags, err := normalizeTags(e.Tags)
+	if err != nil {
+		return err
+	}
+	e.Tags = tags
+	if e.Currency == "" {
+		e.Currency = s.defaultCurrency
+	}
+	if e.Currency, err = entities.NormalizeCurrency(e.Currency); err != nil {
+		return err
+	}
+
 	e.ID = generateUniqueID()
 
-	e.DateCreation = time.Now().Unix()
+	e.DateCreation = date
+	e.Status, e.StatusReason = entities.StatusDraft, ""
+	e.Version = 1
 
-	return s.repo.Create(ctx, e)
+	err = s.inUnit(ctx, func(tx *expenseServiceImpl) error {
+		if err := tx.repo.Create(ctx, e); err != nil {
+			return err
+		}
+		return tx.record(ctx, e.ID, entities.AuditCreate, nil, e)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.budgets != nil {
+		warnings, err := s.budgets.CheckExpense(ctx, e)
+		if err != nil {
+			log.Printf("Error checking budgets for expense %s: %v", e.ID, err)
+		}
+		e.Warnings = warnings
+	}
+	return nil
 }
 
 // GetExpenseByID retrieves an expense by its ID.
@@ -40,27 +171,433 @@ func (s *expenseServiceImpl) GetExpenseByID(ctx context.Context, id string) (*en
 	return s.repo.GetByID(ctx, id)
 }
 
-// UpdateExpense updates an existing expense.
+// ListExpenses retrieves a page of the expenses matching the given filter.
+func (s *expenseServiceImpl) ListExpenses(ctx context.Context, f entities.ExpenseFilter, p entities.PageRequest) (*entities.ExpensePage, error) {
+	if err := validateFilter(&f); err != nil {
+		return nil, err
+	}
+	if p.Limit == 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit < 0 || p.Limit > MaxPageLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, MaxPageLimit)
+	}
+	if p.Sort.Field == "" {
+		p.Sort = entities.DefaultSort
+	}
+
+	page, err := s.repo.List(ctx, f, p)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return page, err
+}
+
+// ExportExpenses calls fn with every expense matching the given filter,
+// oldest first, as they are read from the database. It stops at the first
+// error fn returns.
+func (s *expenseServiceImpl) ExportExpenses(ctx context.Context, f entities.ExpenseFilter, fn func(*entities.Expense) error) error {
+	if err := validateFilter(&f); err != nil {
+		return err
+	}
+	return s.repo.Export(ctx, f, fn)
+}
+
+// validateFilter checks a listing filter and normalizes its tags and
+// currency.
+func validateFilter(f *entities.ExpenseFilter) error {
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		return fmt.Errorf("%w: min_amount is greater than max_amount", ErrInvalidFilter)
+	}
+	if f.From != nil && f.To != nil && *f.From > *f.To {
+		return fmt.Errorf("%w: from is after to", ErrInvalidFilter)
+	}
+	tags, err := normalizeTags(f.Tags)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	f.Tags = tags
+	if f.Currency != "" {
+		if f.Currency, err = entities.NormalizeCurrency(f.Currency); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+		}
+	}
+	if f.Status != "" && !f.Status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, f.Status)
+	}
+	switch f.TagMatch {
+	case "", entities.TagMatchAny, entities.TagMatchAll:
+	default:
+		return fmt.Errorf("%w: tags_match must be any or all", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// UpdateExpense updates an existing expense that is not locked by its
+// approval, if it is still at e.Version, and moves e.Version to the next
+// version. A zero e.Version updates whatever version is current. The
+// status is left untouched.
 func (s *expenseServiceImpl) UpdateExpense(ctx context.Context, e *entities.Expense) error {
-	_, err := s.repo.GetByID(ctx, e.ID)
+	current, err := s.repo.GetByID(ctx, e.ID)
 	if err != nil {
 		return err
 	}
+	if current.Status.Locked() {
+		return fmt.Errorf("%w: %s expenses cannot change", ErrExpenseLocked, current.Status)
+	}
+	if e.Version == 0 {
+		e.Version = current.Version
+	}
+	if e.Version != current.Version {
+		return fmt.Errorf("%w: expense %s is at version %d, not %d", ErrVersionConflict, e.ID, current.Version, e.Version)
+	}
+	e.Status, e.StatusReason = current.Status, current.StatusReason
+	e.ExternalRef = current.ExternalRef
+	if e.Currency == "" {
+		e.Currency = current.Currency
+	}
+	if e.Currency, err = entities.NormalizeCurrency(e.Currency); err != nil {
+		return err
+	}
+	if err := s.checkCategory(ctx, e.CategoryID); err != nil {
+		return err
+	}
+	// Nil tags leave the stored tags untouched, so only normalize a given list.
+	if e.Tags != nil {
+		if e.Tags, err = normalizeTags(e.Tags); err != nil {
+			return err
+		}
+	}
 
-	return s.repo.Update(ctx, e)
+	return s.inUnit(ctx, func(tx *expenseServiceImpl) error {
+		if err := tx.repo.Update(ctx, e); err != nil {
+			return err
+		}
+		updated := *current
+		updated.Description, updated.Amount, updated.Currency, updated.CategoryID = e.Description, e.Amount, e.Currency, e.CategoryID
+		updated.Version = e.Version
+		if e.Tags != nil {
+			updated.Tags = e.Tags
+		}
+		return tx.record(ctx, e.ID, entities.AuditUpdate, current, &updated)
+	})
 }
 
-// DeleteExpense deletes an expense by its ID.
-func (s *expenseServiceImpl) DeleteExpense(ctx context.Context, id string) error {
-	_, err := s.repo.GetByID(ctx, id)
+// DeleteExpense moves an expense that is not locked by its approval to the
+// trash, from which it can be restored until it is purged, if it is still
+// at the given version. A zero version deletes whatever version is
+// current.
+func (s *expenseServiceImpl) DeleteExpense(ctx context.Context, id string, version int) error {
+	current, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if current.Status.Locked() {
+		return fmt.Errorf("%w: %s expenses cannot be deleted", ErrExpenseLocked, current.Status)
+	}
+	if version == 0 {
+		version = current.Version
+	}
+	if version != current.Version {
+		return fmt.Errorf("%w: expense %s is at version %d, not %d", ErrVersionConflict, id, current.Version, version)
+	}
 
-	return s.repo.Delete(ctx, id)
+	return s.inUnit(ctx, func(tx *expenseServiceImpl) error {
+		if err := tx.repo.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return tx.record(ctx, id, entities.AuditDelete, current, nil)
+	})
+}
+
+// SubmitExpense submits a draft or rejected expense of the user in ctx for
+// approval.
+func (s *expenseServiceImpl) SubmitExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	e, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, e, entities.StatusSubmitted, "", entities.AuditSubmit)
+}
+
+// ApproveExpense approves a submitted expense of another member of the
+// workspace in ctx.
+func (s *expenseServiceImpl) ApproveExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	e, err := s.review(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, e, entities.StatusApproved, "", entities.AuditApprove)
+}
+
+// RejectExpense sends a submitted expense of another member of the
+// workspace in ctx back to its owner, who may fix and submit it again.
+func (s *expenseServiceImpl) RejectExpense(ctx context.Context, id, reason string) (*entities.Expense, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: rejecting an expense needs a reason", ErrReasonRequired)
+	}
+	e, err := s.review(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, e, entities.StatusRejected, reason, entities.AuditReject)
+}
+
+// ReimburseExpense records that an approved expense of another member of
+// the workspace in ctx was paid back.
+func (s *expenseServiceImpl) ReimburseExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	e, err := s.review(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(ctx, e, entities.StatusReimbursed, "", entities.AuditReimburse)
+}
+
+// ListPendingApprovals retrieves the submitted expenses of the workspace
+// in ctx, oldest first.
+func (s *expenseServiceImpl) ListPendingApprovals(ctx context.Context) ([]*entities.Expense, error) {
+	return s.repo.ListByStatus(ctx, entities.StatusSubmitted)
+}
+
+// ListExpenseRevisions retrieves the prior versions of an expense, oldest
+// first.
+func (s *expenseServiceImpl) ListExpenseRevisions(ctx context.Context, id string) ([]*entities.ExpenseRevision, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRevisions(ctx, id)
+}
+
+// GetExpenseRevision retrieves a prior version of an expense by its
+// number.
+func (s *expenseServiceImpl) GetExpenseRevision(ctx context.Context, id string, revision int) (*entities.ExpenseRevision, error) {
+	return s.repo.GetRevision(ctx, id, revision)
+}
+
+// RevertExpense updates an expense back to the description, amount,
+// currency, category and tags of one of its revisions, if it is still at
+// version; a zero version reverts whatever version is current. Like any
+// update, it keeps the version it replaces as a new revision and leaves
+// the status untouched.
+func (s *expenseServiceImpl) RevertExpense(ctx context.Context, id string, revision, version int) (*entities.Expense, error) {
+	rev, err := s.repo.GetRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	tags := rev.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	e := &entities.Expense{
+		ID:          id,
+		Description: rev.Description,
+		Amount:      rev.Amount,
+		Currency:    rev.Currency,
+		CategoryID:  rev.CategoryID,
+		Tags:        tags,
+		Version:     version,
+	}
+	if err := s.UpdateExpense(ctx, e); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// RestoreExpense takes an expense of the user in ctx out of the trash.
+func (s *expenseServiceImpl) RestoreExpense(ctx context.Context, id string) (*entities.Expense, error) {
+	var e *entities.Expense
+	err := s.inUnit(ctx, func(tx *expenseServiceImpl) error {
+		if err := tx.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		var err error
+		if e, err = tx.repo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return tx.record(ctx, id, entities.AuditRestore, nil, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ListTrash retrieves the deleted expenses of the user in ctx, most
+// recently deleted first.
+func (s *expenseServiceImpl) ListTrash(ctx context.Context) ([]*entities.Expense, error) {
+	return s.repo.ListDeleted(ctx)
+}
+
+// PurgeTrash removes for good the expenses of every user deleted before
+// the given time and returns how many it removed. Their audit log stays.
+// The bytes of their attachments are deleted after the rows, unless other
+// attachments have the same content; failing to delete some is reported
+// once the others are deleted.
+func (s *expenseServiceImpl) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	purged, orphans, err := s.repo.Purge(ctx, before.Unix())
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	for _, hash := range orphans {
+		if err := s.blobs.Delete(ctx, hash); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting attachment blob %s: %w", hash, err))
+		}
+	}
+	return purged, errors.Join(errs...)
+}
+
+// RunTrashPurger purges every interval, until ctx is done, the expenses
+// that have been in the trash for longer than retention.
+func RunTrashPurger(ctx context.Context, svc ExpenseService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := svc.PurgeTrash(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Error purging deleted expenses: %v", err)
+		}
+		if purged > 0 {
+			log.Printf("Purged %d deleted expenses", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// review returns an expense of the workspace in ctx for the user in ctx to
+// decide on. Nobody decides on their own expenses.
+func (s *expenseServiceImpl) review(ctx context.Context, id string) (*entities.Expense, error) {
+	user, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.repo.GetInWorkspace(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.OwnerID == user {
+		return nil, fmt.Errorf("%w: expenses are reviewed by somebody other than their owner", auth.ErrForbidden)
+	}
+	return e, nil
+}
+
+// transition moves e to status to if the workflow allows it, recording
+// the move as action.
+func (s *expenseServiceImpl) transition(ctx context.Context, e *entities.Expense, to entities.ExpenseStatus, reason string, action entities.AuditAction) (*entities.Expense, error) {
+	if !e.Status.CanMoveTo(to) {
+		return nil, fmt.Errorf("%w: a %s expense cannot become %s", ErrInvalidTransition, e.Status, to)
+	}
+	before := *e
+	err := s.inUnit(ctx, func(tx *expenseServiceImpl) error {
+		version, err := tx.repo.SetStatus(ctx, e.ID, e.Status, to, reason)
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: expense %s changed meanwhile", ErrInvalidTransition, e.ID)
+		}
+		if err != nil {
+			return err
+		}
+		e.Status, e.StatusReason, e.Version = to, reason, version
+		return tx.record(ctx, e.ID, action, &before, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// inUnit runs fn in a new unit of work, committed once fn succeeds, so
+// that a change and its audit event are saved together or not at all. fn
+// gets a copy of s working in the unit of work, or s itself when it
+// already works in one, as in a batch.
+func (s *expenseServiceImpl) inUnit(ctx context.Context, fn func(tx *expenseServiceImpl) error) error {
+	if s.units == nil {
+		return fn(s)
+	}
+	uow, err := s.units.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer uow.Rollback()
+
+	if err := fn(s.withUnit(uow)); err != nil {
+		return err
+	}
+	return uow.Commit()
+}
+
+// withUnit returns a copy of s making and recording its changes in uow.
+// The copy checks no budgets.
+func (s *expenseServiceImpl) withUnit(uow repository.UnitOfWork) *expenseServiceImpl {
+	tx := &expenseServiceImpl{repo: uow.Expenses(), categories: s.categories, defaultCurrency: s.defaultCurrency}
+	if s.audit != nil {
+		tx.audit = s.audit.InUnit(uow)
+	}
+	return tx
+}
+
+// record adds a change of an expense to the audit log, if there is one.
+// Nil before or after stand for a created or deleted expense.
+func (s *expenseServiceImpl) record(ctx context.Context, id string, action entities.AuditAction, before, after *entities.Expense) error {
+	if s.audit == nil {
+		return nil
+	}
+	return s.audit.Record(ctx, entities.AuditEntityExpense, id, action, auditedExpense(before), auditedExpense(after))
+}
+
+// checkCategory verifies that the category an expense refers to exists.
+// Uncategorized expenses are always valid.
+func (s *expenseServiceImpl) checkCategory(ctx context.Context, categoryID string) error {
+	if categoryID == "" {
+		return nil
+	}
+	_, err := s.categories.GetByID(ctx, categoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrUnknownCategory, categoryID)
+	}
+	return err
+}
+
+// normalizeTags trims and lowercases tags and drops duplicates, keeping
+// the first occurrence order. Nil stays nil.
+func normalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > maxTagLength {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
 }
 
 // generateUniqueID generates a new unique ID for an expense.
 func generateUniqueID() string {
-	return fmt.Sprintf("expense_%d", time.Now().UnixNano())
+	return generateID("expense")
+}
+
+// generateID generates a new unique ID with the given prefix. The clock
+// alone repeats within tight loops such as batches and imports, and on
+// platforms with a coarse clock, so the time is followed by random bits.
+func generateID(prefix string) string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("error generating ID: %v", err))
+	}
+	return fmt.Sprintf("%s_%d_%s", prefix, time.Now().UnixNano(), hex.EncodeToString(b))
 }
@@ -2,10 +2,18 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/demo-talent/auth"
 	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
 	"github.com/demo-talent/repository/mocks"
+	storagemocks "github.com/demo-talent/storage/mocks"
 	"github.com/golang/mock/gomock"
 )
 
@@ -14,9 +22,11 @@ func Test_expenseServiceImpl_CreateExpense(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockCategories := mocks.NewMockCategoryRepositoryInterface(ctrl)
 
 	type fields struct {
-		repo *mocks.MockExpenseRepositoryInterface
+		repo       *mocks.MockExpenseRepositoryInterface
+		categories *mocks.MockCategoryRepositoryInterface
 	}
 	type args struct {
 		ctx context.Context
@@ -43,12 +53,41 @@ func Test_expenseServiceImpl_CreateExpense(t *testing.T) {
 				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil) // Expect the Create method to be called once with any arguments and to return nil
 			},
 		},
+		{
+			name: "CreateExpense_InvalidCurrency",
+			fields: fields{
+				repo: mockRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				e:   &entities.Expense{Description: "Test expense", Currency: "XYZ"},
+			},
+			wantErr:   true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {},
+		},
+		{
+			name: "CreateExpense_UnknownCategory",
+			fields: fields{
+				repo:       mockRepo,
+				categories: mockCategories,
+			},
+			args: args{
+				ctx: context.TODO(),
+				e:   &entities.Expense{Description: "Test expense", CategoryID: "missing"},
+			},
+			wantErr: true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {
+				mockCategories.EXPECT().GetByID(gomock.Any(), "missing").Return(nil, repository.ErrNotFound) // Create must not be reached
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock(tt.fields.repo) // Setup the mock expectations
 			s := &expenseServiceImpl{
-				repo: tt.fields.repo,
+				repo:            tt.fields.repo,
+				categories:      tt.fields.categories,
+				defaultCurrency: "USD",
 			}
 			if err := s.CreateExpense(tt.args.ctx, tt.args.e); (err != nil) != tt.wantErr {
 				t.Errorf("expenseServiceImpl.CreateExpense() error = %v, wantErr %v", err, tt.wantErr)
@@ -56,3 +95,674 @@ func Test_expenseServiceImpl_CreateExpense(t *testing.T) {
 		})
 	}
 }
+
+func Test_expenseServiceImpl_ListExpenses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+
+	min, max := entities.Money(5000), entities.Money(1000)
+	from, to := int64(200), int64(100)
+
+	type args struct {
+		ctx context.Context
+		f   entities.ExpenseFilter
+		p   entities.PageRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantLen   int
+		wantErr   bool
+		setupMock func(*mocks.MockExpenseRepositoryInterface)
+	}{
+		{
+			name: "ListExpenses_Success",
+			args: args{
+				ctx: context.TODO(),
+				f:   entities.ExpenseFilter{Description: "lunch"},
+			},
+			wantLen: 2,
+			wantErr: false,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {
+				// Missing limit and sort fall back to the defaults
+				m.EXPECT().List(gomock.Any(), entities.ExpenseFilter{Description: "lunch"}, entities.PageRequest{Limit: DefaultPageLimit, Sort: entities.DefaultSort}).
+					Return(&entities.ExpensePage{Items: []*entities.Expense{{ID: "1"}, {ID: "2"}}}, nil)
+			},
+		},
+		{
+			name: "ListExpenses_InvalidAmountRange",
+			args: args{
+				ctx: context.TODO(),
+				f:   entities.ExpenseFilter{MinAmount: &min, MaxAmount: &max},
+			},
+			wantErr:   true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {},
+		},
+		{
+			name: "ListExpenses_InvalidDateRange",
+			args: args{
+				ctx: context.TODO(),
+				f:   entities.ExpenseFilter{From: &from, To: &to},
+			},
+			wantErr:   true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {},
+		},
+		{
+			name: "ListExpenses_AllTags",
+			args: args{
+				ctx: context.TODO(),
+				f:   entities.ExpenseFilter{Tags: []string{" Trip ", "client-a", "trip"}, TagMatch: entities.TagMatchAll},
+			},
+			wantLen: 1,
+			wantErr: false,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {
+				want := entities.ExpenseFilter{Tags: []string{"trip", "client-a"}, TagMatch: entities.TagMatchAll}
+				m.EXPECT().List(gomock.Any(), want, gomock.Any()).Return(&entities.ExpensePage{Items: []*entities.Expense{{ID: "1"}}}, nil)
+			},
+		},
+		{
+			name: "ListExpenses_InvalidTagMatch",
+			args: args{
+				ctx: context.TODO(),
+				f:   entities.ExpenseFilter{Tags: []string{"trip"}, TagMatch: "some"},
+			},
+			wantErr:   true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {},
+		},
+		{
+			name: "ListExpenses_LimitTooLarge",
+			args: args{
+				ctx: context.TODO(),
+				p:   entities.PageRequest{Limit: MaxPageLimit + 1},
+			},
+			wantErr:   true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {},
+		},
+		{
+			name: "ListExpenses_InvalidCursor",
+			args: args{
+				ctx: context.TODO(),
+				p:   entities.PageRequest{Cursor: "garbage"},
+			},
+			wantErr: true,
+			setupMock: func(m *mocks.MockExpenseRepositoryInterface) {
+				m.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repository.ErrInvalidCursor)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock(mockRepo)
+			s := &expenseServiceImpl{
+				repo: mockRepo,
+			}
+			got, err := s.ListExpenses(tt.args.ctx, tt.args.f, tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expenseServiceImpl.ListExpenses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidFilter) {
+					t.Errorf("expenseServiceImpl.ListExpenses() error = %v, want ErrInvalidFilter", err)
+				}
+				return
+			}
+			if len(got.Items) != tt.wantLen {
+				t.Errorf("expenseServiceImpl.ListExpenses() returned %d expenses, want %d", len(got.Items), tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_expenseServiceImpl_ExportExpenses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from, to := int64(200), int64(100)
+	stop := errors.New("stop")
+
+	tests := []struct {
+		name      string
+		f         entities.ExpenseFilter
+		setupMock func(mockRepo *mocks.MockExpenseRepositoryInterface)
+		wantIDs   []string
+		wantErr   error
+	}{
+		{
+			name: "Exported",
+			f:    entities.ExpenseFilter{Currency: "eur", Tags: []string{" Trip "}},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().Export(gomock.Any(), entities.ExpenseFilter{Currency: "EUR", Tags: []string{"trip"}}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entities.ExpenseFilter, fn func(*entities.Expense) error) error {
+						for _, id := range []string{"expense_1", "expense_2"} {
+							if err := fn(&entities.Expense{ID: id}); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			},
+			wantIDs: []string{"expense_1", "expense_2"},
+		},
+		{
+			name: "Stopped",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entities.ExpenseFilter, fn func(*entities.Expense) error) error {
+						return fn(&entities.Expense{ID: "expense_1"})
+					})
+			},
+			wantIDs: []string{"expense_1"},
+			wantErr: stop,
+		},
+		{
+			name:      "InvalidDateRange",
+			f:         entities.ExpenseFilter{From: &from, To: &to},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {},
+			wantErr:   ErrInvalidFilter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			s := &expenseServiceImpl{repo: mockRepo}
+			var ids []string
+			err := s.ExportExpenses(context.TODO(), tt.f, func(e *entities.Expense) error {
+				ids = append(ids, e.ID)
+				if tt.wantErr == stop {
+					return stop
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expenseServiceImpl.ExportExpenses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("expenseServiceImpl.ExportExpenses() exported %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func Test_normalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "Nil", tags: nil, want: nil},
+		{name: "Empty", tags: []string{}, want: []string{}},
+		{name: "TrimLowercaseDedupe", tags: []string{" Trip", "CLIENT-A", "trip "}, want: []string{"trip", "client-a"}},
+		{name: "Blank", tags: []string{"trip", "  "}, wantErr: true},
+		{name: "TooLong", tags: []string{strings.Repeat("x", maxTagLength+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTags(tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("normalizeTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeTags() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expenseServiceImpl_UpdateExpense_Locked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Status: entities.StatusApproved}, nil).Times(2)
+
+	s := &expenseServiceImpl{repo: mockRepo, defaultCurrency: "USD"}
+	if err := s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Amount: 100}); !errors.Is(err, ErrExpenseLocked) {
+		t.Errorf("expenseServiceImpl.UpdateExpense() error = %v, want %v", err, ErrExpenseLocked)
+	}
+	if err := s.DeleteExpense(context.TODO(), "expense_1", 0); !errors.Is(err, ErrExpenseLocked) {
+		t.Errorf("expenseServiceImpl.DeleteExpense() error = %v, want %v", err, ErrExpenseLocked)
+	}
+}
+
+func Test_expenseServiceImpl_UpdateExpense_SplitMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Amount: 1000, Currency: "USD"}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: expense expense_1 is split for 10.00", repository.ErrSplitMismatch))
+
+	auditor := &recordingAuditor{}
+	s := &expenseServiceImpl{repo: mockRepo, audit: auditor, defaultCurrency: "USD"}
+	if err := s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Amount: 1250}); !errors.Is(err, ErrSplitMismatch) {
+		t.Errorf("expenseServiceImpl.UpdateExpense() error = %v, want %v", err, ErrSplitMismatch)
+	}
+	if len(auditor.actions) != 0 {
+		t.Errorf("expenseServiceImpl.UpdateExpense() recorded %v for a refused update", auditor.actions)
+	}
+}
+
+func Test_expenseServiceImpl_transitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_approver", WorkspaceID: "workspace_1"})
+	expense := func(owner string, status entities.ExpenseStatus) *entities.Expense {
+		return &entities.Expense{ID: "expense_1", OwnerID: owner, Status: status}
+	}
+
+	tests := []struct {
+		name       string
+		call       func(s *expenseServiceImpl) (*entities.Expense, error)
+		wantStatus entities.ExpenseStatus
+		wantErr    error
+		setupMock  func()
+	}{
+		{
+			name:       "Submit_Draft",
+			call:       func(s *expenseServiceImpl) (*entities.Expense, error) { return s.SubmitExpense(ctx, "expense_1") },
+			wantStatus: entities.StatusSubmitted,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(expense("user_approver", entities.StatusDraft), nil)
+				mockRepo.EXPECT().SetStatus(gomock.Any(), "expense_1", entities.StatusDraft, entities.StatusSubmitted, "").Return(2, nil)
+			},
+		},
+		{
+			name:    "Submit_Approved",
+			call:    func(s *expenseServiceImpl) (*entities.Expense, error) { return s.SubmitExpense(ctx, "expense_1") },
+			wantErr: ErrInvalidTransition,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(expense("user_approver", entities.StatusApproved), nil)
+			},
+		},
+		{
+			name:       "Approve_Submitted",
+			call:       func(s *expenseServiceImpl) (*entities.Expense, error) { return s.ApproveExpense(ctx, "expense_1") },
+			wantStatus: entities.StatusApproved,
+			setupMock: func() {
+				mockRepo.EXPECT().GetInWorkspace(gomock.Any(), "expense_1").Return(expense("user_owner", entities.StatusSubmitted), nil)
+				mockRepo.EXPECT().SetStatus(gomock.Any(), "expense_1", entities.StatusSubmitted, entities.StatusApproved, "").Return(2, nil)
+			},
+		},
+		{
+			name:    "Approve_Draft",
+			call:    func(s *expenseServiceImpl) (*entities.Expense, error) { return s.ApproveExpense(ctx, "expense_1") },
+			wantErr: ErrInvalidTransition,
+			setupMock: func() {
+				mockRepo.EXPECT().GetInWorkspace(gomock.Any(), "expense_1").Return(expense("user_owner", entities.StatusDraft), nil)
+			},
+		},
+		{
+			name:    "Approve_Own",
+			call:    func(s *expenseServiceImpl) (*entities.Expense, error) { return s.ApproveExpense(ctx, "expense_1") },
+			wantErr: auth.ErrForbidden,
+			setupMock: func() {
+				mockRepo.EXPECT().GetInWorkspace(gomock.Any(), "expense_1").Return(expense("user_approver", entities.StatusSubmitted), nil)
+			},
+		},
+		{
+			name:    "Approve_Concurrent",
+			call:    func(s *expenseServiceImpl) (*entities.Expense, error) { return s.ApproveExpense(ctx, "expense_1") },
+			wantErr: ErrInvalidTransition,
+			setupMock: func() {
+				mockRepo.EXPECT().GetInWorkspace(gomock.Any(), "expense_1").Return(expense("user_owner", entities.StatusSubmitted), nil)
+				mockRepo.EXPECT().SetStatus(gomock.Any(), "expense_1", entities.StatusSubmitted, entities.StatusApproved, "").Return(0, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "Reject_WithReason",
+			call: func(s *expenseServiceImpl) (*entities.Expense, error) {
+				return s.RejectExpense(ctx, "expense_1", " Missing receipt ")
+			},
+			wantStatus: entities.StatusRejected,
+			setupMock: func() {
+				mockRepo.EXPECT().GetInWorkspace(gomock.Any(), "expense_1").Return(expense("user_owner", entities.StatusSubmitted), nil)
+				mockRepo.EXPECT().SetStatus(gomock.Any(), "expense_1", entities.StatusSubmitted, entities.StatusRejected, "Missing receipt").Return(2, nil)
+			},
+		},
+		{
+			name:      "Reject_WithoutReason",
+			call:      func(s *expenseServiceImpl) (*entities.Expense, error) { return s.RejectExpense(ctx, "expense_1", " ") },
+			wantErr:   ErrReasonRequired,
+			setupMock: func() {},
+		},
+		{
+			name:       "Reimburse_Approved",
+			call:       func(s *expenseServiceImpl) (*entities.Expense, error) { return s.ReimburseExpense(ctx, "expense_1") },
+			wantStatus: entities.StatusReimbursed,
+			setupMock: func() {
+				mockRepo.EXPECT().GetInWorkspace(gomock.Any(), "expense_1").Return(expense("user_owner", entities.StatusApproved), nil)
+				mockRepo.EXPECT().SetStatus(gomock.Any(), "expense_1", entities.StatusApproved, entities.StatusReimbursed, "").Return(2, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &expenseServiceImpl{repo: mockRepo}
+			got, err := tt.call(s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expenseServiceImpl transition error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Status != tt.wantStatus {
+				t.Errorf("expenseServiceImpl transition status = %q, want %q", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// recordingAuditor keeps the actions recorded through it, in or out of a
+// unit of work.
+type recordingAuditor struct {
+	actions []entities.AuditAction
+	before  []interface{}
+	after   []interface{}
+}
+
+func (a *recordingAuditor) Record(_ context.Context, _, _ string, action entities.AuditAction, before, after interface{}) error {
+	a.actions = append(a.actions, action)
+	a.before = append(a.before, before)
+	a.after = append(a.after, after)
+	return nil
+}
+
+func (a *recordingAuditor) InUnit(repository.UnitOfWork) Auditor {
+	return a
+}
+
+func Test_expenseServiceImpl_CreateExpense_AuditFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRepositoryInterface(ctrl)
+	mockUnit := mocks.NewMockUnitOfWork(ctrl)
+	mockUnits := mocks.NewMockUnitOfWorkFactory(ctrl)
+	mockUnits.EXPECT().Begin(gomock.Any()).Return(mockUnit, nil)
+	mockUnit.EXPECT().Expenses().Return(mockRepo)
+	mockUnit.EXPECT().Audit().Return(mockAudit)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockAudit.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+	// The expense is rolled back with the event, never committed.
+	mockUnit.EXPECT().Rollback().Return(nil)
+
+	ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", WorkspaceID: "workspace_1"})
+	s := &expenseServiceImpl{units: mockUnits, audit: &auditServiceImpl{}, defaultCurrency: "USD"}
+	if err := s.CreateExpense(ctx, &entities.Expense{Description: "Taxi", Amount: 1000}); err == nil {
+		t.Error("expenseServiceImpl.CreateExpense() error = nil, want the audit failure")
+	}
+}
+
+func Test_expenseServiceImpl_UpdateExpense_Audited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	current := &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1000, Currency: "USD", Tags: []string{"trip"}, OwnerID: "user_1"}
+	mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	auditor := &recordingAuditor{}
+	s := &expenseServiceImpl{repo: mockRepo, audit: auditor, defaultCurrency: "USD"}
+	if err := s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1250}); err != nil {
+		t.Fatalf("expenseServiceImpl.UpdateExpense() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(auditor.actions, []entities.AuditAction{entities.AuditUpdate}) {
+		t.Fatalf("expenseServiceImpl.UpdateExpense() recorded %v", auditor.actions)
+	}
+	want := *current
+	want.Amount = 1250
+	if !reflect.DeepEqual(auditor.after[0], want) {
+		t.Errorf("expenseServiceImpl.UpdateExpense() recorded after = %+v, want %+v", auditor.after[0], want)
+	}
+}
+
+func Test_expenseServiceImpl_RestoreExpense(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		setupMock   func(mockRepo *mocks.MockExpenseRepositoryInterface)
+		wantErr     error
+		wantActions []entities.AuditAction
+	}{
+		{
+			name: "Restored",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().Restore(gomock.Any(), "expense_1").Return(nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Status: entities.StatusDraft}, nil)
+			},
+			wantActions: []entities.AuditAction{entities.AuditRestore},
+		},
+		{
+			name: "NotInTrash",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().Restore(gomock.Any(), "expense_1").Return(repository.ErrNotFound)
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			auditor := &recordingAuditor{}
+			s := &expenseServiceImpl{repo: mockRepo, audit: auditor, defaultCurrency: "USD"}
+			e, err := s.RestoreExpense(context.TODO(), "expense_1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expenseServiceImpl.RestoreExpense() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && e.ID != "expense_1" {
+				t.Errorf("expenseServiceImpl.RestoreExpense() = %+v", e)
+			}
+			if !reflect.DeepEqual(auditor.actions, tt.wantActions) {
+				t.Errorf("expenseServiceImpl.RestoreExpense() recorded %v, want %v", auditor.actions, tt.wantActions)
+			}
+		})
+	}
+}
+
+func Test_expenseServiceImpl_PurgeTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockBlobs := storagemocks.NewMockBlobStore(ctrl)
+	mockRepo.EXPECT().Purge(gomock.Any(), int64(1700000000)).Return(int64(3), []string{"aaa", "bbb", "ccc"}, nil)
+	mockBlobs.EXPECT().Delete(gomock.Any(), "aaa").Return(nil)
+	mockBlobs.EXPECT().Delete(gomock.Any(), "bbb").Return(errors.New("disk failure"))
+	mockBlobs.EXPECT().Delete(gomock.Any(), "ccc").Return(nil)
+
+	s := &expenseServiceImpl{repo: mockRepo, blobs: mockBlobs, defaultCurrency: "USD"}
+	purged, err := s.PurgeTrash(context.TODO(), time.Unix(1700000000, 0))
+	if err == nil || !strings.Contains(err.Error(), "bbb") {
+		t.Errorf("expenseServiceImpl.PurgeTrash() error = %v, want the failed blob deletion", err)
+	}
+	if purged != 3 {
+		t.Errorf("expenseServiceImpl.PurgeTrash() = %d, want 3", purged)
+	}
+}
+
+func Test_expenseServiceImpl_RevertExpense(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rev := &entities.ExpenseRevision{ExpenseID: "expense_1", Revision: 1, Description: "Taxi", Amount: 1000, Currency: "EUR", Status: entities.StatusDraft}
+
+	tests := []struct {
+		name      string
+		version   int
+		setupMock func(mockRepo *mocks.MockExpenseRepositoryInterface)
+		wantErr   error
+	}{
+		{
+			name:    "Reverted",
+			version: 2,
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), "expense_1", 1).Return(rev, nil)
+				current := &entities.Expense{ID: "expense_1", Description: "Taxi home", Amount: 1500, Currency: "USD", Tags: []string{"trip"}, Status: entities.StatusRejected, Version: 2}
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+				want := &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1000, Currency: "EUR", Tags: []string{}, Status: entities.StatusRejected, Version: 2}
+				mockRepo.EXPECT().Update(gomock.Any(), want).Return(nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1000, Currency: "EUR", Status: entities.StatusRejected}, nil)
+			},
+		},
+		{
+			name: "UnknownRevision",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), "expense_1", 1).Return(nil, repository.ErrNotFound)
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "Locked",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), "expense_1", 1).Return(rev, nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Status: entities.StatusApproved}, nil)
+			},
+			wantErr: ErrExpenseLocked,
+		},
+		{
+			name:    "VersionConflict",
+			version: 2,
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), "expense_1", 1).Return(rev, nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(&entities.Expense{ID: "expense_1", Status: entities.StatusDraft, Version: 3}, nil)
+			},
+			wantErr: ErrVersionConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			s := &expenseServiceImpl{repo: mockRepo, defaultCurrency: "USD"}
+			e, err := s.RevertExpense(context.TODO(), "expense_1", 1, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expenseServiceImpl.RevertExpense() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && e.Description != "Taxi" {
+				t.Errorf("expenseServiceImpl.RevertExpense() = %+v", e)
+			}
+		})
+	}
+}
+
+func Test_expenseServiceImpl_versions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1000, Currency: "USD", Status: entities.StatusDraft, Version: 3}
+
+	tests := []struct {
+		name      string
+		call      func(s *expenseServiceImpl) error
+		setupMock func(mockRepo *mocks.MockExpenseRepositoryInterface)
+		wantErr   error
+	}{
+		{
+			name: "UpdateCurrentVersion",
+			call: func(s *expenseServiceImpl) error {
+				return s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1250, Version: 3})
+			},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *entities.Expense) error {
+					if e.Version != 3 {
+						t.Errorf("Update() got version %d, want 3", e.Version)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "UpdateAnyVersion",
+			call: func(s *expenseServiceImpl) error {
+				return s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1250})
+			},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *entities.Expense) error {
+					if e.Version != 3 {
+						t.Errorf("Update() got version %d, want 3", e.Version)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "UpdateOlderVersion",
+			call: func(s *expenseServiceImpl) error {
+				return s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1250, Version: 2})
+			},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+			},
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name: "UpdateRacingChange",
+			call: func(s *expenseServiceImpl) error {
+				return s.UpdateExpense(context.TODO(), &entities.Expense{ID: "expense_1", Description: "Taxi", Amount: 1250, Version: 3})
+			},
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+			},
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name: "DeleteCurrentVersion",
+			call: func(s *expenseServiceImpl) error { return s.DeleteExpense(context.TODO(), "expense_1", 3) },
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), "expense_1", 3).Return(nil)
+			},
+		},
+		{
+			name: "DeleteOlderVersion",
+			call: func(s *expenseServiceImpl) error { return s.DeleteExpense(context.TODO(), "expense_1", 2) },
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "expense_1").Return(current, nil)
+			},
+			wantErr: ErrVersionConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			s := &expenseServiceImpl{repo: mockRepo, defaultCurrency: "USD"}
+			if err := tt.call(s); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expenseServiceImpl error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_generateID(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		id := generateID("expense")
+		if !strings.HasPrefix(id, "expense_") {
+			t.Fatalf("generateID() = %q, want the expense_ prefix", id)
+		}
+		if seen[id] {
+			t.Fatalf("generateID() = %q twice", id)
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// ErrInvalidIdempotencyKey is returned when an Idempotency-Key is blank,
+// too long or not printable.
+var ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+
+// ErrIdempotencyKeyReused is returned when an Idempotency-Key comes back
+// with another request than the one it was first sent with.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused")
+
+// ErrIdempotencyInProgress is returned when a request arrives while the
+// first request with its Idempotency-Key is still in progress.
+var ErrIdempotencyInProgress = errors.New("idempotent request in progress")
+
+// ErrIdempotencyClaimLost is returned when a request creates an expense
+// after a retry took its Idempotency-Key over.
+var ErrIdempotencyClaimLost = repository.ErrIdempotencyClaimLost
+
+const (
+	// maxIdempotencyKeyLength matches the size of the
+	// idempotency_keys.idempotency_key column.
+	maxIdempotencyKeyLength = 255
+	// idempotencyLease is how long a request may hold its key without
+	// committing before an identical retry takes it over. The request
+	// then fails to commit, so a slow request is retried rather than
+	// processed twice.
+	idempotencyLease = time.Minute
+)
+
+// IdempotencyService lets retries of a request get the response of the
+// first one instead of being processed again. Begin claims the key of a
+// request, which is then processed with the context WithIdempotencyClaim
+// returns, and Complete or Release end the claim.
+type IdempotencyService interface {
+	Begin(ctx context.Context, key, requestHash string) (string, *entities.IdempotentResponse, error)
+	Complete(ctx context.Context, key, token string, resp *entities.IdempotentResponse) error
+	Release(ctx context.Context, key, token string) error
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyServiceImpl struct {
+	repo repository.IdempotencyRepositoryInterface
+	ttl  time.Duration
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService.
+// Keys are remembered for ttl after their first request.
+func NewIdempotencyService(repo repository.IdempotencyRepositoryInterface, ttl time.Duration) IdempotencyService {
+	return &idempotencyServiceImpl{repo: repo, ttl: ttl}
+}
+
+// Begin claims key for the request identified by requestHash. It returns
+// the token the request holds the key with when the request should be
+// processed, or the response to replay when it is a retry of a completed
+// request.
+func (s *idempotencyServiceImpl) Begin(ctx context.Context, key, requestHash string) (string, *entities.IdempotentResponse, error) {
+	if !validIdempotencyKey(key) {
+		return "", nil, fmt.Errorf("%w: keys are 1 to %d printable ASCII characters", ErrInvalidIdempotencyKey, maxIdempotencyKeyLength)
+	}
+	token, err := generateClaimToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	claimed, err := s.repo.Claim(ctx, &entities.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		Token:       token,
+		ClaimedAt:   now.Unix(),
+		ExpiresAt:   now.Add(s.ttl).Unix(),
+	}, now.Add(-idempotencyLease).Unix())
+	if err != nil {
+		return "", nil, err
+	}
+	if claimed {
+		return token, nil, nil
+	}
+
+	rec, err := s.repo.Get(ctx, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		// The key expired and was deleted since the claim failed.
+		return "", nil, fmt.Errorf("%w: retry the request", ErrIdempotencyInProgress)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if rec.RequestHash != requestHash {
+		return "", nil, fmt.Errorf("%w: %s was sent with another request", ErrIdempotencyKeyReused, key)
+	}
+	if rec.Response == nil && rec.Committed {
+		return "", nil, fmt.Errorf("%w: the first request with %s created the expense, but its response is not stored yet", ErrIdempotencyInProgress, key)
+	}
+	if rec.Response == nil {
+		return "", nil, fmt.Errorf("%w: the first request with %s is not done yet", ErrIdempotencyInProgress, key)
+	}
+	return "", rec.Response, nil
+}
+
+// WithIdempotencyClaim returns a copy of ctx for processing the request
+// holding key with token, so that what it creates is only saved while it
+// still holds the key.
+func WithIdempotencyClaim(ctx context.Context, key, token string) context.Context {
+	return repository.WithIdempotencyClaim(ctx, key, token)
+}
+
+// Complete stores the response to replay to the retries of the request
+// holding key with token.
+func (s *idempotencyServiceImpl) Complete(ctx context.Context, key, token string, resp *entities.IdempotentResponse) error {
+	return s.repo.Complete(ctx, key, token, resp)
+}
+
+// Release frees key after its request, holding it with token, failed
+// without committing anything, so a retry is processed again.
+func (s *idempotencyServiceImpl) Release(ctx context.Context, key, token string) error {
+	return s.repo.Release(ctx, key, token)
+}
+
+// PurgeExpired removes the keys of every user that expired at now and
+// returns how many it removed.
+func (s *idempotencyServiceImpl) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteExpired(ctx, now.Unix())
+}
+
+// RunIdempotencyKeyPurger removes the expired Idempotency-Keys every
+// interval until ctx is done.
+func RunIdempotencyKeyPurger(ctx context.Context, svc IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := svc.PurgeExpired(ctx, time.Now())
+		if err != nil {
+			log.Printf("Error purging idempotency keys: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d idempotency keys", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// generateClaimToken returns a new token for holding an Idempotency-Key,
+// with 128 random bits.
+func generateClaimToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating claim token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validIdempotencyKey reports whether key is short and printable ASCII.
+func validIdempotencyKey(key string) bool {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
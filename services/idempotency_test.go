@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_idempotencyServiceImpl_Begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := &entities.IdempotentResponse{StatusCode: 201, Header: map[string][]string{"Content-Type": {"application/json"}}, Body: []byte(`{"id":"expense_1"}`)}
+
+	tests := []struct {
+		name      string
+		key       string
+		setupMock func(mockRepo *mocks.MockIdempotencyRepositoryInterface)
+		want      *entities.IdempotentResponse
+		wantClaim bool
+		wantErr   error
+	}{
+		{
+			name:      "FirstRequest",
+			key:       "key-1",
+			wantClaim: true,
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {
+				mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *entities.IdempotencyRecord, staleBefore int64) (bool, error) {
+					if r.Key != "key-1" || r.RequestHash != "hash_1" || len(r.Token) != 32 || r.ExpiresAt-r.ClaimedAt != int64(time.Hour/time.Second) {
+						t.Errorf("Claim() got %+v", r)
+					}
+					if staleBefore >= r.ClaimedAt {
+						t.Errorf("Claim() got staleBefore %d, not before %d", staleBefore, r.ClaimedAt)
+					}
+					return true, nil
+				})
+			},
+		},
+		{
+			name: "Retry",
+			key:  "key-1",
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {
+				mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().Get(gomock.Any(), "key-1").Return(&entities.IdempotencyRecord{Key: "key-1", RequestHash: "hash_1", Response: stored}, nil)
+			},
+			want: stored,
+		},
+		{
+			name: "OtherRequest",
+			key:  "key-1",
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {
+				mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().Get(gomock.Any(), "key-1").Return(&entities.IdempotencyRecord{Key: "key-1", RequestHash: "hash_2", Response: stored}, nil)
+			},
+			wantErr: ErrIdempotencyKeyReused,
+		},
+		{
+			name: "InProgress",
+			key:  "key-1",
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {
+				mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().Get(gomock.Any(), "key-1").Return(&entities.IdempotencyRecord{Key: "key-1", RequestHash: "hash_1"}, nil)
+			},
+			wantErr: ErrIdempotencyInProgress,
+		},
+		{
+			name: "CommittedWithoutResponse",
+			key:  "key-1",
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {
+				mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().Get(gomock.Any(), "key-1").Return(&entities.IdempotencyRecord{Key: "key-1", RequestHash: "hash_1", Committed: true}, nil)
+			},
+			wantErr: ErrIdempotencyInProgress,
+		},
+		{
+			name: "ExpiredMeanwhile",
+			key:  "key-1",
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {
+				mockRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().Get(gomock.Any(), "key-1").Return(nil, repository.ErrNotFound)
+			},
+			wantErr: ErrIdempotencyInProgress,
+		},
+		{
+			name:      "TooLong",
+			key:       strings.Repeat("k", 256),
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {},
+			wantErr:   ErrInvalidIdempotencyKey,
+		},
+		{
+			name:      "NotPrintable",
+			key:       "key\n1",
+			setupMock: func(mockRepo *mocks.MockIdempotencyRepositoryInterface) {},
+			wantErr:   ErrInvalidIdempotencyKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIdempotencyRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			s := &idempotencyServiceImpl{repo: mockRepo, ttl: time.Hour}
+			token, got, err := s.Begin(context.TODO(), tt.key, "hash_1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("idempotencyServiceImpl.Begin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (token != "") != tt.wantClaim {
+				t.Errorf("idempotencyServiceImpl.Begin() token = %q, want a claim %v", token, tt.wantClaim)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("idempotencyServiceImpl.Begin() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
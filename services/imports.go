@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// ErrInvalidImport is returned when an imported file cannot be read at
+// all or the import options are wrong. Rows that cannot be read are
+// reported in the summary instead.
+var ErrInvalidImport = errors.New("invalid import")
+
+// ErrAlreadyImported is returned when importing an expense with the
+// ExternalRef of an expense already in the workspace.
+var ErrAlreadyImported = repository.ErrDuplicateExternalRef
+
+// MaxImportRows is the largest number of rows a file may hold.
+const MaxImportRows = 10000
+
+// ImportService imports expenses from bank and card statements.
+type ImportService interface {
+	ImportCSV(ctx context.Context, r io.Reader, opts entities.CSVImportOptions) (*entities.ImportSummary, error)
+	ImportOFX(ctx context.Context, r io.Reader, dryRun bool) (*entities.ImportSummary, error)
+	ImportQIF(ctx context.Context, r io.Reader, opts entities.QIFImportOptions) (*entities.ImportSummary, error)
+}
+
+type importServiceImpl struct {
+	expenses        ExpenseService
+	repo            repository.ExpenseRepositoryInterface
+	categories      repository.CategoryRepositoryInterface
+	defaultCurrency string
+}
+
+// NewImportService creates a new instance of ImportService creating
+// expenses through expenses, and looking up in repo the transactions that
+// were imported already. Rows without a currency are in defaultCurrency.
+func NewImportService(expenses ExpenseService, repo repository.ExpenseRepositoryInterface, categories repository.CategoryRepositoryInterface, defaultCurrency string) ImportService {
+	return &importServiceImpl{expenses: expenses, repo: repo, categories: categories, defaultCurrency: defaultCurrency}
+}
+
+// statementRow is an expense read from a statement, or the error reading
+// it. Rows holding no expense have neither, and may tell why in Skip.
+type statementRow struct {
+	Line    int
+	Expense *entities.Expense
+	// Category is the name or ID of the category of the expense.
+	Category string
+	Skip     string
+	Err      error
+}
+
+// ImportCSV creates an expense for each row of a CSV statement, dated as
+// on the statement. Amounts are expenses or money coming in by their
+// sign, as set by opts.AmountSign, and money coming in is skipped as in
+// OFX and QIF statements. Rows that fail do not keep the others from being
+// created.
+func (s *importServiceImpl) ImportCSV(ctx context.Context, r io.Reader, opts entities.CSVImportOptions) (*entities.ImportSummary, error) {
+	rows, err := parseCSVStatement(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.importRows(ctx, rows, opts.DryRun)
+}
+
+// importRows checks the rows read from a statement and, unless dryRun is
+// set, creates their expenses. Rows whose ExternalRef was imported
+// already, or comes up again in the statement, are skipped.
+func (s *importServiceImpl) importRows(ctx context.Context, rows []statementRow, dryRun bool) (*entities.ImportSummary, error) {
+	categories, err := s.categoryIDs(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := s.importedRefs(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	// lines maps the references read so far to the line they were read on.
+	lines := make(map[string]int)
+
+	summary := &entities.ImportSummary{DryRun: dryRun, Rows: make([]entities.ImportRow, 0, len(rows))}
+	for _, row := range rows {
+		res := entities.ImportRow{Line: row.Line, Expense: row.Expense, Reason: row.Skip}
+		err := row.Err
+		var ref string
+		if err == nil && row.Expense != nil {
+			ref = row.Expense.ExternalRef
+			err = s.check(row, categories)
+		}
+		switch {
+		case ref != "" && imported[ref]:
+			res.Status, res.Expense, res.Reason = entities.ImportSkipped, nil, "already imported"
+		case ref != "" && lines[ref] != 0:
+			res.Status, res.Expense, res.Reason = entities.ImportSkipped, nil, fmt.Sprintf("same transaction as line %d", lines[ref])
+		case err != nil:
+			res.Status, res.Expense, res.Error = entities.ImportFailed, nil, err.Error()
+		case row.Expense == nil:
+			res.Status = entities.ImportSkipped
+		case dryRun:
+			res.Status = entities.ImportValid
+		default:
+			res.Status = entities.ImportCreated
+			err := s.expenses.ImportExpense(ctx, row.Expense)
+			switch {
+			case errors.Is(err, ErrAlreadyImported):
+				// Another import created it since the references were read.
+				res.Status, res.Expense, res.Reason = entities.ImportSkipped, nil, "already imported"
+			case err != nil:
+				res.Status, res.Expense, res.Error = entities.ImportFailed, nil, importError(row.Line, err)
+			}
+		}
+		if ref != "" && lines[ref] == 0 {
+			lines[ref] = row.Line
+		}
+
+		switch res.Status {
+		case entities.ImportFailed:
+			summary.Failed++
+		case entities.ImportSkipped:
+			summary.Skipped++
+		default:
+			summary.Created++
+		}
+		summary.Rows = append(summary.Rows, res)
+	}
+	return summary, nil
+}
+
+// check validates an expense read from a statement the way creating it
+// would, and resolves its category.
+func (s *importServiceImpl) check(row statementRow, categories map[string]string) error {
+	e := row.Expense
+	e.Description = strings.TrimSpace(e.Description)
+	if e.Description == "" {
+		return errors.New("description is required")
+	}
+	if e.Currency == "" {
+		e.Currency = s.defaultCurrency
+	}
+	var err error
+	if e.Currency, err = entities.NormalizeCurrency(e.Currency); err != nil {
+		return err
+	}
+	if row.Category != "" {
+		id, ok := categories[strings.ToLower(row.Category)]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownCategory, row.Category)
+		}
+		e.CategoryID = id
+	}
+	return nil
+}
+
+// categoryIDs maps the lowercased names and IDs of the categories to their
+// IDs, when rows name any.
+func (s *importServiceImpl) categoryIDs(ctx context.Context, rows []statementRow) (map[string]string, error) {
+	ids := make(map[string]string)
+	for _, row := range rows {
+		if row.Category == "" {
+			continue
+		}
+		categories, err := s.categories.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range categories {
+			ids[strings.ToLower(c.ID)] = c.ID
+			ids[strings.ToLower(c.Name)] = c.ID
+		}
+		break
+	}
+	return ids, nil
+}
+
+// importedRefs tells which of the external references of the rows were
+// imported already.
+func (s *importServiceImpl) importedRefs(ctx context.Context, rows []statementRow) (map[string]bool, error) {
+	var refs []string
+	for _, row := range rows {
+		if row.Expense != nil && row.Expense.ExternalRef != "" {
+			refs = append(refs, row.Expense.ExternalRef)
+		}
+	}
+	if len(refs) == 0 {
+		return map[string]bool{}, nil
+	}
+	return s.repo.ExternalRefs(ctx, refs)
+}
+
+// statementRef makes the external reference of a transaction read from a
+// statement of the given kind out of what identifies it there.
+func statementRef(kind string, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return kind + ":" + hex.EncodeToString(sum[:16])
+}
+
+// importError describes why creating the expense of a row failed, without
+// leaking unexpected errors to the client.
+func importError(line int, err error) string {
+	switch {
+	case errors.Is(err, ErrUnknownCategory), errors.Is(err, ErrInvalidTag), errors.Is(err, ErrInvalidCurrency), errors.Is(err, entities.ErrInvalidAmount):
+		return err.Error()
+	default:
+		log.Printf("Error importing expense on line %d: %v", line, err)
+		return "the expense could not be created"
+	}
+}
+
+// parseCSVStatement reads the rows of a CSV statement with a header.
+func parseCSVStatement(r io.Reader, opts entities.CSVImportOptions) ([]statementRow, error) {
+	if opts.Columns.Date == "" || opts.Columns.Description == "" || opts.Columns.Amount == "" {
+		return nil, fmt.Errorf("%w: date, description and amount columns are required", ErrInvalidImport)
+	}
+	if opts.DateFormat == "" {
+		opts.DateFormat = "YYYY-MM-DD"
+	}
+	layout, err := dateLayout(opts.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+	decimal, thousands, err := amountSeparators(opts.DecimalSeparator)
+	if err != nil {
+		return nil, err
+	}
+	// sign turns the amounts of expenses positive.
+	var sign entities.Money
+	switch opts.AmountSign {
+	case "", entities.ExpensesPositive:
+		sign = 1
+	case entities.ExpensesNegative:
+		sign = -1
+	default:
+		return nil, fmt.Errorf("%w: amount sign must be %s or %s", ErrInvalidImport, entities.ExpensesPositive, entities.ExpensesNegative)
+	}
+
+	cr := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		cr.Comma = opts.Delimiter
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	// Spreadsheets often start their CSV exports with a byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		i, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("%w: no %q column", ErrInvalidImport, name)
+		}
+		return i, nil
+	}
+	var index [5]int
+	for i, name := range []string{opts.Columns.Date, opts.Columns.Description, opts.Columns.Amount, opts.Columns.Currency, opts.Columns.Category} {
+		if index[i], err = column(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []statementRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		if len(rows) == MaxImportRows {
+			return nil, fmt.Errorf("%w: a file holds at most %d rows", ErrInvalidImport, MaxImportRows)
+		}
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		line, _ := cr.FieldPos(0)
+		date, description, amount := field(index[0]), field(index[1]), field(index[2])
+
+		row := statementRow{Line: line}
+		if date == "" && description == "" && amount == "" {
+			rows = append(rows, row)
+			continue
+		}
+		day, err := time.Parse(layout, date)
+		if err != nil {
+			row.Err = fmt.Errorf("date %q is not %s", date, opts.DateFormat)
+			rows = append(rows, row)
+			continue
+		}
+		value, err := parseLocalAmount(amount, decimal, thousands)
+		switch value *= sign; {
+		case err != nil:
+			row.Err = err
+		case value < 0:
+			row.Skip = "money coming in"
+		case value > 0:
+			row.Expense = &entities.Expense{Description: description, Amount: value, Currency: field(index[3]), DateCreation: day.Unix()}
+			row.Category = field(index[4])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// dateLayout turns a date format such as DD/MM/YYYY into a time layout.
+func dateLayout(format string) (string, error) {
+	layout := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(format)
+	if !strings.Contains(layout, "06") || !strings.Contains(layout, "01") || !strings.Contains(layout, "02") ||
+		strings.IndexFunc(layout, func(r rune) bool { return r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' }) >= 0 {
+		return "", fmt.Errorf("%w: date format %q must be made of YYYY or YY, MM and DD", ErrInvalidImport, format)
+	}
+	return layout, nil
+}
+
+// amountSeparators returns the decimal and thousands separators of amounts
+// whose decimal separator is decimal, "." when empty.
+func amountSeparators(decimal string) (string, string, error) {
+	switch decimal {
+	case "", ".":
+		return ".", ",", nil
+	case ",":
+		return ",", ".", nil
+	default:
+		return "", "", fmt.Errorf("%w: decimal separator must be . or ,", ErrInvalidImport)
+	}
+}
+
+// parseLocalAmount parses an amount written with the given decimal and
+// thousands separators, such as 1.234,56.
+func parseLocalAmount(s, decimal, thousands string) (entities.Money, error) {
+	s = strings.NewReplacer(thousands, "", " ", "", "\u00a0", "", "'", "").Replace(s)
+	s = strings.TrimPrefix(strings.Replace(s, decimal, ".", 1), "+")
+	return entities.ParseMoney(s)
+}
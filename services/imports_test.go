@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_importServiceImpl_ImportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	statement := "\ufeffDatum;Omschrijving;Bedrag;Valuta;Soort\n" +
+		"02.01.2024;Taxi;1.234,50;eur;Travel\n" +
+		";;;;\n" +
+		"03.01.2024;Refund;0,00;EUR;\n" +
+		"2024-01-04;Lunch;12,00;EUR;\n" +
+		"05.01.2024;Hotel;80,00;EUR;Unknown\n" +
+		"06.01.2024;Parking;7,5;;\n"
+	opts := entities.CSVImportOptions{
+		Columns:          entities.CSVColumns{Date: "datum", Description: "Omschrijving", Amount: "Bedrag", Currency: "Valuta", Category: "Soort"},
+		DateFormat:       "DD.MM.YYYY",
+		DecimalSeparator: ",",
+		Delimiter:        ';',
+	}
+	wantRows := []entities.ImportRow{
+		{Line: 2, Status: entities.ImportCreated},
+		{Line: 3, Status: entities.ImportSkipped},
+		{Line: 4, Status: entities.ImportSkipped},
+		{Line: 5, Status: entities.ImportFailed, Error: `date "2024-01-04" is not DD.MM.YYYY`},
+		{Line: 6, Status: entities.ImportFailed, Error: "unknown category: Unknown"},
+		{Line: 7, Status: entities.ImportCreated},
+	}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		setupMock   func(mockRepo *mocks.MockExpenseRepositoryInterface)
+		wantCreated []entities.Expense
+	}{
+		{
+			name: "Import",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantCreated: []entities.Expense{
+				{Description: "Taxi", Amount: 123450, Currency: "EUR", CategoryID: "category_1", DateCreation: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix()},
+				{Description: "Parking", Amount: 750, Currency: "USD", DateCreation: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC).Unix()},
+			},
+		},
+		{
+			name:      "DryRun",
+			dryRun:    true,
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			mockCategories := mocks.NewMockCategoryRepositoryInterface(ctrl)
+			mockCategories.EXPECT().List(gomock.Any()).Return([]*entities.Category{{ID: "category_1", Name: "Travel"}}, nil)
+			mockCategories.EXPECT().GetByID(gomock.Any(), "category_1").Return(&entities.Category{ID: "category_1"}, nil).AnyTimes()
+			tt.setupMock(mockRepo)
+
+			s := &importServiceImpl{
+				expenses:        &expenseServiceImpl{repo: mockRepo, categories: mockCategories, defaultCurrency: "USD"},
+				categories:      mockCategories,
+				defaultCurrency: "USD",
+			}
+			o := opts
+			o.DryRun = tt.dryRun
+			got, err := s.ImportCSV(context.TODO(), strings.NewReader(statement), o)
+			if err != nil {
+				t.Fatalf("importServiceImpl.ImportCSV() error = %v", err)
+			}
+			if got.DryRun != tt.dryRun || got.Created != 2 || got.Skipped != 2 || got.Failed != 2 {
+				t.Errorf("importServiceImpl.ImportCSV() = %+v", got)
+			}
+
+			var created []entities.Expense
+			for i, row := range got.Rows {
+				want := wantRows[i]
+				if tt.dryRun && want.Status == entities.ImportCreated {
+					want.Status = entities.ImportValid
+				}
+				if row.Line != want.Line || row.Status != want.Status || row.Error != want.Error {
+					t.Errorf("importServiceImpl.ImportCSV() row %d = %+v, want %+v", i, row, want)
+				}
+				if row.Status == entities.ImportCreated {
+					e := *row.Expense
+					if e.ID == "" || e.Status != entities.StatusDraft {
+						t.Errorf("importServiceImpl.ImportCSV() created %+v", e)
+					}
+					e.ID, e.Status, e.Version, e.Tags = "", "", 0, nil
+					created = append(created, e)
+				}
+			}
+			if !reflect.DeepEqual(created, tt.wantCreated) {
+				t.Errorf("importServiceImpl.ImportCSV() created %+v, want %+v", created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func Test_parseCSVStatement_AmountSign(t *testing.T) {
+	statement := "date,description,amount\n" +
+		"2024-01-02,Taxi,-12.50\n" +
+		"2024-01-03,Salary,2000.00\n"
+	columns := entities.CSVColumns{Date: "date", Description: "description", Amount: "amount"}
+
+	tests := []struct {
+		name      string
+		sign      entities.AmountSign
+		wantSkip  []string
+		wantSpent []entities.Money
+	}{
+		{name: "Default", wantSkip: []string{"money coming in", ""}, wantSpent: []entities.Money{0, 200000}},
+		{name: "Positive", sign: entities.ExpensesPositive, wantSkip: []string{"money coming in", ""}, wantSpent: []entities.Money{0, 200000}},
+		{name: "Negative", sign: entities.ExpensesNegative, wantSkip: []string{"", "money coming in"}, wantSpent: []entities.Money{1250, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseCSVStatement(strings.NewReader(statement), entities.CSVImportOptions{Columns: columns, AmountSign: tt.sign})
+			if err != nil {
+				t.Fatalf("parseCSVStatement() error = %v", err)
+			}
+			for i, row := range rows {
+				var spent entities.Money
+				if row.Expense != nil {
+					spent = row.Expense.Amount
+				}
+				if row.Err != nil || row.Skip != tt.wantSkip[i] || spent != tt.wantSpent[i] {
+					t.Errorf("parseCSVStatement() row %d = %+v, want skip %q and amount %d", i, row, tt.wantSkip[i], tt.wantSpent[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_parseCSVStatement_Invalid(t *testing.T) {
+	columns := entities.CSVColumns{Date: "date", Description: "description", Amount: "amount"}
+
+	tests := []struct {
+		name      string
+		statement string
+		opts      entities.CSVImportOptions
+	}{
+		{name: "Empty", statement: "", opts: entities.CSVImportOptions{Columns: columns}},
+		{name: "MissingMapping", statement: "date,description,amount\n", opts: entities.CSVImportOptions{Columns: entities.CSVColumns{Date: "date"}}},
+		{name: "MissingColumn", statement: "date,description\n", opts: entities.CSVImportOptions{Columns: columns}},
+		{name: "DateFormat", statement: "date,description,amount\n", opts: entities.CSVImportOptions{Columns: columns, DateFormat: "MMM D, YYYY"}},
+		{name: "DecimalSeparator", statement: "date,description,amount\n", opts: entities.CSVImportOptions{Columns: columns, DecimalSeparator: "'"}},
+		{name: "AmountSign", statement: "date,description,amount\n", opts: entities.CSVImportOptions{Columns: columns, AmountSign: "debit"}},
+		{name: "BadQuote", statement: "date,description,amount\n2024-01-02,\"Taxi,12.50\n", opts: entities.CSVImportOptions{Columns: columns}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCSVStatement(strings.NewReader(tt.statement), tt.opts); !errors.Is(err, ErrInvalidImport) {
+				t.Errorf("parseCSVStatement() error = %v, wantErr %v", err, ErrInvalidImport)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/demo-talent/entities"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockExpenseService) EXPECT() *MockExpenseServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveExpense mocks base method.
+func (m *MockExpenseService) ApproveExpense(arg0 context.Context, arg1 string) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveExpense", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveExpense indicates an expected call of ApproveExpense.
+func (mr *MockExpenseServiceMockRecorder) ApproveExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveExpense", reflect.TypeOf((*MockExpenseService)(nil).ApproveExpense), arg0, arg1)
+}
+
 // CreateExpense mocks base method.
 func (m *MockExpenseService) CreateExpense(arg0 context.Context, arg1 *entities.Expense) error {
 	m.ctrl.T.Helper()
@@ -50,17 +66,31 @@ func (mr *MockExpenseServiceMockRecorder) CreateExpense(arg0, arg1 interface{})
 }
 
 // DeleteExpense mocks base method.
-func (m *MockExpenseService) DeleteExpense(arg0 context.Context, arg1 string) error {
+func (m *MockExpenseService) DeleteExpense(arg0 context.Context, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExpense", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteExpense", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteExpense indicates an expected call of DeleteExpense.
-func (mr *MockExpenseServiceMockRecorder) DeleteExpense(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockExpenseServiceMockRecorder) DeleteExpense(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpense", reflect.TypeOf((*MockExpenseService)(nil).DeleteExpense), arg0, arg1, arg2)
+}
+
+// ExportExpenses mocks base method.
+func (m *MockExpenseService) ExportExpenses(arg0 context.Context, arg1 entities.ExpenseFilter, arg2 func(*entities.Expense) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportExpenses", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportExpenses indicates an expected call of ExportExpenses.
+func (mr *MockExpenseServiceMockRecorder) ExportExpenses(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpense", reflect.TypeOf((*MockExpenseService)(nil).DeleteExpense), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportExpenses", reflect.TypeOf((*MockExpenseService)(nil).ExportExpenses), arg0, arg1, arg2)
 }
 
 // GetExpenseByID mocks base method.
@@ -78,6 +108,200 @@ func (mr *MockExpenseServiceMockRecorder) GetExpenseByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseByID", reflect.TypeOf((*MockExpenseService)(nil).GetExpenseByID), arg0, arg1)
 }
 
+// GetExpenseRevision mocks base method.
+func (m *MockExpenseService) GetExpenseRevision(arg0 context.Context, arg1 string, arg2 int) (*entities.ExpenseRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.ExpenseRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseRevision indicates an expected call of GetExpenseRevision.
+func (mr *MockExpenseServiceMockRecorder) GetExpenseRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseRevision", reflect.TypeOf((*MockExpenseService)(nil).GetExpenseRevision), arg0, arg1, arg2)
+}
+
+// ImportExpense mocks base method.
+func (m *MockExpenseService) ImportExpense(arg0 context.Context, arg1 *entities.Expense) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportExpense", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportExpense indicates an expected call of ImportExpense.
+func (mr *MockExpenseServiceMockRecorder) ImportExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportExpense", reflect.TypeOf((*MockExpenseService)(nil).ImportExpense), arg0, arg1)
+}
+
+// ListExpenseRevisions mocks base method.
+func (m *MockExpenseService) ListExpenseRevisions(arg0 context.Context, arg1 string) ([]*entities.ExpenseRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpenseRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.ExpenseRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpenseRevisions indicates an expected call of ListExpenseRevisions.
+func (mr *MockExpenseServiceMockRecorder) ListExpenseRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpenseRevisions", reflect.TypeOf((*MockExpenseService)(nil).ListExpenseRevisions), arg0, arg1)
+}
+
+// ListExpenses mocks base method.
+func (m *MockExpenseService) ListExpenses(arg0 context.Context, arg1 entities.ExpenseFilter, arg2 entities.PageRequest) (*entities.ExpensePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpenses", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.ExpensePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpenses indicates an expected call of ListExpenses.
+func (mr *MockExpenseServiceMockRecorder) ListExpenses(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpenses", reflect.TypeOf((*MockExpenseService)(nil).ListExpenses), arg0, arg1, arg2)
+}
+
+// ListPendingApprovals mocks base method.
+func (m *MockExpenseService) ListPendingApprovals(arg0 context.Context) ([]*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingApprovals", arg0)
+	ret0, _ := ret[0].([]*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingApprovals indicates an expected call of ListPendingApprovals.
+func (mr *MockExpenseServiceMockRecorder) ListPendingApprovals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingApprovals", reflect.TypeOf((*MockExpenseService)(nil).ListPendingApprovals), arg0)
+}
+
+// ListTrash mocks base method.
+func (m *MockExpenseService) ListTrash(arg0 context.Context) ([]*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", arg0)
+	ret0, _ := ret[0].([]*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockExpenseServiceMockRecorder) ListTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockExpenseService)(nil).ListTrash), arg0)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockExpenseService) PurgeTrash(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockExpenseServiceMockRecorder) PurgeTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockExpenseService)(nil).PurgeTrash), arg0, arg1)
+}
+
+// ReimburseExpense mocks base method.
+func (m *MockExpenseService) ReimburseExpense(arg0 context.Context, arg1 string) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReimburseExpense", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReimburseExpense indicates an expected call of ReimburseExpense.
+func (mr *MockExpenseServiceMockRecorder) ReimburseExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReimburseExpense", reflect.TypeOf((*MockExpenseService)(nil).ReimburseExpense), arg0, arg1)
+}
+
+// RejectExpense mocks base method.
+func (m *MockExpenseService) RejectExpense(arg0 context.Context, arg1, arg2 string) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectExpense", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectExpense indicates an expected call of RejectExpense.
+func (mr *MockExpenseServiceMockRecorder) RejectExpense(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectExpense", reflect.TypeOf((*MockExpenseService)(nil).RejectExpense), arg0, arg1, arg2)
+}
+
+// RestoreExpense mocks base method.
+func (m *MockExpenseService) RestoreExpense(arg0 context.Context, arg1 string) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreExpense", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreExpense indicates an expected call of RestoreExpense.
+func (mr *MockExpenseServiceMockRecorder) RestoreExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreExpense", reflect.TypeOf((*MockExpenseService)(nil).RestoreExpense), arg0, arg1)
+}
+
+// RevertExpense mocks base method.
+func (m *MockExpenseService) RevertExpense(arg0 context.Context, arg1 string, arg2, arg3 int) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertExpense", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertExpense indicates an expected call of RevertExpense.
+func (mr *MockExpenseServiceMockRecorder) RevertExpense(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertExpense", reflect.TypeOf((*MockExpenseService)(nil).RevertExpense), arg0, arg1, arg2, arg3)
+}
+
+// RunBatch mocks base method.
+func (m *MockExpenseService) RunBatch(arg0 context.Context, arg1 *entities.ExpenseBatch) (*entities.ExpenseBatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunBatch", arg0, arg1)
+	ret0, _ := ret[0].(*entities.ExpenseBatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunBatch indicates an expected call of RunBatch.
+func (mr *MockExpenseServiceMockRecorder) RunBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunBatch", reflect.TypeOf((*MockExpenseService)(nil).RunBatch), arg0, arg1)
+}
+
+// SubmitExpense mocks base method.
+func (m *MockExpenseService) SubmitExpense(arg0 context.Context, arg1 string) (*entities.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitExpense", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitExpense indicates an expected call of SubmitExpense.
+func (mr *MockExpenseServiceMockRecorder) SubmitExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitExpense", reflect.TypeOf((*MockExpenseService)(nil).SubmitExpense), arg0, arg1)
+}
+
 // UpdateExpense mocks base method.
 func (m *MockExpenseService) UpdateExpense(arg0 context.Context, arg1 *entities.Expense) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/entities"
+)
+
+// ImportOFX creates an expense for each debit of an OFX or QFX statement,
+// dated as on the statement. Transactions are told apart by their FITID,
+// so those imported before, even from another statement of the account,
+// are skipped.
+func (s *importServiceImpl) ImportOFX(ctx context.Context, r io.Reader, dryRun bool) (*entities.ImportSummary, error) {
+	rows, err := parseOFXStatement(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.importRows(ctx, rows, dryRun)
+}
+
+// parseOFXStatement reads the transactions of the bank and card
+// statements of an OFX file, written in the SGML of OFX 1 or the XML of
+// OFX 2. Only the elements that make up transactions are looked at, so
+// both read the same.
+func parseOFXStatement(r io.Reader) ([]statementRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: not an OFX file", ErrInvalidImport)
+	}
+	line := 1 + strings.Count(text[:start], "\n")
+
+	var (
+		rows              []statementRow
+		account, currency string
+		// trn holds the elements of the transaction being read, if any.
+		trn     map[string]string
+		trnLine int
+	)
+	for i := start; ; {
+		open := strings.IndexByte(text[i:], '<')
+		if open < 0 {
+			break
+		}
+		line += strings.Count(text[i:i+open], "\n")
+		i += open
+		end := strings.IndexByte(text[i:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated tag on line %d", ErrInvalidImport, line)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(text[i+1 : i+end]))
+		i += end + 1
+		// In SGML elements are not closed, so their value runs up to the
+		// next tag.
+		value := text[i:]
+		if next := strings.IndexByte(value, '<'); next >= 0 {
+			value = value[:next]
+		}
+		value = strings.TrimSpace(html.UnescapeString(value))
+
+		switch {
+		case tag == "STMTRS" || tag == "CCSTMTRS":
+			account, currency = "", ""
+		case tag == "ACCTID":
+			account = value
+		case tag == "CURDEF":
+			currency = value
+		case tag == "STMTTRN":
+			trn, trnLine = make(map[string]string), line
+		case tag == "/STMTTRN":
+			if trn == nil {
+				return nil, fmt.Errorf("%w: unexpected </STMTTRN> on line %d", ErrInvalidImport, line)
+			}
+			if len(rows) == MaxImportRows {
+				return nil, fmt.Errorf("%w: a file holds at most %d transactions", ErrInvalidImport, MaxImportRows)
+			}
+			rows = append(rows, ofxTransaction(trn, trnLine, account, currency))
+			trn = nil
+		case trn != nil && value != "" && !strings.HasPrefix(tag, "/"):
+			// NAME comes up again in the PAYEE aggregate; the first one wins.
+			if _, ok := trn[tag]; !ok {
+				trn[tag] = value
+			}
+		}
+	}
+	if trn != nil {
+		return nil, fmt.Errorf("%w: the transaction on line %d is not closed", ErrInvalidImport, trnLine)
+	}
+	return rows, nil
+}
+
+// ofxTransaction turns the elements of an STMTTRN aggregate of the given
+// account into a row. Debits are negative in OFX and become expenses of
+// the opposite amount; credits are skipped.
+func ofxTransaction(trn map[string]string, line int, account, currency string) statementRow {
+	row := statementRow{Line: line}
+	posted := trn["DTPOSTED"]
+	if len(posted) < 8 {
+		row.Err = fmt.Errorf("DTPOSTED %q is not a date", posted)
+		return row
+	}
+	// Dates may carry a time and a time zone after the day, which is all
+	// that is kept.
+	day, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		row.Err = fmt.Errorf("DTPOSTED %q is not a date", posted)
+		return row
+	}
+	amount, err := parseOFXAmount(trn["TRNAMT"])
+	if err != nil {
+		row.Err = err
+		return row
+	}
+	switch {
+	case amount == 0:
+		return row
+	case amount > 0:
+		row.Skip = "money coming in"
+		return row
+	}
+
+	description := trn["NAME"]
+	if description == "" {
+		description = trn["MEMO"]
+	}
+	// A transaction in a currency other than the statement's names it.
+	if cur := trn["CURSYM"]; cur != "" {
+		currency = cur
+	}
+	row.Expense = &entities.Expense{Description: description, Amount: -amount, Currency: currency, DateCreation: day.Unix()}
+	if fitid := trn["FITID"]; fitid != "" {
+		// FITIDs are only unique within an account.
+		row.Expense.ExternalRef = statementRef("ofx", account, fitid)
+	}
+	return row
+}
+
+// parseOFXAmount parses an OFX amount, whose decimal separator is either
+// "." or "," and which has no thousands separator.
+func parseOFXAmount(s string) (entities.Money, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: TRNAMT is missing", entities.ErrInvalidAmount)
+	}
+	v := strings.TrimPrefix(strings.Replace(s, ",", ".", 1), "+")
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		// Some banks write more decimals than there are cents.
+		for len(v) > i+3 && v[len(v)-1] == '0' {
+			v = v[:len(v)-1]
+		}
+		if i == 0 || v[:i] == "-" {
+			v = v[:i] + "0" + v[i:]
+		}
+	}
+	m, err := entities.ParseMoney(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: TRNAMT %q is not an amount", entities.ErrInvalidAmount, s)
+	}
+	return m, nil
+}
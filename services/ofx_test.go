@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+// ofxStatement is an OFX 1 statement of a checking account followed by a
+// card statement, with some transactions written on a single line.
+const ofxStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>EUR
+<BANKACCTFROM><BANKID>123<ACCTID>0001<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240102120000.000[+1:CET]
+<TRNAMT>-12,50
+<FITID>T1
+<NAME>Caf&eacute; &amp; Co
+<MEMO>Card 1234
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240103
+<TRNAMT>1000.00
+<FITID>T2
+<NAME>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>2024
+<TRNAMT>-5.00
+<FITID>T3
+</STMTTRN>
+<STMTTRN><TRNTYPE>FEE<DTPOSTED>20240104<TRNAMT>-.5000<FITID>T4<MEMO>Fee<CURRENCY><CURRATE>1.1<CURSYM>USD</CURRENCY></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>GBP
+<CCACCTFROM><ACCTID>0002</CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240105<TRNAMT>-7.00<FITID>T1<NAME>Books<PAYEE><NAME>Ignored</PAYEE></STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func Test_parseOFXStatement(t *testing.T) {
+	rows, err := parseOFXStatement(strings.NewReader(ofxStatement))
+	if err != nil {
+		t.Fatalf("parseOFXStatement() error = %v", err)
+	}
+
+	day := func(d int) int64 { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC).Unix() }
+	want := []statementRow{
+		{Line: 10, Expense: &entities.Expense{Description: "Café & Co", Amount: 1250, Currency: "EUR", DateCreation: day(2), ExternalRef: statementRef("ofx", "0001", "T1")}},
+		{Line: 18, Skip: "money coming in"},
+		{Line: 25, Err: errors.New(`DTPOSTED "2024" is not a date`)},
+		{Line: 31, Expense: &entities.Expense{Description: "Fee", Amount: 50, Currency: "USD", DateCreation: day(4), ExternalRef: statementRef("ofx", "0001", "T4")}},
+		{Line: 38, Expense: &entities.Expense{Description: "Books", Amount: 700, Currency: "GBP", DateCreation: day(5), ExternalRef: statementRef("ofx", "0002", "T1")}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("parseOFXStatement() = %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if got, want := fmt.Sprint(row.Line, row.Expense, row.Skip, row.Err), fmt.Sprint(want[i].Line, want[i].Expense, want[i].Skip, want[i].Err); got != want {
+			t.Errorf("parseOFXStatement() row %d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func Test_parseOFXStatement_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+	}{
+		{name: "Empty", statement: ""},
+		{name: "NotOFX", statement: "date,description,amount\n"},
+		{name: "UnterminatedTag", statement: "<OFX><STMTTRN"},
+		{name: "UnclosedTransaction", statement: "<OFX><STMTTRN><TRNAMT>-1.00</OFX>"},
+		{name: "UnexpectedClose", statement: "<OFX></STMTTRN></OFX>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseOFXStatement(strings.NewReader(tt.statement)); !errors.Is(err, ErrInvalidImport) {
+				t.Errorf("parseOFXStatement() error = %v, wantErr %v", err, ErrInvalidImport)
+			}
+		})
+	}
+}
+
+func Test_importServiceImpl_ImportOFX(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	statement := `<OFX><STMTRS><CURDEF>EUR<ACCTID>0001
+<STMTTRN><DTPOSTED>20240102<TRNAMT>-1.00<FITID>T1<NAME>Imported</STMTTRN>
+<STMTTRN><DTPOSTED>20240102<TRNAMT>-2.00<FITID>T2<NAME>Taxi</STMTTRN>
+<STMTTRN><DTPOSTED>20240102<TRNAMT>-2.00<FITID>T2<NAME>Taxi</STMTTRN>
+<STMTTRN><DTPOSTED>20240103<TRNAMT>-3.00<FITID>T3<NAME>Raced</STMTTRN>
+</STMTRS></OFX>`
+	refs := []string{statementRef("ofx", "0001", "T1"), statementRef("ofx", "0001", "T2"), statementRef("ofx", "0001", "T2"), statementRef("ofx", "0001", "T3")}
+
+	tests := []struct {
+		name      string
+		dryRun    bool
+		setupMock func(mockRepo *mocks.MockExpenseRepositoryInterface)
+		wantRows  []entities.ImportRow
+	}{
+		{
+			name: "Import",
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {
+				gomock.InOrder(
+					mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *entities.Expense) error {
+						if e.ExternalRef != refs[1] || e.Amount != 200 {
+							t.Errorf("Create() expense = %+v", e)
+						}
+						return nil
+					}),
+					mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(ErrAlreadyImported),
+				)
+			},
+			wantRows: []entities.ImportRow{
+				{Line: 2, Status: entities.ImportSkipped, Reason: "already imported"},
+				{Line: 3, Status: entities.ImportCreated},
+				{Line: 4, Status: entities.ImportSkipped, Reason: "same transaction as line 3"},
+				{Line: 5, Status: entities.ImportSkipped, Reason: "already imported"},
+			},
+		},
+		{
+			name:      "DryRun",
+			dryRun:    true,
+			setupMock: func(mockRepo *mocks.MockExpenseRepositoryInterface) {},
+			wantRows: []entities.ImportRow{
+				{Line: 2, Status: entities.ImportSkipped, Reason: "already imported"},
+				{Line: 3, Status: entities.ImportValid},
+				{Line: 4, Status: entities.ImportSkipped, Reason: "same transaction as line 3"},
+				{Line: 5, Status: entities.ImportValid},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockExpenseRepositoryInterface(ctrl)
+			mockRepo.EXPECT().ExternalRefs(gomock.Any(), refs).Return(map[string]bool{refs[0]: true}, nil)
+			tt.setupMock(mockRepo)
+
+			s := &importServiceImpl{
+				expenses:        &expenseServiceImpl{repo: mockRepo, defaultCurrency: "USD"},
+				repo:            mockRepo,
+				defaultCurrency: "USD",
+			}
+			got, err := s.ImportOFX(context.TODO(), strings.NewReader(statement), tt.dryRun)
+			if err != nil {
+				t.Fatalf("importServiceImpl.ImportOFX() error = %v", err)
+			}
+			if len(got.Rows) != len(tt.wantRows) {
+				t.Fatalf("importServiceImpl.ImportOFX() = %+v", got)
+			}
+			for i, row := range got.Rows {
+				want := tt.wantRows[i]
+				if row.Line != want.Line || row.Status != want.Status || row.Reason != want.Reason || row.Error != "" {
+					t.Errorf("importServiceImpl.ImportOFX() row %d = %+v, want %+v", i, row, want)
+				}
+			}
+		})
+	}
+}
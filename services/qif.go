@@ -0,0 +1,208 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/entities"
+)
+
+// qifAccountTypes are the types of the QIF sections listing the
+// transactions of an account. Other sections, like category lists and
+// investments, are skipped.
+var qifAccountTypes = map[string]bool{"bank": true, "ccard": true, "cash": true, "oth a": true, "oth l": true}
+
+// ImportQIF creates an expense for each debit of a QIF statement, dated as
+// on the statement. QIF gives transactions no ID, so they are told apart
+// by their date, amount, payee and check number, and by how many such
+// transactions come before them on the statement; those imported before
+// from an overlapping statement are skipped.
+func (s *importServiceImpl) ImportQIF(ctx context.Context, r io.Reader, opts entities.QIFImportOptions) (*entities.ImportSummary, error) {
+	rows, err := parseQIFStatement(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.importRows(ctx, rows, opts.DryRun)
+}
+
+// parseQIFStatement reads the transactions of the account sections of a
+// QIF file. Split lines are ignored, the transaction being imported as a
+// whole.
+func parseQIFStatement(r io.Reader, opts entities.QIFImportOptions) ([]statementRow, error) {
+	if opts.DateFormat == "" {
+		opts.DateFormat = "MM/DD/YYYY"
+	}
+	layout, err := dateLayout(opts.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+	decimal, thousands, err := amountSeparators(opts.DecimalSeparator)
+	if err != nil {
+		return nil, err
+	}
+	q := qifReader{
+		format:    opts.DateFormat,
+		decimal:   decimal,
+		thousands: thousands,
+		currency:  opts.Currency,
+		seen:      make(map[string]int),
+	}
+	// Days and months may lack their leading zero, and Quicken writes
+	// years of this century with two digits after an apostrophe, as in
+	// 1/ 2'24.
+	q.yearSeparator = "/"
+	if i := strings.Index(layout, "2006"); i > 0 {
+		q.yearSeparator = layout[i-1 : i]
+	}
+	for _, l := range []string{layout, strings.Replace(layout, "2006", "06", 1)} {
+		q.layouts = append(q.layouts, l, qifLayout(l))
+	}
+
+	var (
+		rows    []statementRow
+		section string
+		// fields holds the fields of the transaction being read, if any.
+		fields map[byte]string
+		start  int
+	)
+	end := func() error {
+		if fields != nil && qifAccountTypes[section] {
+			if len(rows) == MaxImportRows {
+				return fmt.Errorf("%w: a file holds at most %d transactions", ErrInvalidImport, MaxImportRows)
+			}
+			rows = append(rows, q.transaction(fields, start))
+		}
+		fields = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		switch text[0] {
+		case '!':
+			header := strings.ToLower(strings.TrimSpace(text[1:]))
+			if strings.HasPrefix(header, "type:") {
+				section = strings.TrimSpace(strings.TrimPrefix(header, "type:"))
+			} else if header == "account" {
+				section = header
+			}
+			// Options such as !Option:AutoSwitch change nothing here.
+			continue
+		case '^':
+			if err := end(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if section == "" {
+			return nil, fmt.Errorf("%w: not a QIF file", ErrInvalidImport)
+		}
+		if fields == nil {
+			fields, start = make(map[byte]string), line
+		}
+		// Split lines repeat their codes; the first one wins.
+		if _, ok := fields[text[0]]; !ok {
+			fields[text[0]] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		return nil, err
+	}
+	if section == "" {
+		return nil, fmt.Errorf("%w: not a QIF file", ErrInvalidImport)
+	}
+	// Some exports leave out the ^ ending the last transaction.
+	if err := end(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// qifLayout makes the leading zeros of the days and months of a time
+// layout optional.
+func qifLayout(layout string) string {
+	return strings.NewReplacer("01", "1", "02", "2").Replace(layout)
+}
+
+// qifReader turns the fields of QIF transactions into rows.
+type qifReader struct {
+	layouts            []string
+	format             string
+	decimal, thousands string
+	currency           string
+	// yearSeparator stands for the apostrophe before two-digit years.
+	yearSeparator string
+	// seen counts the transactions read so far by what identifies them.
+	seen map[string]int
+}
+
+// transaction turns the fields of the transaction starting on line into a
+// row. Debits are negative in QIF and become expenses of the opposite
+// amount; credits are skipped.
+func (q *qifReader) transaction(fields map[byte]string, line int) statementRow {
+	row := statementRow{Line: line}
+	date := strings.NewReplacer("'", q.yearSeparator, " ", "").Replace(fields['D'])
+	var day time.Time
+	var err error
+	for _, layout := range q.layouts {
+		if day, err = time.Parse(layout, date); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		row.Err = fmt.Errorf("date %q is not %s", fields['D'], q.format)
+		return row
+	}
+
+	value := fields['T']
+	if value == "" {
+		value = fields['U']
+	}
+	if value == "" {
+		row.Err = fmt.Errorf("%w: the amount is missing", entities.ErrInvalidAmount)
+		return row
+	}
+	amount, err := parseLocalAmount(value, q.decimal, q.thousands)
+	if err != nil {
+		row.Err = err
+		return row
+	}
+	switch {
+	case amount == 0:
+		return row
+	case amount > 0:
+		row.Skip = "money coming in"
+		return row
+	}
+
+	description := fields['P']
+	if description == "" {
+		description = fields['M']
+	}
+	key := strings.Join([]string{day.Format("2006-01-02"), amount.String(), description, fields['N']}, "\x00")
+	q.seen[key]++
+	row.Expense = &entities.Expense{
+		Description:  description,
+		Amount:       -amount,
+		Currency:     q.currency,
+		DateCreation: day.Unix(),
+		ExternalRef:  statementRef("qif", key, strconv.Itoa(q.seen[key])),
+	}
+	return row
+}
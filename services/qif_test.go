@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/entities"
+)
+
+func Test_parseQIFStatement(t *testing.T) {
+	statement := "\ufeff!Type:Cat\nNGroceries\n^\n" +
+		"!Account\nNChecking\nTBank\n^\n" +
+		"!Type:Bank\n" +
+		"D02.01.2024\nT-1.234,50\nPRent\nMJanuary\nN101\n^\n" +
+		"D3.1.24\nT-4,00\nPCoffee\n^\n" +
+		"D3.1'24\nU-4,00\nPCoffee\n^\n" +
+		"D04.01.2024\nT2.000,00\nPSalary\n^\n" +
+		"D2024-01-05\nT-1,00\n^\n" +
+		"D06.01.2024\nT-5,00\nMSnacks\nSFood\n$-3,00\nSDrinks\n$-2,00\n"
+	rows, err := parseQIFStatement(strings.NewReader(statement), entities.QIFImportOptions{DateFormat: "DD.MM.YYYY", DecimalSeparator: ",", Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("parseQIFStatement() error = %v", err)
+	}
+
+	day := func(d int) int64 { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC).Unix() }
+	ref := func(date, amount, payee, number string, n int) string {
+		return statementRef("qif", strings.Join([]string{date, amount, payee, number}, "\x00"), fmt.Sprint(n))
+	}
+	want := []statementRow{
+		{Line: 9, Expense: &entities.Expense{Description: "Rent", Amount: 123450, Currency: "EUR", DateCreation: day(2), ExternalRef: ref("2024-01-02", "-1234.50", "Rent", "101", 1)}},
+		{Line: 15, Expense: &entities.Expense{Description: "Coffee", Amount: 400, Currency: "EUR", DateCreation: day(3), ExternalRef: ref("2024-01-03", "-4.00", "Coffee", "", 1)}},
+		{Line: 19, Expense: &entities.Expense{Description: "Coffee", Amount: 400, Currency: "EUR", DateCreation: day(3), ExternalRef: ref("2024-01-03", "-4.00", "Coffee", "", 2)}},
+		{Line: 23, Skip: "money coming in"},
+		{Line: 27, Err: errors.New(`date "2024-01-05" is not DD.MM.YYYY`)},
+		{Line: 30, Expense: &entities.Expense{Description: "Snacks", Amount: 500, Currency: "EUR", DateCreation: day(6), ExternalRef: ref("2024-01-06", "-5.00", "Snacks", "", 1)}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("parseQIFStatement() = %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if got, want := fmt.Sprint(row.Line, row.Expense, row.Skip, row.Err), fmt.Sprint(want[i].Line, want[i].Expense, want[i].Skip, want[i].Err); got != want {
+			t.Errorf("parseQIFStatement() row %d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func Test_parseQIFStatement_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		opts      entities.QIFImportOptions
+	}{
+		{name: "Empty", statement: ""},
+		{name: "NoHeader", statement: "D01/02/2024\nT-1.00\n^\n"},
+		{name: "DateFormat", statement: "!Type:Bank\n", opts: entities.QIFImportOptions{DateFormat: "MMM D, YYYY"}},
+		{name: "DecimalSeparator", statement: "!Type:Bank\n", opts: entities.QIFImportOptions{DecimalSeparator: "'"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseQIFStatement(strings.NewReader(tt.statement), tt.opts); !errors.Is(err, ErrInvalidImport) {
+				t.Errorf("parseQIFStatement() error = %v, wantErr %v", err, ErrInvalidImport)
+			}
+		})
+	}
+}
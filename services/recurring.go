@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// ErrInvalidRecurringExpense is returned when a recurring expense fails
+// validation.
+var ErrInvalidRecurringExpense = errors.New("invalid recurring expense")
+
+// maxCatchUp bounds how many missed occurrences of one template a single
+// run materializes, so a long outage does not flood the expenses table.
+const maxCatchUp = 100
+
+// RecurringExpenseService defines the interface for recurring expense
+// templates and their materialization.
+type RecurringExpenseService interface {
+	CreateRecurringExpense(ctx context.Context, r *entities.RecurringExpense) error
+	GetRecurringExpenseByID(ctx context.Context, id string) (*entities.RecurringExpense, error)
+	ListRecurringExpenses(ctx context.Context) ([]*entities.RecurringExpense, error)
+	UpdateRecurringExpense(ctx context.Context, r *entities.RecurringExpense) error
+	DeleteRecurringExpense(ctx context.Context, id string) error
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type recurringExpenseServiceImpl struct {
+	repo            repository.RecurringExpenseRepositoryInterface
+	categories      repository.CategoryRepositoryInterface
+	workspaces      repository.WorkspaceRepositoryInterface
+	expenses        ExpenseService
+	defaultCurrency string
+}
+
+// NewRecurringExpenseService creates a new instance of
+// RecurringExpenseService materializing occurrences through expenses, for
+// the owners workspaces still let write expenses.
+func NewRecurringExpenseService(repo repository.RecurringExpenseRepositoryInterface, categories repository.CategoryRepositoryInterface, workspaces repository.WorkspaceRepositoryInterface, expenses ExpenseService, defaultCurrency string) RecurringExpenseService {
+	return &recurringExpenseServiceImpl{repo: repo, categories: categories, workspaces: workspaces, expenses: expenses, defaultCurrency: defaultCurrency}
+}
+
+// CreateRecurringExpense creates a new recurring expense template.
+func (s *recurringExpenseServiceImpl) CreateRecurringExpense(ctx context.Context, r *entities.RecurringExpense) error {
+	now := time.Now()
+	if r.StartAt == 0 {
+		r.StartAt = now.Unix()
+	}
+	if err := s.validate(ctx, r); err != nil {
+		return err
+	}
+
+	r.ID = generateID("recurring")
+	r.DateCreation = now.Unix()
+	r.NextRun = r.NextAfter(time.Unix(r.StartAt-1, 0))
+
+	return s.repo.Create(ctx, r)
+}
+
+// GetRecurringExpenseByID retrieves a recurring expense template by its ID.
+func (s *recurringExpenseServiceImpl) GetRecurringExpenseByID(ctx context.Context, id string) (*entities.RecurringExpense, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListRecurringExpenses retrieves every recurring expense template.
+func (s *recurringExpenseServiceImpl) ListRecurringExpenses(ctx context.Context) ([]*entities.RecurringExpense, error) {
+	return s.repo.List(ctx)
+}
+
+// UpdateRecurringExpense updates a recurring expense template. The schedule
+// resumes at the first occurrence after the last one already due, so
+// changing it never re-creates past occurrences.
+func (s *recurringExpenseServiceImpl) UpdateRecurringExpense(ctx context.Context, r *entities.RecurringExpense) error {
+	current, err := s.repo.GetByID(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	if r.StartAt == 0 {
+		r.StartAt = current.StartAt
+	}
+	if err := s.validate(ctx, r); err != nil {
+		return err
+	}
+
+	resumeAfter := time.Unix(r.StartAt-1, 0)
+	if current.NextRun == nil {
+		resumeAfter = time.Now()
+	} else if last := time.Unix(*current.NextRun-1, 0); last.After(resumeAfter) {
+		resumeAfter = last
+	}
+	r.NextRun = r.NextAfter(resumeAfter)
+
+	return s.repo.Update(ctx, r)
+}
+
+// DeleteRecurringExpense deletes a recurring expense template by its ID.
+func (s *recurringExpenseServiceImpl) DeleteRecurringExpense(ctx context.Context, id string) error {
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// RunDue materializes every occurrence due at now and returns how many
+// expenses it created. Each occurrence is claimed in the transaction
+// creating its expense, so it is created at most once even across restarts
+// or several schedulers, and a failed creation is retried on the next run.
+// The occurrences due while the owner of a template may not write expenses
+// in its workspace, having left it or been made a viewer, are skipped
+// without creating anything.
+func (s *recurringExpenseServiceImpl) RunDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.ListDue(ctx, now.Unix())
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, r := range due {
+		role, err := s.ownerRole(ctx, r)
+		if err != nil {
+			log.Printf("Error checking the owner of recurring expense %s: %v", r.ID, err)
+			continue
+		}
+		if !role.Can(auth.PermWriteExpenses) {
+			log.Printf("Skipping recurring expense %s: its owner %s may no longer write expenses in workspace %s", r.ID, r.OwnerID, r.WorkspaceID)
+			if err := s.repo.AdvanceNextRun(ctx, r.ID, *r.NextRun, r.NextAfter(now)); err != nil {
+				return created, err
+			}
+			continue
+		}
+
+		for i := 0; r.NextRun != nil && *r.NextRun <= now.Unix() && i < maxCatchUp; i++ {
+			scheduledAt := *r.NextRun
+			err := s.materialize(ctx, r, role, scheduledAt)
+			if err != nil && !errors.Is(err, repository.ErrOccurrenceClaimed) {
+				log.Printf("Error materializing recurring expense %s at %d: %v", r.ID, scheduledAt, err)
+				break
+			}
+			if err == nil {
+				created++
+			}
+
+			r.NextRun = r.NextAfter(time.Unix(scheduledAt, 0))
+			if err := s.repo.AdvanceNextRun(ctx, r.ID, scheduledAt, r.NextRun); err != nil {
+				return created, err
+			}
+		}
+	}
+	return created, nil
+}
+
+// ownerRole is the current role of the owner of a template in its
+// workspace, empty once the owner is no longer a member.
+func (s *recurringExpenseServiceImpl) ownerRole(ctx context.Context, r *entities.RecurringExpense) (auth.Role, error) {
+	w, err := s.workspaces.GetForMember(ctx, r.WorkspaceID, r.OwnerID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return auth.Role(w.Role), nil
+}
+
+// materialize creates the expense of one occurrence, on behalf of the
+// owner of the template, with its role, and in its workspace. It fails with
+// repository.ErrOccurrenceClaimed when the occurrence was already created.
+// The expense is dated at the occurrence, so catching up after downtime
+// does not date it at the time of the run.
+func (s *recurringExpenseServiceImpl) materialize(ctx context.Context, r *entities.RecurringExpense, role auth.Role, scheduledAt int64) error {
+	ctx = auth.WithPrincipal(ctx, auth.Principal{UserID: r.OwnerID, WorkspaceID: r.WorkspaceID, Role: role})
+	ctx = repository.WithRecurringOccurrence(ctx, r.ID, scheduledAt)
+	e := &entities.Expense{
+		Description:  r.Description,
+		Amount:       r.Amount,
+		Currency:     r.Currency,
+		DateCreation: scheduledAt,
+		CategoryID:   r.CategoryID,
+		Tags:         append([]string{}, r.Tags...),
+	}
+	return s.expenses.ImportExpense(ctx, e)
+}
+
+// validate checks and normalizes the fields of a template.
+func (s *recurringExpenseServiceImpl) validate(ctx context.Context, r *entities.RecurringExpense) error {
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Description == "" {
+		return fmt.Errorf("%w: description is required", ErrInvalidRecurringExpense)
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	if !r.Frequency.Valid() {
+		return fmt.Errorf("%w: frequency must be daily, weekly, monthly or yearly", ErrInvalidRecurringExpense)
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidRecurringExpense)
+	}
+	if r.EndAt != nil && *r.EndAt < r.StartAt {
+		return fmt.Errorf("%w: end_at is before start_at", ErrInvalidRecurringExpense)
+	}
+
+	if r.Currency == "" {
+		r.Currency = s.defaultCurrency
+	}
+	var err error
+	if r.Currency, err = entities.NormalizeCurrency(r.Currency); err != nil {
+		return err
+	}
+	if r.Tags, err = normalizeTags(r.Tags); err != nil {
+		return err
+	}
+	if r.Tags == nil {
+		r.Tags = []string{}
+	}
+
+	if r.CategoryID != "" {
+		_, err := s.categories.GetByID(ctx, r.CategoryID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrUnknownCategory, r.CategoryID)
+		}
+		return err
+	}
+	return nil
+}
+
+// RunRecurringScheduler materializes due recurring expenses every interval
+// until ctx is done.
+func RunRecurringScheduler(ctx context.Context, svc RecurringExpenseService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := svc.RunDue(ctx, time.Now())
+		if err != nil {
+			log.Printf("Error running recurring expenses: %v", err)
+		} else if created > 0 {
+			log.Printf("Created %d recurring expenses", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_recurringExpenseServiceImpl_RunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRecurringExpenseRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+
+	jan := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC).Unix()
+	feb := time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC).Unix()
+	mar := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC).Unix()
+	now := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	rent := &entities.RecurringExpense{
+		ID:          "recurring_1",
+		Description: "Rent",
+		Amount:      120000,
+		Currency:    "MXN",
+		Frequency:   entities.FrequencyMonthly,
+		Interval:    1,
+		StartAt:     jan,
+		NextRun:     &jan,
+		OwnerID:     "user_1",
+		WorkspaceID: "workspace_1",
+	}
+
+	mockRepo.EXPECT().ListDue(gomock.Any(), now.Unix()).Return([]*entities.RecurringExpense{rent}, nil)
+	mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(&entities.Workspace{ID: "workspace_1", Role: "member"}, nil)
+	gomock.InOrder(
+		// January was created by a previous run that crashed before advancing.
+		mockExpenses.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrOccurrenceClaimed),
+		mockRepo.EXPECT().AdvanceNextRun(gomock.Any(), "recurring_1", jan, &feb).Return(nil),
+		mockExpenses.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *entities.Expense) error {
+			if p, _ := auth.PrincipalFromContext(ctx); p.UserID != "user_1" || p.WorkspaceID != "workspace_1" || p.Role != auth.RoleMember {
+				t.Errorf("expense created on behalf of %+v, want the template owner with their role", p)
+			}
+			if e.Description != "Rent" || e.Amount != 120000 || e.Currency != "MXN" || e.DateCreation != feb {
+				t.Errorf("materialized expense = %+v, want the template fields", e)
+			}
+			return nil
+		}),
+		mockRepo.EXPECT().AdvanceNextRun(gomock.Any(), "recurring_1", feb, &mar).Return(nil),
+	)
+
+	s := &recurringExpenseServiceImpl{
+		repo:       mockRepo,
+		workspaces: mockWorkspaces,
+		expenses:   &expenseServiceImpl{repo: mockExpenses, defaultCurrency: "USD"},
+	}
+	created, err := s.RunDue(context.TODO(), now)
+	if err != nil {
+		t.Fatalf("recurringExpenseServiceImpl.RunDue() error = %v", err)
+	}
+	if created != 1 {
+		t.Errorf("recurringExpenseServiceImpl.RunDue() created %d expenses, want 1", created)
+	}
+}
+
+func Test_recurringExpenseServiceImpl_RunDue_CatchUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRecurringExpenseRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+
+	// The scheduler was down from January to early May.
+	occurrences := []int64{
+		time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+	now := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	rent := &entities.RecurringExpense{
+		ID:          "recurring_1",
+		Description: "Rent",
+		Amount:      120000,
+		Currency:    "MXN",
+		Frequency:   entities.FrequencyMonthly,
+		Interval:    1,
+		StartAt:     occurrences[0],
+		NextRun:     &occurrences[0],
+		OwnerID:     "user_1",
+		WorkspaceID: "workspace_1",
+	}
+
+	mockRepo.EXPECT().ListDue(gomock.Any(), now.Unix()).Return([]*entities.RecurringExpense{rent}, nil)
+	mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(&entities.Workspace{ID: "workspace_1", Role: "member"}, nil)
+	var calls []*gomock.Call
+	for i, at := range occurrences[:4] {
+		at, next := at, occurrences[i+1]
+		calls = append(calls,
+			mockExpenses.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, e *entities.Expense) error {
+				if e.DateCreation != at {
+					t.Errorf("materialized expense dated %v, want %v", time.Unix(e.DateCreation, 0).UTC(), time.Unix(at, 0).UTC())
+				}
+				return nil
+			}),
+			mockRepo.EXPECT().AdvanceNextRun(gomock.Any(), "recurring_1", at, &next).Return(nil),
+		)
+	}
+	gomock.InOrder(calls...)
+
+	s := &recurringExpenseServiceImpl{
+		repo:       mockRepo,
+		workspaces: mockWorkspaces,
+		expenses:   &expenseServiceImpl{repo: mockExpenses, defaultCurrency: "USD"},
+	}
+	created, err := s.RunDue(context.TODO(), now)
+	if err != nil {
+		t.Fatalf("recurringExpenseServiceImpl.RunDue() error = %v", err)
+	}
+	if created != 4 {
+		t.Errorf("recurringExpenseServiceImpl.RunDue() created %d expenses, want 4", created)
+	}
+}
+
+func Test_recurringExpenseServiceImpl_RunDue_OwnerLostAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jan := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC).Unix()
+	mar := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC).Unix()
+	now := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		workspace *entities.Workspace
+		err       error
+	}{
+		{name: "Removed", err: repository.ErrNotFound},
+		{name: "Viewer", workspace: &entities.Workspace{ID: "workspace_1", Role: "viewer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockRecurringExpenseRepositoryInterface(ctrl)
+			mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+
+			rent := &entities.RecurringExpense{
+				ID:          "recurring_1",
+				Description: "Rent",
+				Amount:      120000,
+				Frequency:   entities.FrequencyMonthly,
+				Interval:    1,
+				StartAt:     jan,
+				NextRun:     &jan,
+				OwnerID:     "user_1",
+				WorkspaceID: "workspace_1",
+			}
+			mockRepo.EXPECT().ListDue(gomock.Any(), now.Unix()).Return([]*entities.RecurringExpense{rent}, nil)
+			mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(tt.workspace, tt.err)
+			// The missed occurrences are skipped without creating anything.
+			mockRepo.EXPECT().AdvanceNextRun(gomock.Any(), "recurring_1", jan, &mar).Return(nil)
+
+			s := &recurringExpenseServiceImpl{repo: mockRepo, workspaces: mockWorkspaces}
+			created, err := s.RunDue(context.TODO(), now)
+			if err != nil {
+				t.Fatalf("recurringExpenseServiceImpl.RunDue() error = %v", err)
+			}
+			if created != 0 {
+				t.Errorf("recurringExpenseServiceImpl.RunDue() created %d expenses, want 0", created)
+			}
+		})
+	}
+}
+
+func Test_recurringExpenseServiceImpl_RunDue_Failures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRecurringExpenseRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+
+	jan := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC).Unix()
+	now := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	template := func(id string) *entities.RecurringExpense {
+		return &entities.RecurringExpense{
+			ID:          id,
+			Description: "Rent",
+			Amount:      120000,
+			Currency:    "MXN",
+			Frequency:   entities.FrequencyMonthly,
+			Interval:    1,
+			StartAt:     jan,
+			NextRun:     &jan,
+			OwnerID:     "user_" + id,
+			WorkspaceID: "workspace_1",
+		}
+	}
+
+	mockRepo.EXPECT().ListDue(gomock.Any(), now.Unix()).Return([]*entities.RecurringExpense{template("1"), template("2")}, nil)
+	// The role of the first owner cannot be checked, which must not stop
+	// the run for the second one.
+	mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(nil, errors.New("connection reset"))
+	mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_2").Return(&entities.Workspace{ID: "workspace_1", Role: "member"}, nil)
+	// The expense of the second one fails to be created, so its occurrence
+	// is left due for the next run instead of being advanced past.
+	mockExpenses.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	s := &recurringExpenseServiceImpl{
+		repo:       mockRepo,
+		workspaces: mockWorkspaces,
+		expenses:   &expenseServiceImpl{repo: mockExpenses, defaultCurrency: "USD"},
+	}
+	created, err := s.RunDue(context.TODO(), now)
+	if err != nil {
+		t.Fatalf("recurringExpenseServiceImpl.RunDue() error = %v", err)
+	}
+	if created != 0 {
+		t.Errorf("recurringExpenseServiceImpl.RunDue() created %d expenses, want 0", created)
+	}
+}
+
+func Test_recurringExpenseServiceImpl_CreateRecurringExpense(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRecurringExpenseRepositoryInterface(ctrl)
+
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC).Unix()
+	tests := []struct {
+		name      string
+		r         *entities.RecurringExpense
+		wantErr   bool
+		setupMock func()
+	}{
+		{
+			name:    "CreateRecurringExpense_Success",
+			r:       &entities.RecurringExpense{Description: "Netflix", Amount: 1599, Frequency: entities.FrequencyMonthly, StartAt: start},
+			wantErr: false,
+			setupMock: func() {
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *entities.RecurringExpense) error {
+					if r.Interval != 1 || r.Currency != "USD" || r.NextRun == nil || *r.NextRun != start {
+						t.Errorf("created recurring expense = %+v, want defaults and next_run at start", r)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name:      "CreateRecurringExpense_InvalidFrequency",
+			r:         &entities.RecurringExpense{Description: "Netflix", Amount: 1599, Frequency: "hourly"},
+			wantErr:   true,
+			setupMock: func() {},
+		},
+		{
+			name:      "CreateRecurringExpense_EndBeforeStart",
+			r:         &entities.RecurringExpense{Description: "Netflix", Amount: 1599, Frequency: entities.FrequencyMonthly, StartAt: start, EndAt: new(int64)},
+			wantErr:   true,
+			setupMock: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &recurringExpenseServiceImpl{
+				repo:            mockRepo,
+				defaultCurrency: "USD",
+			}
+			if err := s.CreateRecurringExpense(context.TODO(), tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("recurringExpenseServiceImpl.CreateRecurringExpense() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+// ErrInvalidSplit is returned when a split or a settlement fails
+// validation.
+var ErrInvalidSplit = errors.New("invalid split")
+
+// maxExactSettleUp bounds the number of non-zero balances of a currency
+// for which SettleUp searches the minimal set of transfers, as the search
+// is exponential in it. Larger groups are settled greedily.
+const maxExactSettleUp = 16
+
+// maxSplitShares bounds the shares of a participant so that allocating
+// any amount by them cannot overflow.
+const maxSplitShares = 1_000_000
+
+// SplitService defines the interface for shared expenses, the balances
+// they leave between the members of a workspace and their settlement.
+type SplitService interface {
+	SetSplits(ctx context.Context, expenseID string, req entities.SplitRequest) ([]entities.ExpenseSplit, error)
+	ListSplits(ctx context.Context, expenseID string) ([]entities.ExpenseSplit, error)
+	Balances(ctx context.Context) ([]entities.Balance, error)
+	SettleUp(ctx context.Context) ([]entities.Transfer, error)
+	RecordSettlement(ctx context.Context, st *entities.Settlement) error
+	ListSettlements(ctx context.Context) ([]*entities.Settlement, error)
+}
+
+type splitServiceImpl struct {
+	repo       repository.SplitRepositoryInterface
+	expenses   repository.ExpenseRepositoryInterface
+	workspaces repository.WorkspaceRepositoryInterface
+}
+
+// NewSplitService creates a new instance of SplitService.
+func NewSplitService(repo repository.SplitRepositoryInterface, expenses repository.ExpenseRepositoryInterface, workspaces repository.WorkspaceRepositoryInterface) SplitService {
+	return &splitServiceImpl{repo: repo, expenses: expenses, workspaces: workspaces}
+}
+
+// SetSplits divides an expense of the user in ctx among members of its
+// workspace and replaces its previous splits. Without participants the
+// expense is no longer shared.
+func (s *splitServiceImpl) SetSplits(ctx context.Context, expenseID string, req entities.SplitRequest) ([]entities.ExpenseSplit, error) {
+	e, err := s.expenses.GetByID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Participants) == 0 {
+		return []entities.ExpenseSplit{}, s.repo.SetSplits(ctx, expenseID, nil)
+	}
+
+	amounts, err := allocate(e.Amount, req)
+	if err != nil {
+		return nil, err
+	}
+	splits := make([]entities.ExpenseSplit, len(amounts))
+	for i, p := range req.Participants {
+		if err := s.checkMember(ctx, p.UserID); err != nil {
+			return nil, err
+		}
+		splits[i] = entities.ExpenseSplit{ExpenseID: expenseID, UserID: p.UserID, Amount: amounts[i]}
+	}
+
+	if err := s.repo.SetSplits(ctx, expenseID, splits); err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// ListSplits retrieves the splits of an expense of the user in ctx.
+func (s *splitServiceImpl) ListSplits(ctx context.Context, expenseID string) ([]entities.ExpenseSplit, error) {
+	if _, err := s.expenses.GetByID(ctx, expenseID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListSplits(ctx, expenseID)
+}
+
+// Balances retrieves the non-zero balances of the workspace in ctx.
+func (s *splitServiceImpl) Balances(ctx context.Context) ([]entities.Balance, error) {
+	return s.repo.Balances(ctx)
+}
+
+// SettleUp computes, for each currency, the fewest transfers that zero out
+// the balances of the workspace in ctx. Recording them as settlements
+// leaves every balance at zero.
+func (s *splitServiceImpl) SettleUp(ctx context.Context) ([]entities.Transfer, error) {
+	balances, err := s.repo.Balances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return settleUp(balances), nil
+}
+
+// RecordSettlement records a payment from the user in ctx to another
+// member of the workspace.
+func (s *splitServiceImpl) RecordSettlement(ctx context.Context, st *entities.Settlement) error {
+	user, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	if st.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidSplit)
+	}
+	if st.ToUserID == "" || st.ToUserID == user {
+		return fmt.Errorf("%w: to_user_id must be another member", ErrInvalidSplit)
+	}
+	if st.Currency, err = entities.NormalizeCurrency(st.Currency); err != nil {
+		return err
+	}
+	if err := s.checkMember(ctx, st.ToUserID); err != nil {
+		return err
+	}
+
+	st.ID = generateID("settlement")
+	st.FromUserID = user
+	st.DateCreation = time.Now().Unix()
+
+	return s.repo.CreateSettlement(ctx, st)
+}
+
+// ListSettlements retrieves the settlements of the workspace in ctx.
+func (s *splitServiceImpl) ListSettlements(ctx context.Context) ([]*entities.Settlement, error) {
+	return s.repo.ListSettlements(ctx)
+}
+
+// checkMember reports ErrInvalidSplit unless userID is a member of the
+// workspace in ctx.
+func (s *splitServiceImpl) checkMember(ctx context.Context, userID string) error {
+	workspace, err := auth.WorkspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.workspaces.GetForMember(ctx, workspace, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s is not a member of the workspace", ErrInvalidSplit, userID)
+	}
+	return err
+}
+
+// allocate divides amount among the participants of req. Apart from exact
+// splits, the cents that do not divide evenly go to the participants with
+// the largest remainders, so the parts always add up to amount.
+func allocate(amount entities.Money, req entities.SplitRequest) ([]entities.Money, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: only expenses with a positive amount can be split", ErrInvalidSplit)
+	}
+	seen := make(map[string]bool, len(req.Participants))
+	for _, p := range req.Participants {
+		if strings.TrimSpace(p.UserID) == "" {
+			return nil, fmt.Errorf("%w: user_id is required", ErrInvalidSplit)
+		}
+		if seen[p.UserID] {
+			return nil, fmt.Errorf("%w: %s takes part more than once", ErrInvalidSplit, p.UserID)
+		}
+		seen[p.UserID] = true
+	}
+
+	weights := make([]int64, len(req.Participants))
+	switch req.Method {
+	case entities.SplitEqual:
+		for i := range weights {
+			weights[i] = 1
+		}
+	case entities.SplitExact:
+		amounts := make([]entities.Money, len(req.Participants))
+		var total entities.Money
+		for i, p := range req.Participants {
+			if p.Amount < 0 {
+				return nil, fmt.Errorf("%w: amounts cannot be negative", ErrInvalidSplit)
+			}
+			amounts[i] = p.Amount
+			total += p.Amount
+		}
+		if total != amount {
+			return nil, fmt.Errorf("%w: amounts add up to %s, not %s", ErrInvalidSplit, total, amount)
+		}
+		return amounts, nil
+	case entities.SplitPercentage:
+		var total int64
+		for i, p := range req.Participants {
+			if math.IsNaN(p.Percentage) || p.Percentage < 0 || p.Percentage > 100 {
+				return nil, fmt.Errorf("%w: percentages must be between 0 and 100", ErrInvalidSplit)
+			}
+			// Percentages are weighed in basis points, so 33.33 is kept exact.
+			weights[i] = int64(math.Round(p.Percentage * 100))
+			total += weights[i]
+		}
+		if total != 100_00 {
+			return nil, fmt.Errorf("%w: percentages must add up to 100", ErrInvalidSplit)
+		}
+	case entities.SplitShares:
+		for i, p := range req.Participants {
+			if p.Shares <= 0 || p.Shares > maxSplitShares {
+				return nil, fmt.Errorf("%w: shares must be between 1 and %d", ErrInvalidSplit, maxSplitShares)
+			}
+			weights[i] = p.Shares
+		}
+	default:
+		return nil, fmt.Errorf("%w: method must be equal, exact, percentage or shares", ErrInvalidSplit)
+	}
+
+	return allocateByWeight(amount, weights)
+}
+
+// allocateByWeight divides amount in proportion to weights, which must add
+// up to a positive total, with the largest remainder method. Ties go to
+// the earliest participant.
+func allocateByWeight(amount entities.Money, weights []int64) ([]entities.Money, error) {
+	var total int64
+	for _, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("%w: weights cannot be negative", ErrInvalidSplit)
+		}
+		total += w
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("%w: weights must add up to a positive total", ErrInvalidSplit)
+	}
+
+	parts := make([]entities.Money, len(weights))
+	remainders := make([]int64, len(weights))
+	left := amount
+	for i, w := range weights {
+		// Amounts and weights are small enough for the product to fit.
+		parts[i] = entities.Money(int64(amount) * w / total)
+		remainders[i] = int64(amount) * w % total
+		left -= parts[i]
+	}
+	if left < 0 || int(left) > len(weights) {
+		return nil, fmt.Errorf("%w: weights do not divide %s", ErrInvalidSplit, amount)
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for _, i := range order[:left] {
+		parts[i]++
+	}
+	return parts, nil
+}
+
+// settleUp returns the transfers that zero out balances, currency by
+// currency.
+func settleUp(balances []entities.Balance) []entities.Transfer {
+	var currencies []string
+	byCurrency := make(map[string][]entities.Balance)
+	for _, b := range balances {
+		if b.Amount == 0 {
+			continue
+		}
+		if _, ok := byCurrency[b.Currency]; !ok {
+			currencies = append(currencies, b.Currency)
+		}
+		byCurrency[b.Currency] = append(byCurrency[b.Currency], b)
+	}
+	sort.Strings(currencies)
+
+	transfers := []entities.Transfer{}
+	for _, c := range currencies {
+		group := byCurrency[c]
+		if len(group) <= maxExactSettleUp {
+			for _, g := range zeroSumGroups(group) {
+				transfers = append(transfers, matchGreedily(g)...)
+			}
+		} else {
+			transfers = append(transfers, matchGreedily(group)...)
+		}
+	}
+	return transfers
+}
+
+// zeroSumGroups partitions balances, which add up to zero, into as many
+// groups adding up to zero as possible. A group of n balances settles with
+// n-1 transfers, so the most groups take the fewest transfers overall.
+func zeroSumGroups(balances []entities.Balance) [][]entities.Balance {
+	n := len(balances)
+	full := 1<<n - 1
+
+	// groups[mask] is the most zero-sum groups the balances of mask split
+	// into, counting what is left over as a group when mask adds up to zero.
+	sums := make([]entities.Money, full+1)
+	groups := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		low := mask & -mask
+		sums[mask] = sums[mask^low] + balances[bitIndex(low)].Amount
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 && groups[mask^(1<<i)] > groups[mask] {
+				groups[mask] = groups[mask^(1<<i)]
+			}
+		}
+		if sums[mask] == 0 {
+			groups[mask]++
+		}
+	}
+
+	// Removing the balances in reverse order of an optimal sequence makes
+	// every group a run of consecutive balances ending at a zero sum.
+	order := make([]int, 0, n)
+	for mask := full; mask != 0; {
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			rest := groups[mask^(1<<i)]
+			if sums[mask] == 0 {
+				rest++
+			}
+			if rest == groups[mask] {
+				order = append(order, i)
+				mask ^= 1 << i
+				break
+			}
+		}
+	}
+
+	var result [][]entities.Balance
+	var current []entities.Balance
+	var sum entities.Money
+	for j := len(order) - 1; j >= 0; j-- {
+		current = append(current, balances[order[j]])
+		sum += balances[order[j]].Amount
+		if sum == 0 {
+			result = append(result, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// bitIndex returns the position of the single bit set in bit.
+func bitIndex(bit int) int {
+	i := 0
+	for bit > 1 {
+		bit >>= 1
+		i++
+	}
+	return i
+}
+
+// matchGreedily settles balances of one currency by repeatedly having the
+// largest debtor pay the largest creditor. Each transfer zeroes at least
+// one of them.
+func matchGreedily(balances []entities.Balance) []entities.Transfer {
+	var debtors, creditors []entities.Balance
+	for _, b := range balances {
+		switch {
+		case b.Amount < 0:
+			debtors = append(debtors, entities.Balance{UserID: b.UserID, Currency: b.Currency, Amount: -b.Amount})
+		case b.Amount > 0:
+			creditors = append(creditors, b)
+		}
+	}
+	largestFirst := func(bs []entities.Balance) {
+		sort.SliceStable(bs, func(i, j int) bool { return bs[i].Amount > bs[j].Amount })
+	}
+
+	var transfers []entities.Transfer
+	for len(debtors) > 0 && len(creditors) > 0 {
+		largestFirst(debtors)
+		largestFirst(creditors)
+		d, c := &debtors[0], &creditors[0]
+		amount := d.Amount
+		if c.Amount < amount {
+			amount = c.Amount
+		}
+		transfers = append(transfers, entities.Transfer{
+			FromUserID: d.UserID,
+			ToUserID:   c.UserID,
+			Amount:     amount,
+			Currency:   d.Currency,
+		})
+		d.Amount -= amount
+		c.Amount -= amount
+		if d.Amount == 0 {
+			debtors = debtors[1:]
+		}
+		if c.Amount == 0 {
+			creditors = creditors[1:]
+		}
+	}
+	return transfers
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_allocate(t *testing.T) {
+	participants := func(ps ...entities.SplitParticipant) []entities.SplitParticipant { return ps }
+
+	tests := []struct {
+		name    string
+		amount  entities.Money
+		req     entities.SplitRequest
+		want    []entities.Money
+		wantErr error
+	}{
+		{
+			name:   "Equal_Remainder",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitEqual, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1"},
+				entities.SplitParticipant{UserID: "user_2"},
+				entities.SplitParticipant{UserID: "user_3"},
+			)},
+			want: []entities.Money{334, 333, 333},
+		},
+		{
+			name:   "Exact",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitExact, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Amount: 250},
+				entities.SplitParticipant{UserID: "user_2", Amount: 750},
+			)},
+			want: []entities.Money{250, 750},
+		},
+		{
+			name:   "Exact_WrongTotal",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitExact, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Amount: 250},
+				entities.SplitParticipant{UserID: "user_2", Amount: 700},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:   "Percentage",
+			amount: 10001,
+			req: entities.SplitRequest{Method: entities.SplitPercentage, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Percentage: 33.33},
+				entities.SplitParticipant{UserID: "user_2", Percentage: 33.33},
+				entities.SplitParticipant{UserID: "user_3", Percentage: 33.34},
+			)},
+			want: []entities.Money{3333, 3333, 3335},
+		},
+		{
+			name:   "Percentage_NotHundred",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitPercentage, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Percentage: 50},
+				entities.SplitParticipant{UserID: "user_2", Percentage: 40},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			// Percentages that wrap around int64 to a total of 10000 basis points.
+			name:   "Percentage_Overflow",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitPercentage, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_2", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_3", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_4", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_5", Percentage: 100},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:   "Percentage_Overflow_OddAmount",
+			amount: 1001,
+			req: entities.SplitRequest{Method: entities.SplitPercentage, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_2", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_3", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_4", Percentage: 4.611686018427388e16},
+				entities.SplitParticipant{UserID: "user_5", Percentage: 100},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:   "Percentage_Negative",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitPercentage, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Percentage: 150},
+				entities.SplitParticipant{UserID: "user_2", Percentage: -50},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:   "Shares",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitShares, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Shares: 2},
+				entities.SplitParticipant{UserID: "user_2", Shares: 1},
+			)},
+			want: []entities.Money{667, 333},
+		},
+		{
+			name:   "Shares_Zero",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitShares, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Shares: 0},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:   "Shares_TooMany",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitShares, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1", Shares: maxSplitShares + 1},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:   "DuplicateParticipant",
+			amount: 1000,
+			req: entities.SplitRequest{Method: entities.SplitEqual, Participants: participants(
+				entities.SplitParticipant{UserID: "user_1"},
+				entities.SplitParticipant{UserID: "user_1"},
+			)},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name:    "UnknownMethod",
+			amount:  1000,
+			req:     entities.SplitRequest{Method: "halves", Participants: participants(entities.SplitParticipant{UserID: "user_1"})},
+			wantErr: ErrInvalidSplit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allocate(tt.amount, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("allocate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_settleUp(t *testing.T) {
+	balance := func(user string, amount entities.Money) entities.Balance {
+		return entities.Balance{UserID: user, Currency: "USD", Amount: amount}
+	}
+
+	tests := []struct {
+		name          string
+		balances      []entities.Balance
+		wantTransfers int
+	}{
+		{
+			name:          "Empty",
+			wantTransfers: 0,
+		},
+		{
+			name:          "TwoMembers",
+			balances:      []entities.Balance{balance("user_1", 500), balance("user_2", -500)},
+			wantTransfers: 1,
+		},
+		{
+			// Greedy matching pays user_2 from user_3 first and needs four
+			// transfers; pairing the equal balances needs three.
+			name: "EqualBalances",
+			balances: []entities.Balance{
+				balance("user_1", 500), balance("user_2", -900), balance("user_3", 400),
+				balance("user_4", 800), balance("user_5", -800),
+			},
+			wantTransfers: 3,
+		},
+		{
+			name: "Currencies",
+			balances: []entities.Balance{
+				balance("user_1", 500), balance("user_2", -500),
+				{UserID: "user_1", Currency: "EUR", Amount: -300}, {UserID: "user_2", Currency: "EUR", Amount: 300},
+			},
+			wantTransfers: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfers := settleUp(tt.balances)
+			if len(transfers) != tt.wantTransfers {
+				t.Errorf("settleUp() made %d transfers, want %d: %v", len(transfers), tt.wantTransfers, transfers)
+			}
+
+			left := make(map[string]entities.Money)
+			for _, b := range tt.balances {
+				left[b.Currency+"/"+b.UserID] += b.Amount
+			}
+			for _, tr := range transfers {
+				if tr.Amount <= 0 {
+					t.Errorf("settleUp() made a transfer of %s", tr.Amount)
+				}
+				left[tr.Currency+"/"+tr.FromUserID] += tr.Amount
+				left[tr.Currency+"/"+tr.ToUserID] -= tr.Amount
+			}
+			for k, v := range left {
+				if v != 0 {
+					t.Errorf("settleUp() left %s at %s", k, v)
+				}
+			}
+		})
+	}
+}
+
+func Test_zeroSumGroups(t *testing.T) {
+	balances := []entities.Balance{
+		{UserID: "user_1", Amount: 700}, {UserID: "user_2", Amount: 300},
+		{UserID: "user_3", Amount: -800}, {UserID: "user_4", Amount: -200},
+		{UserID: "user_5", Amount: 100}, {UserID: "user_6", Amount: -100},
+	}
+	if got := len(zeroSumGroups(balances)); got != 2 {
+		t.Errorf("zeroSumGroups() = %d groups, want 2", got)
+	}
+}
+
+func Test_splitServiceImpl_SetSplits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSplitRepositoryInterface(ctrl)
+	mockExpenses := mocks.NewMockExpenseRepositoryInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", WorkspaceID: "workspace_1"})
+	expense := &entities.Expense{ID: "expense_1", Amount: 900, Currency: "USD"}
+	equal := entities.SplitRequest{Method: entities.SplitEqual, Participants: []entities.SplitParticipant{{UserID: "user_1"}, {UserID: "user_2"}}}
+
+	tests := []struct {
+		name      string
+		req       entities.SplitRequest
+		want      []entities.ExpenseSplit
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name: "SetSplits_Success",
+			req:  equal,
+			want: []entities.ExpenseSplit{
+				{ExpenseID: "expense_1", UserID: "user_1", Amount: 450},
+				{ExpenseID: "expense_1", UserID: "user_2", Amount: 450},
+			},
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(expense, nil)
+				mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(&entities.Workspace{ID: "workspace_1"}, nil)
+				mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_2").Return(&entities.Workspace{ID: "workspace_1"}, nil)
+				mockRepo.EXPECT().SetSplits(gomock.Any(), "expense_1", gomock.Len(2)).Return(nil)
+			},
+		},
+		{
+			name:    "SetSplits_NotMember",
+			req:     equal,
+			wantErr: ErrInvalidSplit,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(expense, nil)
+				mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(&entities.Workspace{ID: "workspace_1"}, nil)
+				mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_2").Return(nil, repository.ErrNotFound)
+			},
+		},
+		{
+			name:    "SetSplits_ExpenseNotFound",
+			req:     equal,
+			wantErr: ErrNotFound,
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(nil, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "SetSplits_Clear",
+			want: []entities.ExpenseSplit{},
+			setupMock: func() {
+				mockExpenses.EXPECT().GetByID(gomock.Any(), "expense_1").Return(expense, nil)
+				mockRepo.EXPECT().SetSplits(gomock.Any(), "expense_1", gomock.Nil()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &splitServiceImpl{repo: mockRepo, expenses: mockExpenses, workspaces: mockWorkspaces}
+			got, err := s.SetSplits(ctx, "expense_1", tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("splitServiceImpl.SetSplits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitServiceImpl.SetSplits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitServiceImpl_RecordSettlement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSplitRepositoryInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", WorkspaceID: "workspace_1"})
+
+	tests := []struct {
+		name      string
+		st        entities.Settlement
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name: "RecordSettlement_Success",
+			st:   entities.Settlement{ToUserID: "user_2", Amount: 500, Currency: "usd"},
+			setupMock: func() {
+				mockWorkspaces.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_2").Return(&entities.Workspace{ID: "workspace_1"}, nil)
+				mockRepo.EXPECT().CreateSettlement(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "RecordSettlement_ToSelf",
+			st:        entities.Settlement{ToUserID: "user_1", Amount: 500, Currency: "USD"},
+			wantErr:   ErrInvalidSplit,
+			setupMock: func() {},
+		},
+		{
+			name:      "RecordSettlement_NotPositive",
+			st:        entities.Settlement{ToUserID: "user_2", Amount: 0, Currency: "USD"},
+			wantErr:   ErrInvalidSplit,
+			setupMock: func() {},
+		},
+		{
+			name:      "RecordSettlement_InvalidCurrency",
+			st:        entities.Settlement{ToUserID: "user_2", Amount: 500, Currency: "dollars"},
+			wantErr:   ErrInvalidCurrency,
+			setupMock: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &splitServiceImpl{repo: mockRepo, workspaces: mockWorkspaces}
+			err := s.RecordSettlement(ctx, &tt.st)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("splitServiceImpl.RecordSettlement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (tt.st.FromUserID != "user_1" || tt.st.Currency != "USD" || tt.st.ID == "") {
+				t.Errorf("splitServiceImpl.RecordSettlement() recorded %+v", tt.st)
+			}
+		})
+	}
+}
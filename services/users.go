@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+var (
+	// ErrInvalidUser is returned when a user fails validation.
+	ErrInvalidUser = errors.New("invalid user")
+	// ErrEmailTaken is returned when another user has the same email.
+	ErrEmailTaken = errors.New("email already registered")
+	// ErrUnverifiedEmail is returned when registering a user whose token
+	// carries no verified email.
+	ErrUnverifiedEmail = errors.New("email not verified")
+	// ErrUnauthenticated is returned when an operation needs an
+	// authenticated user and the context has none.
+	ErrUnauthenticated = auth.ErrUnauthenticated
+)
+
+// UserService defines the interface for user-related operations.
+type UserService interface {
+	ProvisionUser(ctx context.Context, p auth.Principal) (*entities.User, error)
+	GetUserByID(ctx context.Context, id string) (*entities.User, error)
+	CurrentUser(ctx context.Context) (*entities.User, error)
+	SetUserRole(ctx context.Context, id string, role auth.Role) error
+}
+
+type userServiceImpl struct {
+	repo  repository.UserRepositoryInterface
+	units repository.UnitOfWorkFactory
+}
+
+// NewUserService creates a new instance of UserService. Users are
+// registered with their personal workspace in a unit of work from units.
+func NewUserService(repo repository.UserRepositoryInterface, units repository.UnitOfWorkFactory) UserService {
+	return &userServiceImpl{repo: repo, units: units}
+}
+
+// ProvisionUser registers the user named by a verified token the first
+// time it is seen, under the ID of its sub claim and with the email its
+// issuer verified, so users own their ID and the email members are added
+// by. Users start as members across workspaces; instance admins are made
+// by other instance admins or, for the first one, with the grant-admin
+// command. Users are the admins of their personal workspace, created along
+// with them in a single transaction.
+func (s *userServiceImpl) ProvisionUser(ctx context.Context, p auth.Principal) (*entities.User, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(p.Email))
+	if err != nil || addr.Name != "" {
+		return nil, fmt.Errorf("%w: the token of %s carries no verified email", ErrUnverifiedEmail, p.UserID)
+	}
+	u := &entities.User{
+		ID:           p.UserID,
+		Email:        strings.ToLower(addr.Address),
+		Role:         string(auth.RoleMember),
+		DateCreation: time.Now().Unix(),
+	}
+
+	_, err = s.repo.GetByEmail(ctx, u.Email)
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEmailTaken, u.Email)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.create(ctx, u); err != nil {
+		if !errors.Is(err, repository.ErrUserExists) {
+			return nil, err
+		}
+		// Another request of the user provisioned it first.
+		if existing, err := s.repo.GetByID(ctx, u.ID); err == nil {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrEmailTaken, u.Email)
+	}
+	return u, nil
+}
+
+// create saves u with its personal workspace in a unit of work.
+func (s *userServiceImpl) create(ctx context.Context, u *entities.User) error {
+	uow, err := s.units.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer uow.Rollback()
+
+	if err := uow.Users().Create(ctx, u); err != nil {
+		return err
+	}
+	err = uow.Workspaces().Create(ctx, &entities.Workspace{
+		ID:           generateID("workspace"),
+		Name:         personalWorkspaceName,
+		OwnerID:      u.ID,
+		DateCreation: u.DateCreation,
+	})
+	if err != nil {
+		return err
+	}
+	return uow.Commit()
+}
+
+// GetUserByID retrieves a user by its ID.
+func (s *userServiceImpl) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CurrentUser retrieves the user authenticated in ctx.
+func (s *userServiceImpl) CurrentUser(ctx context.Context) (*entities.User, error) {
+	id, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// SetUserRole changes the instance role of a user, which only decides who
+// may change what every workspace shares; roles in a workspace are set
+// with WorkspaceService.SetMemberRole.
+func (s *userServiceImpl) SetUserRole(ctx context.Context, id string, role auth.Role) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: role must be viewer, member, approver or admin", ErrInvalidUser)
+	}
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.SetRole(ctx, id, string(role))
+}
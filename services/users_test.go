@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+var errWorkspaceFailed = errors.New("workspace failed")
+
+func Test_userServiceImpl_ProvisionUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockWorkspaces := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	mockUnit := mocks.NewMockUnitOfWork(ctrl)
+	mockUnits := mocks.NewMockUnitOfWorkFactory(ctrl)
+	inUnit := func() {
+		mockUnits.EXPECT().Begin(gomock.Any()).Return(mockUnit, nil)
+		mockUnit.EXPECT().Users().Return(mockRepo).AnyTimes()
+		mockUnit.EXPECT().Workspaces().Return(mockWorkspaces).AnyTimes()
+		mockUnit.EXPECT().Rollback().Return(nil)
+	}
+
+	tests := []struct {
+		name      string
+		principal auth.Principal
+		want      *entities.User
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name:      "ProvisionUser_Success",
+			principal: auth.Principal{UserID: "auth0|ana", Email: " Ana@Example.com "},
+			want:      &entities.User{ID: "auth0|ana", Email: "ana@example.com", Role: "member"},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "ana@example.com").Return(nil, repository.ErrNotFound)
+				inUnit()
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockWorkspaces.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, w *entities.Workspace) error {
+					if w.OwnerID != "auth0|ana" || w.Name != personalWorkspaceName {
+						t.Errorf("personal workspace = %+v", w)
+					}
+					return nil
+				})
+				mockUnit.EXPECT().Commit().Return(nil)
+			},
+		},
+		{
+			// The user is rolled back with the workspace, so the next
+			// request of the user retries.
+			name:      "ProvisionUser_WorkspaceFailed",
+			principal: auth.Principal{UserID: "auth0|ana", Email: "ana@example.com"},
+			wantErr:   errWorkspaceFailed,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "ana@example.com").Return(nil, repository.ErrNotFound)
+				inUnit()
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				mockWorkspaces.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errWorkspaceFailed)
+			},
+		},
+		{
+			name:      "ProvisionUser_ConcurrentRequest",
+			principal: auth.Principal{UserID: "auth0|ana", Email: "ana@example.com"},
+			want:      &entities.User{ID: "auth0|ana", Email: "ana@example.com", Role: "admin"},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "ana@example.com").Return(nil, repository.ErrNotFound)
+				inUnit()
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrUserExists)
+				mockRepo.EXPECT().GetByID(gomock.Any(), "auth0|ana").Return(&entities.User{ID: "auth0|ana", Email: "ana@example.com", Role: "admin"}, nil)
+			},
+		},
+		{
+			name:      "ProvisionUser_EmailTaken",
+			principal: auth.Principal{UserID: "auth0|ana", Email: "ana@example.com"},
+			wantErr:   ErrEmailTaken,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(gomock.Any(), "ana@example.com").Return(&entities.User{ID: "user_1"}, nil)
+			},
+		},
+		{
+			name:      "ProvisionUser_UnverifiedEmail",
+			principal: auth.Principal{UserID: "auth0|ana"},
+			wantErr:   ErrUnverifiedEmail,
+			setupMock: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := NewUserService(mockRepo, mockUnits)
+			got, err := s.ProvisionUser(context.TODO(), tt.principal)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("userServiceImpl.ProvisionUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && (got.ID != tt.want.ID || got.Email != tt.want.Email || got.Role != tt.want.Role) {
+				t.Errorf("userServiceImpl.ProvisionUser() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userServiceImpl_SetUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+
+	tests := []struct {
+		name      string
+		role      auth.Role
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name: "SetUserRole_Success",
+			role: auth.RoleApprover,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "user_1").Return(&entities.User{ID: "user_1"}, nil)
+				mockRepo.EXPECT().SetRole(gomock.Any(), "user_1", "approver").Return(nil)
+			},
+		},
+		{
+			name:      "SetUserRole_UnknownRole",
+			role:      "owner",
+			wantErr:   ErrInvalidUser,
+			setupMock: func() {},
+		},
+		{
+			name:    "SetUserRole_UnknownUser",
+			role:    auth.RoleViewer,
+			wantErr: repository.ErrNotFound,
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "user_1").Return(nil, repository.ErrNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := NewUserService(mockRepo, nil)
+			err := s.SetUserRole(context.TODO(), "user_1", tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userServiceImpl.SetUserRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
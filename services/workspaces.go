@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+)
+
+var (
+	// ErrInvalidWorkspace is returned when a workspace or a change to its
+	// members fails validation.
+	ErrInvalidWorkspace = errors.New("invalid workspace")
+	// ErrUnknownUser is returned when a member to add is not registered.
+	ErrUnknownUser = errors.New("unknown user")
+	// ErrNoWorkspace is returned when an operation acts on the data of a
+	// workspace and the context selects none.
+	ErrNoWorkspace = auth.ErrNoWorkspace
+)
+
+// personalWorkspaceName names the workspace every user gets on
+// registration.
+const personalWorkspaceName = "Personal"
+
+// WorkspaceService defines the interface for workspaces and their members.
+type WorkspaceService interface {
+	CreateWorkspace(ctx context.Context, w *entities.Workspace) error
+	ListWorkspaces(ctx context.Context) ([]*entities.Workspace, error)
+	SelectWorkspace(ctx context.Context, id string) (*entities.Workspace, error)
+	ListMembers(ctx context.Context, workspaceID string) ([]*entities.WorkspaceMember, error)
+	AddMember(ctx context.Context, workspaceID, email string) (*entities.WorkspaceMember, error)
+	SetMemberRole(ctx context.Context, workspaceID, userID string, role auth.Role) error
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+}
+
+type workspaceServiceImpl struct {
+	repo  repository.WorkspaceRepositoryInterface
+	users repository.UserRepositoryInterface
+}
+
+// NewWorkspaceService creates a new instance of WorkspaceService.
+func NewWorkspaceService(repo repository.WorkspaceRepositoryInterface, users repository.UserRepositoryInterface) WorkspaceService {
+	return &workspaceServiceImpl{repo: repo, users: users}
+}
+
+// CreateWorkspace creates a new workspace owned by the user in ctx, who
+// becomes its first member and an admin of it.
+func (s *workspaceServiceImpl) CreateWorkspace(ctx context.Context, w *entities.Workspace) error {
+	owner, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	w.Name = strings.TrimSpace(w.Name)
+	if w.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWorkspace)
+	}
+
+	w.ID = generateID("workspace")
+	w.OwnerID = owner
+	w.DateCreation = time.Now().Unix()
+	w.Role = string(auth.RoleAdmin)
+
+	return s.repo.Create(ctx, w)
+}
+
+// ListWorkspaces retrieves the workspaces of the user in ctx, in the order
+// they were joined.
+func (s *workspaceServiceImpl) ListWorkspaces(ctx context.Context) ([]*entities.Workspace, error) {
+	user, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListForMember(ctx, user)
+}
+
+// SelectWorkspace returns the workspace with the given ID if the user in
+// ctx is a member of it, and otherwise reports it as not found. Without an
+// ID it returns the first workspace the user joined, or ErrNoWorkspace.
+func (s *workspaceServiceImpl) SelectWorkspace(ctx context.Context, id string) (*entities.Workspace, error) {
+	user, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id != "" {
+		return s.member(ctx, id)
+	}
+
+	workspaces, err := s.repo.ListForMember(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(workspaces) == 0 {
+		return nil, ErrNoWorkspace
+	}
+	return workspaces[0], nil
+}
+
+// ListMembers retrieves the members of a workspace the user in ctx belongs
+// to.
+func (s *workspaceServiceImpl) ListMembers(ctx context.Context, workspaceID string) ([]*entities.WorkspaceMember, error) {
+	if _, err := s.member(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, workspaceID)
+}
+
+// AddMember adds the user registered with email, which the issuer of its
+// token verified, to a workspace as a member. Only the admins of the
+// workspace may add members.
+func (s *workspaceServiceImpl) AddMember(ctx context.Context, workspaceID, email string) (*entities.WorkspaceMember, error) {
+	if _, err := s.manage(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	u, err := s.users.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownUser, email)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &entities.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      u.ID,
+		Email:       u.Email,
+		Name:        u.Name,
+		Role:        string(auth.RoleMember),
+		DateJoined:  time.Now().Unix(),
+	}
+	if err := s.repo.AddMember(ctx, workspaceID, u.ID, m.DateJoined); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetMemberRole changes the role of a member of a workspace. Only the
+// admins of the workspace may change roles, and the owner stays an admin.
+func (s *workspaceServiceImpl) SetMemberRole(ctx context.Context, workspaceID, userID string, role auth.Role) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: role must be viewer, member, approver or admin", ErrInvalidWorkspace)
+	}
+	w, err := s.manage(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if userID == w.OwnerID && role != auth.RoleAdmin {
+		return fmt.Errorf("%w: the owner stays an admin", ErrInvalidWorkspace)
+	}
+	return s.repo.SetMemberRole(ctx, workspaceID, userID, string(role))
+}
+
+// RemoveMember removes a user from a workspace. Members may leave on their
+// own; removing anybody else takes an admin of the workspace. The owner
+// cannot be removed.
+func (s *workspaceServiceImpl) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	user, err := auth.UserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var w *entities.Workspace
+	if userID == user {
+		w, err = s.member(ctx, workspaceID)
+	} else {
+		w, err = s.manage(ctx, workspaceID)
+	}
+	if err != nil {
+		return err
+	}
+	if userID == w.OwnerID {
+		return fmt.Errorf("%w: the owner cannot be removed", ErrInvalidWorkspace)
+	}
+
+	return s.repo.RemoveMember(ctx, workspaceID, userID)
+}
+
+// manage returns a workspace of the user in ctx if the user may manage its
+// members. That depends on the role of the user in this workspace, which
+// need not be the one the request selected, so it is checked here rather
+// than with auth.Authorize.
+func (s *workspaceServiceImpl) manage(ctx context.Context, workspaceID string) (*entities.Workspace, error) {
+	w, err := s.member(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.Role(w.Role).Can(auth.PermManageMembers) {
+		return nil, fmt.Errorf("%w: only the admins of the workspace may manage its members", auth.ErrForbidden)
+	}
+	return w, nil
+}
+
+// member returns a workspace if the user in ctx is one of its members, and
+// otherwise reports it as not found.
+func (s *workspaceServiceImpl) member(ctx context.Context, workspaceID string) (*entities.Workspace, error) {
+	user, err := auth.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetForMember(ctx, workspaceID, user)
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/demo-talent/auth"
+	"github.com/demo-talent/entities"
+	"github.com/demo-talent/repository"
+	"github.com/demo-talent/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_workspaceServiceImpl_SelectWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1"})
+
+	tests := []struct {
+		name      string
+		id        string
+		want      string
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name: "SelectWorkspace_Member",
+			id:   "workspace_2",
+			want: "workspace_2",
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_2", "user_1").Return(&entities.Workspace{ID: "workspace_2"}, nil)
+			},
+		},
+		{
+			name:    "SelectWorkspace_NotMember",
+			id:      "workspace_3",
+			wantErr: repository.ErrNotFound,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_3", "user_1").Return(nil, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "SelectWorkspace_Default",
+			want: "workspace_1",
+			setupMock: func() {
+				mockRepo.EXPECT().ListForMember(gomock.Any(), "user_1").Return([]*entities.Workspace{{ID: "workspace_1"}, {ID: "workspace_2"}}, nil)
+			},
+		},
+		{
+			name:    "SelectWorkspace_NoWorkspace",
+			wantErr: ErrNoWorkspace,
+			setupMock: func() {
+				mockRepo.EXPECT().ListForMember(gomock.Any(), "user_1").Return([]*entities.Workspace{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &workspaceServiceImpl{repo: mockRepo}
+			got, err := s.SelectWorkspace(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("workspaceServiceImpl.SelectWorkspace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID != tt.want {
+				t.Errorf("workspaceServiceImpl.SelectWorkspace() = %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func Test_workspaceServiceImpl_AddMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	mockUsers := mocks.NewMockUserRepositoryInterface(ctrl)
+	as := func(role auth.Role) *entities.Workspace {
+		return &entities.Workspace{ID: "workspace_1", OwnerID: "user_owner", Role: string(role)}
+	}
+
+	tests := []struct {
+		name      string
+		principal auth.Principal
+		email     string
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name:      "AddMember_Owner",
+			principal: auth.Principal{UserID: "user_owner", Role: auth.RoleMember},
+			email:     " Bea@Example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_owner").Return(as(auth.RoleAdmin), nil)
+				mockUsers.EXPECT().GetByEmail(gomock.Any(), "bea@example.com").Return(&entities.User{ID: "user_bea", Email: "bea@example.com"}, nil)
+				mockRepo.EXPECT().AddMember(gomock.Any(), "workspace_1", "user_bea", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "AddMember_WorkspaceAdmin",
+			principal: auth.Principal{UserID: "user_admin", Role: auth.RoleViewer},
+			email:     "bea@example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_admin").Return(as(auth.RoleAdmin), nil)
+				mockUsers.EXPECT().GetByEmail(gomock.Any(), "bea@example.com").Return(&entities.User{ID: "user_bea"}, nil)
+				mockRepo.EXPECT().AddMember(gomock.Any(), "workspace_1", "user_bea", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "AddMember_AdminElsewhere",
+			principal: auth.Principal{UserID: "user_admin", Role: auth.RoleAdmin, InstanceRole: auth.RoleAdmin},
+			email:     "bea@example.com",
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_admin").Return(as(auth.RoleMember), nil)
+			},
+		},
+		{
+			name:      "AddMember_Approver",
+			principal: auth.Principal{UserID: "user_member", Role: auth.RoleApprover},
+			email:     "bea@example.com",
+			wantErr:   auth.ErrForbidden,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_member").Return(as(auth.RoleApprover), nil)
+			},
+		},
+		{
+			name:      "AddMember_UnknownUser",
+			principal: auth.Principal{UserID: "user_owner"},
+			email:     "nobody@example.com",
+			wantErr:   ErrUnknownUser,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_owner").Return(as(auth.RoleAdmin), nil)
+				mockUsers.EXPECT().GetByEmail(gomock.Any(), "nobody@example.com").Return(nil, repository.ErrNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &workspaceServiceImpl{repo: mockRepo, users: mockUsers}
+			ctx := auth.WithPrincipal(context.TODO(), tt.principal)
+			_, err := s.AddMember(ctx, "workspace_1", tt.email)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("workspaceServiceImpl.AddMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_workspaceServiceImpl_SetMemberRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	admin := &entities.Workspace{ID: "workspace_1", OwnerID: "user_owner", Role: string(auth.RoleAdmin)}
+	member := &entities.Workspace{ID: "workspace_1", OwnerID: "user_owner", Role: string(auth.RoleMember)}
+
+	tests := []struct {
+		name      string
+		userID    string
+		role      auth.Role
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name:   "SetMemberRole_Admin",
+			userID: "user_bea",
+			role:   auth.RoleApprover,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(admin, nil)
+				mockRepo.EXPECT().SetMemberRole(gomock.Any(), "workspace_1", "user_bea", "approver").Return(nil)
+			},
+		},
+		{
+			name:    "SetMemberRole_NotAdmin",
+			userID:  "user_1",
+			role:    auth.RoleAdmin,
+			wantErr: auth.ErrForbidden,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(member, nil)
+			},
+		},
+		{
+			name:    "SetMemberRole_DemoteOwner",
+			userID:  "user_owner",
+			role:    auth.RoleViewer,
+			wantErr: ErrInvalidWorkspace,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_1").Return(admin, nil)
+			},
+		},
+		{
+			name:      "SetMemberRole_UnknownRole",
+			userID:    "user_bea",
+			role:      "owner",
+			wantErr:   ErrInvalidWorkspace,
+			setupMock: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &workspaceServiceImpl{repo: mockRepo}
+			ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: "user_1", InstanceRole: auth.RoleAdmin})
+			err := s.SetMemberRole(ctx, "workspace_1", tt.userID, tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("workspaceServiceImpl.SetMemberRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_workspaceServiceImpl_RemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepositoryInterface(ctrl)
+	team := &entities.Workspace{ID: "workspace_1", OwnerID: "user_owner"}
+
+	tests := []struct {
+		name      string
+		user      string
+		remove    string
+		wantErr   error
+		setupMock func()
+	}{
+		{
+			name:   "RemoveMember_Leave",
+			user:   "user_member",
+			remove: "user_member",
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_member").Return(team, nil)
+				mockRepo.EXPECT().RemoveMember(gomock.Any(), "workspace_1", "user_member").Return(nil)
+			},
+		},
+		{
+			name:    "RemoveMember_Owner",
+			user:    "user_owner",
+			remove:  "user_owner",
+			wantErr: ErrInvalidWorkspace,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_owner").Return(team, nil)
+			},
+		},
+		{
+			name:    "RemoveMember_Other",
+			user:    "user_member",
+			remove:  "user_other",
+			wantErr: auth.ErrForbidden,
+			setupMock: func() {
+				mockRepo.EXPECT().GetForMember(gomock.Any(), "workspace_1", "user_member").Return(team, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			s := &workspaceServiceImpl{repo: mockRepo}
+			ctx := auth.WithPrincipal(context.TODO(), auth.Principal{UserID: tt.user, Role: auth.RoleMember})
+			err := s.RemoveMember(ctx, "workspace_1", tt.remove)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("workspaceServiceImpl.RemoveMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
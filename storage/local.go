@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore is a BlobStore keeping each blob in a file under a root
+// directory, sharded by the first two characters of its key.
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore creates a LocalStore under root, creating the directory if
+// needed.
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating blob directory: %w", err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// Put writes the blob to a temporary file and renames it into place, so a
+// failed upload never leaves a partial blob under key.
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		log.Printf("Error storing blob: %v", err)
+		return fmt.Errorf("error storing blob: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		log.Printf("Error storing blob: %v", err)
+		return fmt.Errorf("error storing blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		log.Printf("Error storing blob: %v", err)
+		return fmt.Errorf("error storing blob: %w", err)
+	}
+	return nil
+}
+
+// Get opens the blob stored under key.
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, key)
+		}
+		log.Printf("Error reading blob: %v", err)
+		return nil, fmt.Errorf("error reading blob: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes the blob stored under key. Deleting a missing blob is not
+// an error.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Error deleting blob: %v", err)
+		return fmt.Errorf("error deleting blob: %w", err)
+	}
+	return nil
+}
+
+// path maps key to its file, rejecting keys that could escape the root.
+func (s *LocalStore) path(key string) (string, error) {
+	if len(key) < 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return filepath.Join(s.root, key[:2], key), nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	ctx := context.TODO()
+	key := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	if err := s.Put(ctx, key, strings.NewReader("receipt")); err != nil {
+		t.Fatalf("LocalStore.Put() error = %v", err)
+	}
+	// The key identifies the content, so the stored blob is kept.
+	if err := s.Put(ctx, key, strings.NewReader("other")); err != nil {
+		t.Fatalf("LocalStore.Put() error = %v", err)
+	}
+
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("LocalStore.Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "receipt" {
+		t.Errorf("LocalStore.Get() = %q, want %q", data, "receipt")
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("LocalStore.Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("LocalStore.Get() after Delete error = %v, want ErrBlobNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("LocalStore.Delete() of a missing blob error = %v", err)
+	}
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	for _, key := range []string{"", "ab", "../../etc/passwd", "abc/def", "abc.."} {
+		if err := s.Put(context.TODO(), key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("LocalStore.Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Package storage keeps the bytes of files such as receipts out of the
+// database, behind the BlobStore interface.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned when no blob is stored under a key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrInvalidKey is returned when a key cannot be stored by a BlobStore.
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore stores immutable blobs by key. Keys identify their content, so
+// putting a key that is already stored keeps the existing blob.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}